@@ -55,7 +55,7 @@ func runActivities(cmd *cobra.Command, args []string) {
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 	fmt.Fprintln(w, "ID\tACTION\tMESSAGE\tDATE")
 	for _, a := range activities {
-		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", a.ID, a.Action, a.Message, a.CreatedAt)
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", a.ID, a.Action, a.Message, localTime(a.CreatedAt))
 	}
 	w.Flush()
 }
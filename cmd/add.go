@@ -0,0 +1,181 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+	"github.com/cloudmanic/skyclerk-cli/internal/journal"
+	"github.com/cloudmanic/skyclerk-cli/internal/quickadd"
+	"github.com/spf13/cobra"
+)
+
+// addCmd creates a ledger entry from a one-line description.
+var addCmd = &cobra.Command{
+	Use:   "add \"<amount> <contact> <category> [#label...] [date] [note: text]\"",
+	Short: "Add a ledger entry from one line",
+	Long: `Add a ledger entry described on one line, for example:
+
+  skyclerk add "-4.50 Starbucks Meals #client-x yesterday note: team sync"
+
+The line holds:
+
+  amount      -4.50, +1250 or 12; an unsigned amount takes the category's sign
+  contact     a contact's full name, in any case
+  category    a category's full name, in any case
+  #label      any number of labels; bare label names work too
+  date        any date phrase (see 'skyclerk help dates'); today if none
+  note: ...   everything after "note:" is the note
+
+The contact, category and label names can come in any order. The parsed
+entry is shown and created after confirmation.`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  runAdd,
+
+	// A negative amount such as -4.50 would be read as a shorthand flag, so
+	// flags are parsed by addArgs instead.
+	DisableFlagParsing: true,
+}
+
+// negativeAmount matches arguments that start with a negative amount.
+var negativeAmount = regexp.MustCompile(`^-[$.\d]`)
+
+func init() {
+	addCmd.Flags().BoolP("yes", "y", false, "Don't ask for confirmation")
+	addCmd.Flags().Bool("dry-run", false, "Show the parsed entry without creating it")
+
+	rootCmd.AddCommand(addCmd)
+}
+
+// runAdd parses the line, looks up its names, shows the entry and creates it.
+func runAdd(cmd *cobra.Command, args []string) {
+	args = addArgs(cmd, args)
+	yes, _ := cmd.Flags().GetBool("yes")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	client := newClient()
+
+	entry, err := quickadd.Parse(strings.Join(args, " "), newDateParser(client))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
+	contacts, err := client.GetContacts(nil)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error fetching contacts:", err)
+		os.Exit(1)
+	}
+	categories, err := client.GetCategories(nil)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error fetching categories:", err)
+		os.Exit(1)
+	}
+	labels, err := client.GetLabels(nil)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error fetching labels:", err)
+		os.Exit(1)
+	}
+
+	ledger, err := entry.Resolve(contacts, categories, labels)
+	if err != nil {
+		for _, line := range strings.Split(err.Error(), "\n") {
+			fmt.Fprintln(os.Stderr, "Error:", line)
+		}
+		os.Exit(1)
+	}
+
+	// Keep stdout clean for JSON by echoing the entry to stderr.
+	out := os.Stdout
+	if outputFormat == "json" {
+		out = os.Stderr
+	}
+	printQuickEntry(out, ledger)
+
+	if dryRun {
+		return
+	}
+	if !yes && !confirm("Create this entry?") {
+		fmt.Fprintln(os.Stderr, "Nothing was created.")
+		return
+	}
+
+	category := ledger.Category
+	category.Type = categoryTypeToAPI(category.Type)
+	req := &api.LedgerCreateRequest{
+		Amount:   ledger.Amount,
+		Date:     formatDateForAPI(ledger.Date),
+		Contact:  ledger.Contact,
+		Category: category,
+		Labels:   ledger.Labels,
+		Note:     ledger.Note,
+	}
+
+	created, err := client.CreateLedger(req)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+	recordChange(client, journal.KindLedger, journal.ActionCreate, created.ID, nil, created)
+
+	if outputFormat == "json" {
+		printJSON(created)
+		return
+	}
+
+	fmt.Printf("Created ledger entry %d (%.2f on %s)\n", created.ID, created.Amount, localDay(created.Date))
+}
+
+// addArgs parses the command's flags, setting aside arguments that start with
+// a negative amount, and returns the words of the entry.
+func addArgs(cmd *cobra.Command, args []string) []string {
+	var amounts, rest []string
+	for _, arg := range args {
+		if negativeAmount.MatchString(arg) {
+			amounts = append(amounts, arg)
+			continue
+		}
+		rest = append(rest, arg)
+	}
+
+	// InheritedFlags merges the global flags into the command's flag set.
+	cmd.InheritedFlags()
+	if err := cmd.Flags().Parse(rest); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+	if help, _ := cmd.Flags().GetBool("help"); help {
+		cmd.Help()
+		os.Exit(0)
+	}
+
+	words := append(amounts, cmd.Flags().Args()...)
+	if len(words) == 0 {
+		fmt.Fprintln(os.Stderr, `Error: describe the entry, e.g. skyclerk add "-4.50 Starbucks Meals"`)
+		os.Exit(1)
+	}
+
+	return words
+}
+
+// printQuickEntry shows the entry a quick-add line was read as.
+func printQuickEntry(out *os.File, l api.Ledger) {
+	fmt.Fprintf(out, "Amount:    %.2f\n", l.Amount)
+	fmt.Fprintf(out, "Date:      %s\n", l.Date)
+	fmt.Fprintf(out, "Contact:   %s\n", l.Contact.Name)
+	fmt.Fprintf(out, "Category:  %s\n", l.Category.Name)
+	if len(l.Labels) > 0 {
+		names := make([]string, len(l.Labels))
+		for i, label := range l.Labels {
+			names[i] = label.Name
+		}
+		fmt.Fprintf(out, "Labels:    %s\n", strings.Join(names, ", "))
+	}
+	if l.Note != "" {
+		fmt.Fprintf(out, "Note:      %s\n", l.Note)
+	}
+}
@@ -0,0 +1,145 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+	"github.com/cloudmanic/skyclerk-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// cacheCmd is the parent command for the reference data cache.
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the local cache of contacts, categories, labels and account info",
+}
+
+// cacheClearCmd removes every cached entry.
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove all cached data",
+	Run:   runCacheClear,
+}
+
+// cacheStatusCmd shows what is cached and how fresh it is.
+var cacheStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show cached data per account and resource",
+	Run:   runCacheStatus,
+}
+
+// init registers the cache commands.
+func init() {
+	cacheCmd.AddCommand(cacheClearCmd)
+	cacheCmd.AddCommand(cacheStatusCmd)
+	rootCmd.AddCommand(cacheCmd)
+}
+
+// cacheStatusRow summarizes the cached entries of one resource for one account.
+type cacheStatusRow struct {
+	BaseURL   string    `json:"base_url"`
+	AccountID uint      `json:"account_id"`
+	Resource  string    `json:"resource"`
+	Entries   int       `json:"entries"`
+	Fresh     int       `json:"fresh"`
+	Bytes     int       `json:"bytes"`
+	Newest    time.Time `json:"newest"`
+}
+
+// loadCache opens the cache using the configured TTL, falling back to defaults when not logged in.
+func loadCache() *api.Cache {
+	cfg, err := config.Load()
+	if err != nil {
+		cfg = &config.Config{}
+	}
+
+	cache, err := openCache(cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
+	return cache
+}
+
+// runCacheClear removes the cache directory.
+func runCacheClear(cmd *cobra.Command, args []string) {
+	cache := loadCache()
+
+	if err := cache.Clear(); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("Cache cleared.")
+}
+
+// runCacheStatus lists cached resources grouped by API URL and account.
+func runCacheStatus(cmd *cobra.Command, args []string) {
+	cache := loadCache()
+
+	entries, err := cache.Entries()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
+	groups := map[string]*cacheStatusRow{}
+	for i := range entries {
+		e := &entries[i]
+		key := fmt.Sprintf("%s|%d|%s", e.BaseURL, e.AccountID, e.Group)
+		row, ok := groups[key]
+		if !ok {
+			row = &cacheStatusRow{BaseURL: e.BaseURL, AccountID: e.AccountID, Resource: e.Group}
+			groups[key] = row
+		}
+
+		row.Entries++
+		row.Bytes += len(e.Body)
+		if cache.Fresh(e) {
+			row.Fresh++
+		}
+		if e.StoredAt.After(row.Newest) {
+			row.Newest = e.StoredAt
+		}
+	}
+
+	rows := make([]cacheStatusRow, 0, len(groups))
+	for _, row := range groups {
+		rows = append(rows, *row)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].AccountID != rows[j].AccountID {
+			return rows[i].AccountID < rows[j].AccountID
+		}
+		return rows[i].Resource < rows[j].Resource
+	})
+
+	if outputFormat == "json" {
+		printJSON(rows)
+		return
+	}
+
+	fmt.Printf("Directory: %s\n", cache.Dir())
+	fmt.Printf("TTL:       %s\n\n", cache.TTL())
+
+	if len(rows) == 0 {
+		fmt.Println("Cache is empty.")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ACCOUNT\tAPI URL\tRESOURCE\tENTRIES\tFRESH\tBYTES\tUPDATED")
+	for _, r := range rows {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%d\t%d\t%s\n",
+			r.AccountID, r.BaseURL, r.Resource, r.Entries, r.Fresh, r.Bytes, r.Newest.Local().Format("2006-01-02 15:04:05"))
+	}
+	w.Flush()
+}
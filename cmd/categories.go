@@ -10,6 +10,8 @@ import (
 	"text/tabwriter"
 
 	"github.com/cloudmanic/skyclerk-cli/internal/api"
+	"github.com/cloudmanic/skyclerk-cli/internal/conflict"
+	"github.com/cloudmanic/skyclerk-cli/internal/journal"
 	"github.com/spf13/cobra"
 )
 
@@ -68,6 +70,7 @@ func init() {
 	// Update flags.
 	categoriesUpdateCmd.Flags().String("name", "", "Category name")
 	categoriesUpdateCmd.Flags().String("type", "", "Category type: 1=expense, 2=income")
+	addConflictFlags(categoriesUpdateCmd, false)
 
 	categoriesCmd.AddCommand(categoriesListCmd)
 	categoriesCmd.AddCommand(categoriesGetCmd)
@@ -111,21 +114,25 @@ func runCategoriesGet(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
+	// Read it from the server so the version remembered for later updates is current.
+	client.Invalidate(fmt.Sprintf("/categories/%d", id))
 	category, err := client.GetCategory(uint(id))
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "Error:", err)
 		os.Exit(1)
 	}
+	rememberVersion(client, journal.KindCategory, category.ID, category)
 
 	if outputFormat == "json" {
 		printJSON(category)
 		return
 	}
 
-	fmt.Printf("ID:    %d\n", category.ID)
-	fmt.Printf("Name:  %s\n", category.Name)
-	fmt.Printf("Type:  %s\n", capitalizeType(category.Type))
-	fmt.Printf("Count: %d\n", category.Count)
+	fmt.Printf("ID:      %d\n", category.ID)
+	fmt.Printf("Name:    %s\n", category.Name)
+	fmt.Printf("Type:    %s\n", capitalizeType(category.Type))
+	fmt.Printf("Count:   %d\n", category.Count)
+	fmt.Printf("Version: %s\n", conflict.Hash(category))
 }
 
 // runCategoriesCreate creates a new category from flags.
@@ -143,6 +150,7 @@ func runCategoriesCreate(cmd *cobra.Command, args []string) {
 		fmt.Fprintln(os.Stderr, "Error:", err)
 		os.Exit(1)
 	}
+	recordChange(client, journal.KindCategory, journal.ActionCreate, category.ID, nil, category)
 
 	if outputFormat == "json" {
 		printJSON(category)
@@ -162,6 +170,15 @@ func runCategoriesUpdate(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
+	// Skip the cache so the conflict check sees other people's edits.
+	client.Invalidate(fmt.Sprintf("/categories/%d", id))
+	before, err := client.GetCategory(uint(id))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+	checkUnmodified(cmd, client, journal.KindCategory, before.ID, before, "")
+
 	req := &api.CategoryUpdateRequest{}
 
 	if cmd.Flags().Changed("name") {
@@ -178,6 +195,7 @@ func runCategoriesUpdate(cmd *cobra.Command, args []string) {
 		fmt.Fprintln(os.Stderr, "Error:", err)
 		os.Exit(1)
 	}
+	recordChange(client, journal.KindCategory, journal.ActionUpdate, category.ID, before, category)
 
 	if outputFormat == "json" {
 		printJSON(category)
@@ -197,10 +215,17 @@ func runCategoriesDelete(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
+	before, err := client.GetCategory(uint(id))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
 	if err := client.DeleteCategory(uint(id)); err != nil {
 		fmt.Fprintln(os.Stderr, "Error:", err)
 		os.Exit(1)
 	}
+	recordChange(client, journal.KindCategory, journal.ActionDelete, uint(id), before, nil)
 
 	fmt.Printf("Deleted category %d\n", id)
 }
@@ -6,8 +6,11 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/cloudmanic/skyclerk-cli/internal/config"
+	"github.com/cloudmanic/skyclerk-cli/internal/dates"
 	"github.com/spf13/cobra"
 )
 
@@ -31,10 +34,23 @@ var configInitCmd = &cobra.Command{
 	Run:   runConfigInit,
 }
 
+// configTimezoneCmd shows or sets the time zone dates are read and shown in.
+var configTimezoneCmd = &cobra.Command{
+	Use:   "timezone [zone]",
+	Short: "Show or set the time zone used for dates",
+	Long: `Show or set the time zone ledger dates are entered and displayed in, as an IANA
+name such as America/New_York. A day given to --date is sent as the moment it
+starts in this zone, and report ranges cover whole days in it. Use "local" for
+the system's zone, which is the default.`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runConfigTimezone,
+}
+
 // init registers the config commands.
 func init() {
 	configCmd.AddCommand(configShowCmd)
 	configCmd.AddCommand(configInitCmd)
+	configCmd.AddCommand(configTimezoneCmd)
 	rootCmd.AddCommand(configCmd)
 }
 
@@ -52,6 +68,7 @@ func runConfigShow(cmd *cobra.Command, args []string) {
 			"user_id":            cfg.UserID,
 			"default_account_id": cfg.DefaultAccountID,
 			"api_url":            cfg.ApiURL,
+			"time_zone":          timeZoneName(cfg),
 		})
 		return
 	}
@@ -60,6 +77,49 @@ func runConfigShow(cmd *cobra.Command, args []string) {
 	fmt.Printf("User ID:            %d\n", cfg.UserID)
 	fmt.Printf("Default Account ID: %d\n", cfg.DefaultAccountID)
 	fmt.Printf("API URL:            %s\n", cfg.ApiURL)
+	fmt.Printf("Time Zone:          %s\n", timeZoneName(cfg))
+}
+
+// runConfigTimezone shows the configured time zone, or checks and saves a new one.
+func runConfigTimezone(cmd *cobra.Command, args []string) {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
+	if len(args) == 1 {
+		if _, err := dates.LoadLocation(args[0]); err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+		cfg.TimeZone = args[0]
+		if strings.EqualFold(args[0], "local") {
+			cfg.TimeZone = ""
+		}
+		if err := config.Save(cfg); err != nil {
+			fmt.Fprintln(os.Stderr, "Error saving config:", err)
+			os.Exit(1)
+		}
+	}
+
+	if outputFormat == "json" {
+		printJSON(map[string]string{"time_zone": timeZoneName(cfg)})
+		return
+	}
+
+	fmt.Println(timeZoneName(cfg))
+}
+
+// timeZoneName describes the configured time zone, naming the system's zone
+// when none is set.
+func timeZoneName(cfg *config.Config) string {
+	if cfg.TimeZone != "" {
+		return cfg.TimeZone
+	}
+
+	zone, _ := time.Now().Zone()
+	return "local (" + zone + ")"
 }
 
 // runConfigInit prompts the user to manually set config values.
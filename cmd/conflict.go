@@ -0,0 +1,152 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+	"github.com/cloudmanic/skyclerk-cli/internal/config"
+	"github.com/cloudmanic/skyclerk-cli/internal/conflict"
+	"github.com/cloudmanic/skyclerk-cli/internal/journal"
+	"github.com/spf13/cobra"
+)
+
+// kindCommands names the command group used to fetch each kind of object.
+var kindCommands = map[string]string{
+	journal.KindLedger:   "ledger",
+	journal.KindContact:  "contacts",
+	journal.KindCategory: "categories",
+	journal.KindLabel:    "labels",
+}
+
+// addConflictFlags adds the flags that stop an update from overwriting someone
+// else's changes. withTime adds --if-unmodified-since for objects that report
+// when they were last modified.
+func addConflictFlags(cmd *cobra.Command, withTime bool) {
+	if withTime {
+		cmd.Flags().String("if-unmodified-since", "", "Only update if not modified after this time (RFC 3339, as shown by get)")
+	}
+	cmd.Flags().String("expect-hash", "", "Only update if the current version hash matches (as shown by get)")
+	cmd.Flags().Bool("force", false, "Update even if it was changed since you fetched it")
+}
+
+// openSnapshots loads the object versions fetched for the current account.
+func openSnapshots(client *api.Client) (*conflict.Snapshots, error) {
+	dir, err := config.GetSnapshotsDir()
+	if err != nil {
+		return nil, err
+	}
+
+	return conflict.LoadSnapshots(config.AccountPath(dir, client.BaseURL(), client.AccountID()))
+}
+
+// rememberVersion records the version of an object the user was shown, so a
+// later update can tell whether someone else changed it in between.
+func rememberVersion(client *api.Client, kind string, id uint, v interface{}) {
+	s, err := openSnapshots(client)
+	if err == nil {
+		err = s.Put(kind, id, v)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Warning: unable to remember this version:", err)
+	}
+}
+
+// refreshVersions brings remembered versions up to date with changes made by
+// this CLI, so they aren't mistaken for someone else's edits.
+func refreshVersions(client *api.Client, changes []journal.Change) {
+	s, err := openSnapshots(client)
+	if err != nil {
+		return
+	}
+
+	for _, c := range changes {
+		if c.Action == journal.ActionDelete {
+			s.Forget(c.Kind, c.ID)
+		} else if c.After != nil {
+			s.Refresh(c.Kind, c.ID, c.After)
+		}
+	}
+}
+
+// checkUnmodified exits with a diff of the changed fields if an object was
+// modified after the version the user expects: the time or hash given by flag,
+// otherwise the version last fetched with get. --force skips the check.
+func checkUnmodified(cmd *cobra.Command, client *api.Client, kind string, id uint, current interface{}, updatedAt string) {
+	if force, _ := cmd.Flags().GetBool("force"); force {
+		return
+	}
+
+	var snap conflict.Snapshot
+	hasSnap := false
+	if s, err := openSnapshots(client); err == nil {
+		snap, hasSnap = s.Get(kind, id)
+	}
+
+	hash := conflict.Hash(current)
+	var reason string
+
+	switch {
+	case cmd.Flags().Changed("if-unmodified-since"):
+		value, _ := cmd.Flags().GetString("if-unmodified-since")
+		since, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error: invalid --if-unmodified-since, use a time such as 2026-10-18T09:30:00Z")
+			os.Exit(1)
+		}
+		modified, err := time.Parse(time.RFC3339, updatedAt)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s %d has no modification time, use --expect-hash instead\n", kind, id)
+			os.Exit(1)
+		}
+		if !modified.After(since) {
+			return
+		}
+		reason = "was modified at " + updatedAt
+
+	case cmd.Flags().Changed("expect-hash"):
+		expected, _ := cmd.Flags().GetString("expect-hash")
+		if hash == expected {
+			return
+		}
+		reason = fmt.Sprintf("is now version %s, not %s", hash, expected)
+		hasSnap = hasSnap && snap.Hash == expected
+
+	case hasSnap:
+		if hash == snap.Hash {
+			return
+		}
+		reason = "was changed since you fetched it at " + snap.Time.Local().Format("2006-01-02 15:04")
+
+	default:
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "Error: %s %d %s.\n", kind, id, reason)
+	if hasSnap {
+		if fields := conflict.Diff(snap.Data, current); len(fields) > 0 {
+			w := tabwriter.NewWriter(os.Stderr, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "  FIELD\tYOU SAW\tNOW")
+			for _, f := range fields {
+				fmt.Fprintf(w, "  %s\t%s\t%s\n", f.Name, orEmpty(f.Was), orEmpty(f.Now))
+			}
+			w.Flush()
+		}
+	}
+	fmt.Fprintf(os.Stderr, "Fetch it again with 'skyclerk %s get %d', or use --force to overwrite it.\n", kindCommands[kind], id)
+	os.Exit(1)
+}
+
+// orEmpty shows an empty field value as "(empty)".
+func orEmpty(s string) string {
+	if s == "" {
+		return "(empty)"
+	}
+
+	return s
+}
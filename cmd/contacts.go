@@ -10,6 +10,8 @@ import (
 	"text/tabwriter"
 
 	"github.com/cloudmanic/skyclerk-cli/internal/api"
+	"github.com/cloudmanic/skyclerk-cli/internal/conflict"
+	"github.com/cloudmanic/skyclerk-cli/internal/journal"
 	"github.com/spf13/cobra"
 )
 
@@ -85,6 +87,7 @@ func init() {
 	contactsUpdateCmd.Flags().String("zip", "", "Zip code")
 	contactsUpdateCmd.Flags().String("country", "", "Country")
 	contactsUpdateCmd.Flags().String("website", "", "Website URL")
+	addConflictFlags(contactsUpdateCmd, false)
 
 	// List flags.
 	contactsListCmd.Flags().String("search", "", "Search contacts by name")
@@ -136,11 +139,14 @@ func runContactsGet(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
+	// Read it from the server so the version remembered for later updates is current.
+	client.Invalidate(fmt.Sprintf("/contacts/%d", id))
 	contact, err := client.GetContact(uint(id))
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "Error:", err)
 		os.Exit(1)
 	}
+	rememberVersion(client, journal.KindContact, contact.ID, contact)
 
 	if outputFormat == "json" {
 		printJSON(contact)
@@ -179,6 +185,7 @@ func runContactsGet(cmd *cobra.Command, args []string) {
 	if contact.Website != "" {
 		fmt.Printf("Website: %s\n", contact.Website)
 	}
+	fmt.Printf("Version: %s\n", conflict.Hash(contact))
 }
 
 // runContactsCreate creates a new contact from flags.
@@ -214,6 +221,7 @@ func runContactsCreate(cmd *cobra.Command, args []string) {
 		fmt.Fprintln(os.Stderr, "Error:", err)
 		os.Exit(1)
 	}
+	recordChange(client, journal.KindContact, journal.ActionCreate, contact.ID, nil, contact)
 
 	if outputFormat == "json" {
 		printJSON(contact)
@@ -233,51 +241,60 @@ func runContactsUpdate(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
+	// Skip the cache so the conflict check sees other people's edits.
+	client.Invalidate(fmt.Sprintf("/contacts/%d", id))
+	before, err := client.GetContact(uint(id))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+	checkUnmodified(cmd, client, journal.KindContact, before.ID, before, "")
+
 	req := &api.ContactUpdateRequest{}
 
 	if cmd.Flags().Changed("name") {
 		v, _ := cmd.Flags().GetString("name")
-		req.Name = v
+		req.Name = &v
 	}
 	if cmd.Flags().Changed("first-name") {
 		v, _ := cmd.Flags().GetString("first-name")
-		req.FirstName = v
+		req.FirstName = &v
 	}
 	if cmd.Flags().Changed("last-name") {
 		v, _ := cmd.Flags().GetString("last-name")
-		req.LastName = v
+		req.LastName = &v
 	}
 	if cmd.Flags().Changed("email") {
 		v, _ := cmd.Flags().GetString("email")
-		req.Email = v
+		req.Email = &v
 	}
 	if cmd.Flags().Changed("phone") {
 		v, _ := cmd.Flags().GetString("phone")
-		req.Phone = v
+		req.Phone = &v
 	}
 	if cmd.Flags().Changed("address") {
 		v, _ := cmd.Flags().GetString("address")
-		req.Address = v
+		req.Address = &v
 	}
 	if cmd.Flags().Changed("city") {
 		v, _ := cmd.Flags().GetString("city")
-		req.City = v
+		req.City = &v
 	}
 	if cmd.Flags().Changed("state") {
 		v, _ := cmd.Flags().GetString("state")
-		req.State = v
+		req.State = &v
 	}
 	if cmd.Flags().Changed("zip") {
 		v, _ := cmd.Flags().GetString("zip")
-		req.Zip = v
+		req.Zip = &v
 	}
 	if cmd.Flags().Changed("country") {
 		v, _ := cmd.Flags().GetString("country")
-		req.Country = v
+		req.Country = &v
 	}
 	if cmd.Flags().Changed("website") {
 		v, _ := cmd.Flags().GetString("website")
-		req.Website = v
+		req.Website = &v
 	}
 
 	contact, err := client.UpdateContact(uint(id), req)
@@ -285,6 +302,7 @@ func runContactsUpdate(cmd *cobra.Command, args []string) {
 		fmt.Fprintln(os.Stderr, "Error:", err)
 		os.Exit(1)
 	}
+	recordChange(client, journal.KindContact, journal.ActionUpdate, contact.ID, before, contact)
 
 	if outputFormat == "json" {
 		printJSON(contact)
@@ -304,10 +322,17 @@ func runContactsDelete(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
+	before, err := client.GetContact(uint(id))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
 	if err := client.DeleteContact(uint(id)); err != nil {
 		fmt.Fprintln(os.Stderr, "Error:", err)
 		os.Exit(1)
 	}
+	recordChange(client, journal.KindContact, journal.ActionDelete, uint(id), before, nil)
 
 	fmt.Printf("Deleted contact %d\n", id)
 }
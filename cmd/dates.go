@@ -0,0 +1,151 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+	"github.com/cloudmanic/skyclerk-cli/internal/config"
+	"github.com/cloudmanic/skyclerk-cli/internal/dates"
+	"github.com/spf13/cobra"
+)
+
+// datesHelpCmd is a help topic listing the date formats date flags accept.
+var datesHelpCmd = &cobra.Command{
+	Use:   "dates",
+	Short: "Date formats accepted by --date, --start, --end and other date flags",
+	Long: `Date flags accept any of these, matched without regard to case:
+
+  2026-03-05                 a day
+  today, yesterday, tomorrow
+  last friday, next mon      the nearest such day before or after today
+  -3d, +2w, -1m, -1y         days, weeks, months or years from today
+  mar 5, 5 march 2026        a day by month name (this year if none is given)
+  05/03/2026, 5.3.26, 05/03  a day in the account locale's order
+  2026-03, march 2026        a month
+  2026                       a year
+  this week, last month, next year
+
+A month, year or week used for --start means its first day, and for --end its
+last day, so '--start 2026-03 --end 2026-03' covers all of March. Flags that
+take a single day, such as --date, reject them.
+
+Numeric dates follow the account locale (see 'skyclerk accounts show'): en-US
+reads 05/03/2026 as May 3, en-GB as 5 March. When the locale doesn't settle the
+order, a date that reads both ways is refused rather than guessed; use
+YYYY-MM-DD instead. A bare weekday such as "friday" is refused too; say "last
+friday" or "next friday".`,
+}
+
+func init() {
+	rootCmd.AddCommand(datesHelpCmd)
+}
+
+// location is the time zone from the config, loaded by timeZone.
+var location *time.Location
+
+// timeZone returns the time zone dates are entered and shown in: the config's
+// time_zone, or the system's zone when it isn't set or can't be loaded.
+func timeZone() *time.Location {
+	if location != nil {
+		return location
+	}
+
+	location = time.Local
+	cfg, err := config.Load()
+	if err != nil {
+		return location
+	}
+	loc, err := dates.LoadLocation(cfg.TimeZone)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: invalid time_zone %q in config, using the system's zone\n", cfg.TimeZone)
+		return location
+	}
+	location = loc
+
+	return location
+}
+
+// localDay returns the day an API timestamp falls on in the user's time zone.
+func localDay(value string) string {
+	return dates.LocalDay(value, timeZone())
+}
+
+// localTime returns an API timestamp as a time in the user's time zone.
+func localTime(value string) string {
+	return dates.LocalTime(value, timeZone())
+}
+
+// localizeLedgers returns copies of ledger entries dated by the day they fall
+// on in the user's time zone, for code that reads the day from the date.
+func localizeLedgers(ledgers []api.Ledger) []api.Ledger {
+	out := make([]api.Ledger, len(ledgers))
+	for i, l := range ledgers {
+		l.Date = localDay(l.Date)
+		out[i] = l
+	}
+
+	return out
+}
+
+// newDateParser returns a date parser that reads numeric dates in the
+// account's locale, looking the account up only when a date needs it.
+func newDateParser(client *api.Client) dates.Parser {
+	return dates.Parser{
+		Now: time.Now().In(timeZone()),
+		Locale: func() string {
+			account, err := client.GetAccount()
+			if err != nil {
+				return ""
+			}
+			return account.Locale
+		},
+	}
+}
+
+// dayFlag parses a flag naming a single day, returning it as YYYY-MM-DD or ""
+// when the flag is empty.
+func dayFlag(cmd *cobra.Command, client *api.Client, name string) string {
+	value, _ := cmd.Flags().GetString(name)
+	if value == "" {
+		return ""
+	}
+
+	day, err := newDateParser(client).Day(value)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid --%s: %v (see 'skyclerk help dates')\n", name, err)
+		os.Exit(1)
+	}
+
+	return day
+}
+
+// dateRangeFlags parses --start and --end into the first and last day they
+// cover as YYYY-MM-DD, either of which is "" when its flag is empty.
+func dateRangeFlags(cmd *cobra.Command, client *api.Client) (string, string) {
+	parser := newDateParser(client)
+	parse := func(name string, edge func(string) (string, error)) string {
+		value, _ := cmd.Flags().GetString(name)
+		if value == "" {
+			return ""
+		}
+		day, err := edge(value)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid --%s: %v (see 'skyclerk help dates')\n", name, err)
+			os.Exit(1)
+		}
+		return day
+	}
+
+	start, end := parse("start", parser.Start), parse("end", parser.End)
+	if start != "" && end != "" && start > end {
+		fmt.Fprintf(os.Stderr, "Error: --start %s is after --end %s\n", start, end)
+		os.Exit(1)
+	}
+
+	return start, end
+}
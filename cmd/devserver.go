@@ -0,0 +1,45 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/apitest"
+	"github.com/spf13/cobra"
+)
+
+// devServerCmd runs the in-memory fake Skyclerk API for offline development.
+var devServerCmd = &cobra.Command{
+	Use:    "dev-server",
+	Short:  "Run a fake Skyclerk API locally for offline development",
+	Hidden: true,
+	Run:    runDevServer,
+}
+
+// init registers the dev-server command and its flags.
+func init() {
+	devServerCmd.Flags().String("addr", "127.0.0.1:7070", "Address to listen on")
+
+	rootCmd.AddCommand(devServerCmd)
+}
+
+// runDevServer starts the fake API and blocks until it exits.
+func runDevServer(cmd *cobra.Command, args []string) {
+	addr, _ := cmd.Flags().GetString("addr")
+
+	fmt.Printf("Fake Skyclerk API listening on http://%s\n\n", addr)
+	fmt.Println("Point the CLI at it with 'skyclerk config init' using:")
+	fmt.Printf("  Access Token:       %s\n", apitest.DefaultToken)
+	fmt.Printf("  API URL:            http://%s\n", addr)
+	fmt.Printf("  Default Account ID: %d\n\n", apitest.DefaultAccountID)
+	fmt.Printf("Seeded login: %s / %s (client ID %s)\n", apitest.DefaultEmail, apitest.DefaultPassword, apitest.DefaultClientID)
+
+	if err := http.ListenAndServe(addr, apitest.New()); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
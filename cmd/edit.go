@@ -0,0 +1,290 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+	"github.com/cloudmanic/skyclerk-cli/internal/conflict"
+	"github.com/cloudmanic/skyclerk-cli/internal/edit"
+	"github.com/cloudmanic/skyclerk-cli/internal/journal"
+	"github.com/spf13/cobra"
+)
+
+// ledgerEditCmd edits a ledger entry as YAML in the user's editor.
+var ledgerEditCmd = &cobra.Command{
+	Use:   "edit [id]",
+	Short: "Edit a ledger entry in your editor",
+	Long: `Open a ledger entry in $VISUAL or $EDITOR as YAML, with the contact, category
+and labels written by name. When the editor closes the changes are checked,
+shown as a diff and applied after confirmation. Empty the file to cancel.`,
+	Args: cobra.ExactArgs(1),
+	Run:  runLedgerEdit,
+}
+
+// contactsEditCmd edits a contact as YAML in the user's editor.
+var contactsEditCmd = &cobra.Command{
+	Use:   "edit [id]",
+	Short: "Edit a contact in your editor",
+	Long: `Open a contact in $VISUAL or $EDITOR as YAML. When the editor closes the
+changes are checked, shown as a diff and applied after confirmation. Empty the
+file to cancel.`,
+	Args: cobra.ExactArgs(1),
+	Run:  runContactsEdit,
+}
+
+func init() {
+	ledgerEditCmd.Flags().BoolP("yes", "y", false, "Apply the changes without asking")
+	addConflictFlags(ledgerEditCmd, true)
+
+	contactsEditCmd.Flags().BoolP("yes", "y", false, "Apply the changes without asking")
+	addConflictFlags(contactsEditCmd, false)
+
+	ledgerCmd.AddCommand(ledgerEditCmd)
+	contactsCmd.AddCommand(contactsEditCmd)
+}
+
+// runLedgerEdit edits a ledger entry in the editor and applies the changes.
+func runLedgerEdit(cmd *cobra.Command, args []string) {
+	client := newClient()
+
+	id, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error: invalid ledger ID")
+		os.Exit(1)
+	}
+
+	ledger, err := client.GetLedger(uint(id))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+	// The editor shows the current version, so only an explicit --expect-hash
+	// or --if-unmodified-since can conflict with it.
+	rememberVersion(client, journal.KindLedger, ledger.ID, ledger)
+	checkUnmodified(cmd, client, journal.KindLedger, ledger.ID, ledger, ledger.UpdatedAt)
+
+	categories, err := client.GetCategories(nil)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error fetching categories:", err)
+		os.Exit(1)
+	}
+	labels, err := client.GetLabels(nil)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error fetching labels:", err)
+		os.Exit(1)
+	}
+
+	was := edit.NewLedger(localizeLedgers([]api.Ledger{*ledger})[0])
+	doc, err := was.Marshal(ledger.ID)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
+	var edited api.Ledger
+	ok := editDocument(doc, "skyclerk-ledger-*.yaml", func(data []byte) error {
+		var d edit.Ledger
+		if err := edit.Unmarshal(data, &d); err != nil {
+			return err
+		}
+		contacts, err := client.GetContacts(map[string]string{"search": strings.TrimSpace(d.Contact)})
+		if err != nil {
+			return err
+		}
+		edited, err = d.Resolve(contacts, categories, labels)
+		return err
+	})
+	if !ok {
+		return
+	}
+
+	if !confirmEdit(cmd, conflict.Diff(was, edit.NewLedger(edited))) {
+		return
+	}
+
+	// The entry may have been changed by someone else while it was open.
+	current, err := client.GetLedger(ledger.ID)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+	checkUnmodified(cmd, client, journal.KindLedger, current.ID, current, current.UpdatedAt)
+
+	req := edit.LedgerRequest(localizeLedgers([]api.Ledger{*current})[0], edited)
+	if req.Date != nil {
+		req.Date = api.Ptr(formatDateForAPI(*req.Date))
+	}
+	if req.Category != nil {
+		req.Category.Type = categoryTypeToAPI(req.Category.Type)
+	}
+
+	updated, err := client.UpdateLedger(current.ID, req)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+	recordChange(client, journal.KindLedger, journal.ActionUpdate, updated.ID, current, updated)
+
+	if outputFormat == "json" {
+		printJSON(updated)
+		return
+	}
+
+	fmt.Printf("Updated ledger entry %d\n", updated.ID)
+}
+
+// runContactsEdit edits a contact in the editor and applies the changes.
+func runContactsEdit(cmd *cobra.Command, args []string) {
+	client := newClient()
+
+	id, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error: invalid contact ID")
+		os.Exit(1)
+	}
+
+	path := fmt.Sprintf("/contacts/%d", id)
+	client.Invalidate(path)
+	contact, err := client.GetContact(uint(id))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+	rememberVersion(client, journal.KindContact, contact.ID, contact)
+	checkUnmodified(cmd, client, journal.KindContact, contact.ID, contact, "")
+
+	was := edit.NewContact(*contact)
+	doc, err := was.Marshal(contact.ID)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
+	var edited edit.Contact
+	ok := editDocument(doc, "skyclerk-contact-*.yaml", func(data []byte) error {
+		edited = edit.Contact{}
+		if err := edit.Unmarshal(data, &edited); err != nil {
+			return err
+		}
+		return edited.Validate()
+	})
+	if !ok {
+		return
+	}
+
+	if !confirmEdit(cmd, conflict.Diff(was, edited)) {
+		return
+	}
+
+	// The contact may have been changed by someone else while it was open.
+	client.Invalidate(path)
+	current, err := client.GetContact(contact.ID)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+	checkUnmodified(cmd, client, journal.KindContact, current.ID, current, "")
+
+	updated, err := client.UpdateContact(current.ID, edited.Request(edit.NewContact(*current)))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+	recordChange(client, journal.KindContact, journal.ActionUpdate, updated.ID, current, updated)
+
+	if outputFormat == "json" {
+		printJSON(updated)
+		return
+	}
+
+	fmt.Printf("Updated contact %d: %s\n", updated.ID, updated.Name)
+}
+
+// editDocument opens a document in the editor and checks the result,
+// reopening it after a problem until it passes or the user gives up. It
+// returns false if the edit was cancelled.
+func editDocument(doc []byte, pattern string, check func([]byte) error) bool {
+	f, err := os.CreateTemp("", pattern)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+	defer os.Remove(f.Name())
+
+	_, err = f.Write(doc)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
+	for {
+		if err := edit.Open(f.Name()); err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+
+		data, err := os.ReadFile(f.Name())
+		if err == nil {
+			err = check(data)
+		}
+		if err == nil {
+			return true
+		}
+		if errors.Is(err, edit.ErrEmpty) {
+			fmt.Fprintln(os.Stderr, "Edit cancelled; nothing was changed.")
+			return false
+		}
+
+		fmt.Fprintln(os.Stderr, "The edited document has problems:")
+		for _, line := range strings.Split(err.Error(), "\n") {
+			fmt.Fprintln(os.Stderr, "  "+line)
+		}
+		if !confirm("Edit again?") {
+			fmt.Fprintln(os.Stderr, "Nothing was changed.")
+			return false
+		}
+	}
+}
+
+// confirmEdit shows the fields an edit changes and asks before applying them
+// unless --yes was given. It returns false if there is nothing to apply. For
+// JSON output the changes are shown on stderr, leaving stdout for the result.
+func confirmEdit(cmd *cobra.Command, fields []conflict.Field) bool {
+	out := os.Stdout
+	if outputFormat == "json" {
+		out = os.Stderr
+	}
+
+	if len(fields) == 0 {
+		fmt.Fprintln(out, "No changes.")
+		return false
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "FIELD\tBEFORE\tAFTER")
+	for _, f := range fields {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", f.Name, orEmpty(f.Was), orEmpty(f.Now))
+	}
+	w.Flush()
+
+	if yes, _ := cmd.Flags().GetBool("yes"); yes {
+		return true
+	}
+	if !confirm("Apply these changes?") {
+		fmt.Fprintln(os.Stderr, "Nothing was changed.")
+		return false
+	}
+
+	return true
+}
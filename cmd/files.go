@@ -4,9 +4,19 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
 
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+	"github.com/cloudmanic/skyclerk-cli/internal/receipts"
 	"github.com/spf13/cobra"
 )
 
@@ -16,36 +26,263 @@ var filesCmd = &cobra.Command{
 	Short: "Manage files and receipts",
 }
 
-// filesUploadCmd uploads a file to the current account.
+// filesUploadCmd uploads one or more files to the current account.
 var filesUploadCmd = &cobra.Command{
-	Use:   "upload [file-path]",
-	Short: "Upload a file or receipt",
+	Use:   "upload [file-path|glob...]",
+	Short: "Upload files or receipts",
+	Long: `Upload one or more files or receipts.
+
+Arguments may be file paths or glob patterns, and --dir adds every file in a
+directory. Each file's ledger entry is taken from a sidecar file next to it
+(receipt.jpg.ledger containing the ledger ID), then from the --manifest CSV
+(file,ledger_id rows), then from --ledger-id.`,
+	Args: cobra.ArbitraryArgs,
+	Run:  runFilesUpload,
+}
+
+// filesListCmd lists files in the current account.
+var filesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List files and receipts",
+	Run:   runFilesList,
+}
+
+// filesGetCmd shows a single file's details.
+var filesGetCmd = &cobra.Command{
+	Use:   "get [id]",
+	Short: "Get a single file",
+	Args:  cobra.ExactArgs(1),
+	Run:   runFilesGet,
+}
+
+// filesDownloadCmd downloads a file to disk.
+var filesDownloadCmd = &cobra.Command{
+	Use:   "download [id]",
+	Short: "Download a file or its thumbnail",
 	Args:  cobra.ExactArgs(1),
-	Run:   runFilesUpload,
+	Run:   runFilesDownload,
+}
+
+// filesDeleteCmd deletes a file.
+var filesDeleteCmd = &cobra.Command{
+	Use:   "delete [id]",
+	Short: "Delete a file",
+	Args:  cobra.ExactArgs(1),
+	Run:   runFilesDelete,
+}
+
+// filesWatchCmd watches an inbox directory and uploads receipts dropped into it.
+var filesWatchCmd = &cobra.Command{
+	Use:   "watch [dir]",
+	Short: "Watch a folder and upload new receipts",
+	Long: `Watch a folder and upload receipts as they appear.
+
+The folder is polled, and each file is uploaded once it has stopped changing.
+Uploaded files are moved to uploaded/ and failures to failed/ with a
+.error.txt note. A manifest in the folder records what has been uploaded so a
+restart never uploads the same file twice. Sidecar .ledger files are honored.
+
+With --once the folder is scanned a single time. Files modified within the last
+--stable may still be being written, so they are left for the next run.`,
+	Args: cobra.ExactArgs(1),
+	Run:  runFilesWatch,
+}
+
+// filesDedupeCmd reports files in the account with identical content.
+var filesDedupeCmd = &cobra.Command{
+	Use:   "dedupe",
+	Short: "Find duplicate files in the account",
+	Long: `Find files in the account with identical content.
+
+Files are compared by size, and files sharing a size are compared by SHA-256.
+Hashes already in the local upload manifest are reused; others are computed by
+downloading the file, and are then remembered so later uploads of the same
+content are caught. Nothing is deleted; the report lists the files to remove.`,
+	Run: runFilesDedupe,
+}
+
+// uploadResultRow is the JSON shape of one batch upload result.
+type uploadResultRow struct {
+	Path     string    `json:"path"`
+	LedgerID string    `json:"ledger_id,omitempty"`
+	Status   string    `json:"status"`
+	File     *api.File `json:"file,omitempty"`
+	Error    string    `json:"error,omitempty"`
 }
 
 // init registers the files commands and their flags.
 func init() {
-	filesUploadCmd.Flags().String("ledger-id", "", "Associate file with a ledger entry")
+	filesUploadCmd.Flags().String("ledger-id", "", "Associate files with a ledger entry")
+	filesUploadCmd.Flags().String("dir", "", "Upload every file in a directory")
+	filesUploadCmd.Flags().String("manifest", "", "CSV of file,ledger_id rows mapping files to ledger entries")
+	filesUploadCmd.Flags().Int("parallel", 4, "Number of concurrent uploads")
+	filesUploadCmd.Flags().Bool("force", false, "Upload even if the same content was uploaded before")
+	filesUploadCmd.Flags().Bool("optimize", false, "Fix rotation, downscale, re-encode and strip GPS from JPEG/PNG images before upload")
+	filesUploadCmd.Flags().Int("max-dimension", receipts.DefaultMaxDimension, "Longest side in pixels when optimizing")
+	filesUploadCmd.Flags().Int("quality", receipts.DefaultQuality, "JPEG quality (1-100) when optimizing")
+	filesUploadCmd.Flags().Bool("grayscale", false, "Convert images to grayscale when optimizing")
+
+	filesListCmd.Flags().String("limit", "25", "Number of files to return")
+	filesListCmd.Flags().String("page", "1", "Page number")
+
+	filesDownloadCmd.Flags().StringP("out", "o", "", "Output file or directory (default: current directory)")
+	filesDownloadCmd.Flags().Bool("thumb", false, "Download the 600x600 thumbnail instead of the original")
+	filesDownloadCmd.Flags().Bool("force", false, "Overwrite an existing file")
+
+	filesDedupeCmd.Flags().Bool("size-only", false, "Compare by size only without downloading files")
+
+	filesWatchCmd.Flags().String("ledger-id", "", "Associate uploads with a ledger entry")
+	filesWatchCmd.Flags().Duration("interval", receipts.DefaultInterval, "How often to scan the folder")
+	filesWatchCmd.Flags().Duration("stable", receipts.DefaultStableFor, "How long a file must be unchanged before uploading")
+	filesWatchCmd.Flags().Bool("once", false, "Scan once, upload files unchanged for --stable and exit")
 
 	filesCmd.AddCommand(filesUploadCmd)
+	filesCmd.AddCommand(filesWatchCmd)
+	filesCmd.AddCommand(filesListCmd)
+	filesCmd.AddCommand(filesGetCmd)
+	filesCmd.AddCommand(filesDownloadCmd)
+	filesCmd.AddCommand(filesDeleteCmd)
+	filesCmd.AddCommand(filesDedupeCmd)
 	rootCmd.AddCommand(filesCmd)
 }
 
-// runFilesUpload uploads a file to the Skyclerk API.
+// runFilesUpload uploads a single file, or a batch when given globs, several
+// paths or --dir.
 func runFilesUpload(cmd *cobra.Command, args []string) {
+	ledgerID, _ := cmd.Flags().GetString("ledger-id")
+	dir, _ := cmd.Flags().GetString("dir")
+	manifestPath, _ := cmd.Flags().GetString("manifest")
+	parallel, _ := cmd.Flags().GetInt("parallel")
+	force, _ := cmd.Flags().GetBool("force")
+
+	if len(args) == 0 && dir == "" {
+		fmt.Fprintln(os.Stderr, "Error: provide at least one file, glob or --dir")
+		os.Exit(1)
+	}
+
+	paths, err := receipts.ExpandPaths(args, dir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
+	if len(paths) == 0 {
+		fmt.Fprintln(os.Stderr, "Error: no files to upload")
+		os.Exit(1)
+	}
+
+	var manifest map[string]string
+	if manifestPath != "" {
+		manifest, err = receipts.LoadManifest(manifestPath)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+		paths = withoutPath(paths, manifestPath)
+	}
+
+	jobs, err := receipts.Plan(paths, manifest, ledgerID)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
 	client := newClient()
 
-	filePath := args[0]
-	ledgerID, _ := cmd.Flags().GetString("ledger-id")
+	// Duplicates are detected on the original file, before any optimization.
+	var next receipts.Uploader = client
+	if optimize, _ := cmd.Flags().GetBool("optimize"); optimize {
+		next = newOptimizingUploader(cmd, client)
+	}
+	uploader := &receipts.DedupUploader{Next: next, Manifest: openUploadManifest(client), Force: force}
+
+	if len(jobs) == 1 && dir == "" {
+		uploadSingleFile(uploader, jobs[0])
+		return
+	}
+
+	uploadBatch(uploader, jobs, parallel)
+}
+
+// newOptimizingUploader wraps an uploader with image optimization configured by the upload flags.
+func newOptimizingUploader(cmd *cobra.Command, next receipts.Uploader) *receipts.OptimizingUploader {
+	maxDimension, _ := cmd.Flags().GetInt("max-dimension")
+	quality, _ := cmd.Flags().GetInt("quality")
+	grayscale, _ := cmd.Flags().GetBool("grayscale")
 
-	// Verify the file exists before uploading.
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
-		fmt.Fprintf(os.Stderr, "Error: file not found: %s\n", filePath)
+	if quality < 1 || quality > 100 {
+		fmt.Fprintln(os.Stderr, "Error: --quality must be between 1 and 100")
 		os.Exit(1)
 	}
 
-	file, err := client.UploadFile(filePath, ledgerID)
+	return &receipts.OptimizingUploader{
+		Next: next,
+		Options: receipts.OptimizeOptions{
+			MaxDimension: maxDimension,
+			Quality:      quality,
+			Grayscale:    grayscale,
+		},
+		OnOptimize: printOptimizeResult,
+	}
+}
+
+// printOptimizeResult reports what optimization did to a file on stderr.
+func printOptimizeResult(res receipts.OptimizeResult) {
+	if outputFormat == "json" {
+		return
+	}
+
+	name := filepath.Base(res.Path)
+	if res.Skipped != "" {
+		fmt.Fprintf(os.Stderr, "Not optimized %s: %s\n", name, res.Skipped)
+		return
+	}
+
+	var notes []string
+	if res.Rotated {
+		notes = append(notes, "rotated")
+	}
+	if res.StrippedGPS {
+		notes = append(notes, "GPS removed")
+	}
+
+	detail := fmt.Sprintf("%dx%d", res.Width, res.Height)
+	if len(notes) > 0 {
+		detail += ", " + strings.Join(notes, ", ")
+	}
+
+	fmt.Fprintf(os.Stderr, "Optimized %s: %s -> %s (%s)\n", name, formatBytes(res.OriginalSize), formatBytes(res.OptimizedSize), detail)
+}
+
+// withoutPath removes the file at target from paths so a manifest kept in the
+// upload directory is not uploaded itself.
+func withoutPath(paths []string, target string) []string {
+	targetAbs, err := filepath.Abs(target)
+	if err != nil {
+		return paths
+	}
+
+	kept := paths[:0]
+	for _, p := range paths {
+		if abs, err := filepath.Abs(p); err == nil && abs == targetAbs {
+			continue
+		}
+		kept = append(kept, p)
+	}
+
+	return kept
+}
+
+// uploadSingleFile uploads one file with a progress bar on interactive terminals.
+func uploadSingleFile(uploader receipts.Uploader, job receipts.Job) {
+	var opts []api.UploadOption
+	bar := newProgressBar(filepath.Base(job.Path))
+	if bar != nil {
+		opts = append(opts, api.WithProgress(bar.Update))
+	}
+
+	file, err := uploader.UploadFile(job.Path, job.LedgerID, opts...)
+	bar.Done()
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "Error:", err)
 		os.Exit(1)
@@ -58,3 +295,415 @@ func runFilesUpload(cmd *cobra.Command, args []string) {
 
 	fmt.Printf("Uploaded file %d: %s (%s, %d bytes)\n", file.ID, file.Name, file.Type, file.Size)
 }
+
+// uploadBatch uploads jobs concurrently, reports every result and exits
+// non-zero if any upload failed. Duplicates are skipped, not failures.
+func uploadBatch(uploader receipts.Uploader, jobs []receipts.Job, parallel int) {
+	tick := progress(len(jobs))
+	results := receipts.UploadAll(uploader, jobs, parallel, func(r receipts.Result) {
+		status := "ok"
+		if receipts.IsDuplicate(r.Err) {
+			status = "duplicate"
+		} else if r.Err != nil {
+			status = "failed"
+		}
+		tick(status, r.Path)
+	})
+
+	failed, duplicates := 0, 0
+	rows := make([]uploadResultRow, 0, len(results))
+	for _, r := range results {
+		row := uploadResultRow{Path: r.Path, LedgerID: r.LedgerID, Status: "uploaded", File: r.File}
+		if r.Err != nil {
+			row.Status = "failed"
+			row.Error = strings.TrimSpace(r.Err.Error())
+			if receipts.IsDuplicate(r.Err) {
+				row.Status = "duplicate"
+				duplicates++
+			} else {
+				failed++
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	if outputFormat == "json" {
+		printJSON(rows)
+	} else {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "FILE\tSTATUS\tFILE ID\tLEDGER\tDETAIL")
+		for _, row := range rows {
+			fileID, detail := "", row.Error
+			if row.File != nil {
+				fileID = fmt.Sprintf("%d", row.File.ID)
+				detail = fmt.Sprintf("%s, %d bytes", row.File.Type, row.File.Size)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", row.Path, row.Status, fileID, row.LedgerID, detail)
+		}
+		w.Flush()
+
+		fmt.Printf("\n%d uploaded, %d duplicates skipped, %d failed\n", len(rows)-failed-duplicates, duplicates, failed)
+	}
+
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// runFilesWatch polls a folder and uploads new files until interrupted.
+func runFilesWatch(cmd *cobra.Command, args []string) {
+	client := newClient()
+
+	ledgerID, _ := cmd.Flags().GetString("ledger-id")
+	interval, _ := cmd.Flags().GetDuration("interval")
+	stable, _ := cmd.Flags().GetDuration("stable")
+	once, _ := cmd.Flags().GetBool("once")
+
+	if info, err := os.Stat(args[0]); err != nil || !info.IsDir() {
+		fmt.Fprintf(os.Stderr, "Error: not a directory: %s\n", args[0])
+		os.Exit(1)
+	}
+
+	if interval <= 0 {
+		fmt.Fprintln(os.Stderr, "Error: --interval must be positive")
+		os.Exit(1)
+	}
+
+	uploader := &receipts.DedupUploader{Next: client, Manifest: openUploadManifest(client)}
+	watcher, err := receipts.NewWatcher(args[0], uploader)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
+	watcher.LedgerID = ledgerID
+	watcher.Interval = interval
+	watcher.StableFor = stable
+	watcher.OnEvent = printWatchEvent
+
+	if once {
+		if err := watcher.Scan(); err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if outputFormat != "json" {
+		fmt.Printf("Watching %s every %s (Ctrl-C to stop)\n", args[0], interval)
+	}
+
+	if err := watcher.Run(ctx); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
+
+// printWatchEvent reports one watched file as a log line, or a JSON line in JSON mode.
+func printWatchEvent(e receipts.WatchEvent) {
+	if outputFormat == "json" {
+		printJSONLine(e)
+		return
+	}
+
+	stamp := time.Now().Format("15:04:05")
+	name := filepath.Base(e.Path)
+
+	switch e.Status {
+	case "uploaded":
+		fmt.Printf("%s  uploaded  %s (file %d)\n", stamp, name, e.FileID)
+		if e.Warning != "" {
+			fmt.Fprintln(os.Stderr, "Warning:", e.Warning)
+		}
+	case "skipped":
+		fmt.Printf("%s  skipped   %s (already uploaded as file %d)\n", stamp, name, e.FileID)
+	default:
+		fmt.Printf("%s  failed    %s: %s\n", stamp, name, e.Error)
+	}
+}
+
+// runFilesList fetches and displays files.
+func runFilesList(cmd *cobra.Command, args []string) {
+	client := newClient()
+
+	limit, _ := cmd.Flags().GetString("limit")
+	page, _ := cmd.Flags().GetString("page")
+
+	files, err := client.ListFiles(map[string]string{"limit": limit, "page": page})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
+	if outputFormat == "json" {
+		printJSON(files)
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tTYPE\tSIZE\tCREATED")
+	for _, f := range files {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", f.ID, f.Name, f.Type, formatBytes(f.Size), localTime(f.CreatedAt))
+	}
+	w.Flush()
+}
+
+// runFilesGet fetches and displays a single file.
+func runFilesGet(cmd *cobra.Command, args []string) {
+	client := newClient()
+
+	id, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error: invalid file ID")
+		os.Exit(1)
+	}
+
+	file, err := client.GetFile(uint(id))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
+	if outputFormat == "json" {
+		printJSON(file)
+		return
+	}
+
+	fmt.Printf("ID:      %d\n", file.ID)
+	fmt.Printf("Name:    %s\n", file.Name)
+	fmt.Printf("Type:    %s\n", file.Type)
+	fmt.Printf("Size:    %s (%d bytes)\n", formatBytes(file.Size), file.Size)
+	fmt.Printf("URL:     %s\n", file.URL)
+	if file.Thumb600By600 != "" {
+		fmt.Printf("Thumb:   %s\n", file.Thumb600By600)
+	}
+	fmt.Printf("Created: %s\n", localTime(file.CreatedAt))
+}
+
+// runFilesDownload streams a file to disk under its original name.
+func runFilesDownload(cmd *cobra.Command, args []string) {
+	client := newClient()
+
+	id, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error: invalid file ID")
+		os.Exit(1)
+	}
+
+	out, _ := cmd.Flags().GetString("out")
+	thumb, _ := cmd.Flags().GetBool("thumb")
+	force, _ := cmd.Flags().GetBool("force")
+
+	file, err := client.GetFile(uint(id))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
+	dest := downloadPath(out, file, thumb)
+	if _, err := os.Stat(dest); err == nil && !force {
+		fmt.Fprintf(os.Stderr, "Error: %s already exists (use --force to overwrite)\n", dest)
+		os.Exit(1)
+	}
+
+	dl, err := downloadFileTo(client, file, thumb, dest)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
+	if outputFormat == "json" {
+		printJSON(map[string]interface{}{
+			"file_id": file.ID,
+			"path":    dest,
+			"bytes":   dl.Bytes,
+			"sha256":  dl.SHA256,
+		})
+		return
+	}
+
+	fmt.Printf("Downloaded file %d to %s (%s)\n", file.ID, dest, formatBytes(dl.Bytes))
+	fmt.Printf("SHA-256: %s\n", dl.SHA256)
+}
+
+// downloadFileTo downloads a file to dest through a temporary file, so a
+// failed or corrupt download never leaves a partial file.
+func downloadFileTo(client *api.Client, file *api.File, thumb bool, dest string) (*api.Download, error) {
+	tmp, err := os.CreateTemp(filepath.Dir(dest), "."+filepath.Base(dest)+".*.part")
+	if err != nil {
+		return nil, err
+	}
+
+	dl, err := client.DownloadFile(file, thumb, tmp)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), 0644)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), dest)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return nil, err
+	}
+
+	return dl, nil
+}
+
+// downloadPath resolves where a download is written. out may be empty (the
+// current directory), an existing directory, or a file path. Thumbnails get a
+// "-thumb" suffix before the extension.
+func downloadPath(out string, file *api.File, thumb bool) string {
+	name := filepath.Base(filepath.Clean("/" + strings.ReplaceAll(file.Name, "\\", "/")))
+	if name == "/" || name == "." {
+		name = fmt.Sprintf("file-%d", file.ID)
+	}
+
+	if thumb {
+		ext := filepath.Ext(name)
+		name = strings.TrimSuffix(name, ext) + "-thumb" + ext
+	}
+
+	if out == "" {
+		return name
+	}
+
+	if info, err := os.Stat(out); err == nil && info.IsDir() {
+		return filepath.Join(out, name)
+	}
+
+	return out
+}
+
+// runFilesDelete deletes a file by ID.
+func runFilesDelete(cmd *cobra.Command, args []string) {
+	client := newClient()
+
+	id, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error: invalid file ID")
+		os.Exit(1)
+	}
+
+	if err := client.DeleteFile(uint(id)); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
+	// Let the same content be uploaded again now that it is gone.
+	if err := openUploadManifest(client).Forget(uint(id)); err != nil {
+		fmt.Fprintln(os.Stderr, "Warning:", err)
+	}
+
+	fmt.Printf("Deleted file %d\n", id)
+}
+
+// filesPageSize is the page size used when walking every file in an account.
+const filesPageSize = 100
+
+// listAllFiles fetches every file in the account page by page.
+func listAllFiles(client *api.Client) ([]api.File, error) {
+	var all []api.File
+	for page := 1; ; page++ {
+		files, err := client.ListFiles(map[string]string{
+			"limit": strconv.Itoa(filesPageSize),
+			"page":  strconv.Itoa(page),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		all = append(all, files...)
+		if len(files) < filesPageSize {
+			return all, nil
+		}
+	}
+}
+
+// runFilesDedupe reports groups of files with identical content.
+func runFilesDedupe(cmd *cobra.Command, args []string) {
+	client := newClient()
+	manifest := openUploadManifest(client)
+	sizeOnly, _ := cmd.Flags().GetBool("size-only")
+
+	files, err := listAllFiles(client)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
+	var hash func(api.File) (string, error)
+	if !sizeOnly {
+		hash = func(f api.File) (string, error) {
+			if h, ok := manifest.HashFor(f.ID); ok {
+				return h, nil
+			}
+
+			dl, err := client.DownloadFile(&f, false, io.Discard)
+			if err != nil {
+				return "", err
+			}
+
+			manifest.Record(receipts.UploadRecord{
+				SHA256:     dl.SHA256,
+				Size:       dl.Bytes,
+				FileID:     f.ID,
+				Name:       f.Name,
+				Source:     "remote",
+				UploadedAt: time.Now().UTC(),
+			})
+			return dl.SHA256, nil
+		}
+	}
+
+	groups, errs := receipts.FindDuplicates(files, hash)
+	for _, err := range errs {
+		fmt.Fprintln(os.Stderr, "Warning:", err)
+	}
+
+	if outputFormat == "json" {
+		printJSON(groups)
+		return
+	}
+
+	if len(groups) == 0 {
+		fmt.Printf("No duplicates found in %d files.\n", len(files))
+		return
+	}
+
+	var redundantIDs []string
+	var wasted int64
+	for _, g := range groups {
+		for _, f := range g.Redundant() {
+			redundantIDs = append(redundantIDs, strconv.FormatUint(uint64(f.ID), 10))
+			wasted += f.Size
+		}
+	}
+
+	fmt.Printf("Found %d duplicate groups in %d files (%d redundant, %s).\n\n", len(groups), len(files), len(redundantIDs), formatBytes(wasted))
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "GROUP\tID\tNAME\tSIZE\tCREATED\tACTION")
+	for i, g := range groups {
+		for j, f := range g.Files {
+			action := "keep"
+			if j > 0 {
+				action = "duplicate"
+			}
+			fmt.Fprintf(w, "%d\t%d\t%s\t%s\t%s\t%s\n", i+1, f.ID, f.Name, formatBytes(f.Size), localTime(f.CreatedAt), action)
+		}
+	}
+	w.Flush()
+
+	if sizeOnly {
+		fmt.Println("\nMatched by size only; run without --size-only to confirm by content.")
+		return
+	}
+
+	fmt.Printf("\nRemove duplicates with:\n  for id in %s; do skyclerk files delete $id; done\n", strings.Join(redundantIDs, " "))
+}
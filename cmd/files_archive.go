@@ -0,0 +1,232 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/bulk"
+	"github.com/cloudmanic/skyclerk-cli/internal/receipts"
+	"github.com/spf13/cobra"
+)
+
+// filesArchiveCmd downloads every receipt for a period into a zip or folder.
+var filesArchiveCmd = &cobra.Command{
+	Use:   "archive",
+	Short: "Download all receipts for a period into a zip or folder",
+	Long: `Download every file attached to the ledger entries in a period, organized
+as year/category/date_contact_amount_filename, with an index.csv linking each
+file to its ledger entry.
+
+When --out ends in .zip the files are collected in a <out>.parts folder and
+zipped once all have downloaded; otherwise they are written straight into the
+--out folder. Either way an interrupted or partly failed run can be repeated
+to resume: files already downloaded are kept once their size or, when it is
+known, their checksum matches.`,
+	Args: cobra.NoArgs,
+	Run:  runFilesArchive,
+}
+
+// archiveSummary is the JSON shape of an archive run.
+type archiveSummary struct {
+	Out        string           `json:"out"`
+	Files      int              `json:"files"`
+	Downloaded int              `json:"downloaded"`
+	Resumed    int              `json:"resumed"`
+	Failed     int              `json:"failed"`
+	Bytes      int64            `json:"bytes"`
+	Failures   []archiveFailure `json:"failures,omitempty"`
+}
+
+// archiveFailure is a file that could not be downloaded.
+type archiveFailure struct {
+	Path     string `json:"path"`
+	FileID   uint   `json:"file_id"`
+	LedgerID uint   `json:"ledger_id"`
+	Error    string `json:"error"`
+}
+
+// init registers the archive command and its flags.
+func init() {
+	filesArchiveCmd.Flags().Int("year", 0, "Archive receipts for entries in this year")
+	filesArchiveCmd.Flags().String("start", "", "Start date (see 'skyclerk help dates')")
+	filesArchiveCmd.Flags().String("end", "", "End date (see 'skyclerk help dates')")
+	filesArchiveCmd.Flags().StringP("out", "o", "", "Zip file or folder to write (default: receipts-<year>.zip)")
+	filesArchiveCmd.Flags().Int("parallel", 4, "Number of concurrent downloads")
+	filesArchiveCmd.Flags().Bool("force", false, "Overwrite an existing zip file")
+
+	filesCmd.AddCommand(filesArchiveCmd)
+}
+
+// runFilesArchive downloads the period's receipts and writes the archive.
+func runFilesArchive(cmd *cobra.Command, args []string) {
+	year, _ := cmd.Flags().GetInt("year")
+	out, _ := cmd.Flags().GetString("out")
+	parallel, _ := cmd.Flags().GetInt("parallel")
+	force, _ := cmd.Flags().GetBool("force")
+
+	client := newClient()
+	start, end := dateRangeFlags(cmd, client)
+
+	if year != 0 {
+		if start != "" || end != "" {
+			fmt.Fprintln(os.Stderr, "Error: use either --year or --start/--end")
+			os.Exit(1)
+		}
+		start, end = fmt.Sprintf("%04d-01-01", year), fmt.Sprintf("%04d-12-31", year)
+	}
+
+	if out == "" {
+		out = "receipts.zip"
+		if year != 0 {
+			out = fmt.Sprintf("receipts-%d.zip", year)
+		}
+	}
+
+	zipMode := strings.EqualFold(filepath.Ext(out), ".zip")
+	dir := out
+	if zipMode {
+		dir = out + ".parts"
+		if _, err := os.Stat(out); err == nil && !force {
+			fmt.Fprintf(os.Stderr, "Error: %s already exists (use --force to overwrite)\n", out)
+			os.Exit(1)
+		}
+	}
+
+	ledgers, err := listAllLedgers(client)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
+	items := receipts.PlanArchive(localizeLedgers(bulk.Filter{Start: start, End: end, Location: timeZone()}.Select(ledgers)))
+	if len(items) == 0 {
+		if outputFormat == "json" {
+			printJSON(archiveSummary{Out: out})
+			return
+		}
+		fmt.Println("No files are attached to ledger entries in this period.")
+		return
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
+	// Known hashes let a resumed run verify the copies it keeps.
+	manifest := openUploadManifest(client)
+	for i := range items {
+		items[i].KnownSHA256, _ = manifest.HashFor(items[i].File.ID)
+	}
+
+	tick := progress(len(items))
+	results := receipts.FetchArchive(client, dir, items, parallel, func(r receipts.ArchiveResult) {
+		status := "ok"
+		if r.Err != nil {
+			status = "failed"
+		} else if r.Resumed {
+			status = "resumed"
+		}
+		tick(status, r.Path)
+	})
+
+	summary := archiveSummary{Out: out, Files: len(results)}
+	for _, r := range results {
+		switch {
+		case r.Err != nil:
+			summary.Failed++
+			summary.Failures = append(summary.Failures, archiveFailure{
+				Path:     r.Path,
+				FileID:   r.File.ID,
+				LedgerID: r.Ledger.ID,
+				Error:    strings.TrimSpace(r.Err.Error()),
+			})
+		case r.Resumed:
+			summary.Resumed++
+		default:
+			summary.Downloaded++
+			if _, ok := manifest.HashFor(r.File.ID); !ok {
+				manifest.Record(receipts.UploadRecord{
+					SHA256:     r.SHA256,
+					Size:       r.Bytes,
+					FileID:     r.File.ID,
+					Name:       r.File.Name,
+					Source:     "remote",
+					UploadedAt: time.Now().UTC(),
+				})
+			}
+		}
+		summary.Bytes += r.Bytes
+	}
+
+	if err := writeArchive(out, dir, zipMode, summary, results); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
+	if outputFormat == "json" {
+		printJSON(summary)
+	} else {
+		printArchiveSummary(summary, zipMode, dir)
+	}
+
+	if summary.Failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// writeArchive writes the index into the folder, or zips the folder once every
+// file is present. A zip is never built from a partial download.
+func writeArchive(out string, dir string, zipMode bool, summary archiveSummary, results []receipts.ArchiveResult) error {
+	if !zipMode {
+		f, err := os.Create(filepath.Join(dir, receipts.ArchiveIndexName))
+		if err != nil {
+			return fmt.Errorf("unable to write index: %w", err)
+		}
+		if err := receipts.WriteArchiveIndex(f, results); err != nil {
+			f.Close()
+			return fmt.Errorf("unable to write index: %w", err)
+		}
+		return f.Close()
+	}
+
+	if summary.Failed > 0 {
+		return nil
+	}
+
+	if err := receipts.ZipArchive(dir, out, results); err != nil {
+		return err
+	}
+
+	return os.RemoveAll(dir)
+}
+
+// printArchiveSummary reports what was archived and any files that failed.
+func printArchiveSummary(s archiveSummary, zipMode bool, dir string) {
+	if s.Failed == 0 {
+		fmt.Printf("\nArchived %d files (%s) to %s\n", s.Files, formatBytes(s.Bytes), s.Out)
+		fmt.Printf("%d downloaded, %d already present\n", s.Downloaded, s.Resumed)
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "\nFILE ID\tLEDGER\tPATH\tERROR")
+	for _, f := range s.Failures {
+		fmt.Fprintf(w, "%d\t%d\t%s\t%s\n", f.FileID, f.LedgerID, f.Path, f.Error)
+	}
+	w.Flush()
+
+	fmt.Printf("\n%d downloaded, %d already present, %d failed\n", s.Downloaded, s.Resumed, s.Failed)
+	if zipMode {
+		fmt.Printf("The zip was not written; completed files are kept in %s. Run the same command again to resume.\n", dir)
+	} else {
+		fmt.Println("Run the same command again to retry the failed files.")
+	}
+}
@@ -0,0 +1,369 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+	"github.com/cloudmanic/skyclerk-cli/internal/journal"
+	"github.com/cloudmanic/skyclerk-cli/internal/receipts"
+	"github.com/spf13/cobra"
+)
+
+// filesImportEmlCmd imports receipts from saved email messages.
+var filesImportEmlCmd = &cobra.Command{
+	Use:   "import-eml [file...]",
+	Short: "Import receipts from saved .eml emails",
+	Long: `Import receipts from saved .eml email files.
+
+PDF and image attachments are uploaded. An email without attachments has its
+body saved as an HTML file and uploaded instead. The sender, Date header and
+any total found in the body are reported, and with --create-ledger a ledger
+entry is created from them with the uploaded files attached.`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  runFilesImportEml,
+}
+
+// emailImportResult is the outcome of importing one email.
+type emailImportResult struct {
+	Path     string     `json:"path"`
+	From     string     `json:"from"`
+	Contact  string     `json:"contact"`
+	Subject  string     `json:"subject"`
+	Date     string     `json:"date"`
+	Total    float64    `json:"total,omitempty"`
+	LedgerID uint       `json:"ledger_id,omitempty"`
+	Files    []api.File `json:"files"`
+	Skipped  []string   `json:"skipped,omitempty"`
+	Error    string     `json:"error,omitempty"`
+}
+
+// emailImportOptions holds the import-eml flags resolved once for all emails.
+type emailImportOptions struct {
+	createLedger bool
+	dryRun       bool
+	income       bool
+	amount       float64
+	force        bool
+	category     *api.Category
+	contact      *api.Contact
+	manifest     *receipts.UploadManifest
+}
+
+// init registers the import-eml command and its flags.
+func init() {
+	filesImportEmlCmd.Flags().Bool("create-ledger", false, "Create a ledger entry for each email and attach its files")
+	filesImportEmlCmd.Flags().Uint("category-id", 0, "Category for created ledger entries (required with --create-ledger)")
+	filesImportEmlCmd.Flags().Uint("contact-id", 0, "Contact for created ledger entries (default: match or create the sender)")
+	filesImportEmlCmd.Flags().Float64("amount", 0, "Amount for created ledger entries (default: total found in the email)")
+	filesImportEmlCmd.Flags().Bool("income", false, "Record created entries as income instead of expenses")
+	filesImportEmlCmd.Flags().Bool("force", false, "Upload attachments even if the same content was uploaded before")
+	filesImportEmlCmd.Flags().Bool("dry-run", false, "Show what would be imported without uploading")
+
+	filesCmd.AddCommand(filesImportEmlCmd)
+}
+
+// runFilesImportEml imports each email, continuing past failures.
+func runFilesImportEml(cmd *cobra.Command, args []string) {
+	client := newClient()
+
+	opts := emailImportOptions{}
+	opts.createLedger, _ = cmd.Flags().GetBool("create-ledger")
+	opts.dryRun, _ = cmd.Flags().GetBool("dry-run")
+	opts.income, _ = cmd.Flags().GetBool("income")
+	opts.amount, _ = cmd.Flags().GetFloat64("amount")
+	opts.force, _ = cmd.Flags().GetBool("force")
+
+	if opts.createLedger && !opts.dryRun {
+		categoryID, _ := cmd.Flags().GetUint("category-id")
+		if categoryID == 0 {
+			fmt.Fprintln(os.Stderr, "Error: --category-id is required with --create-ledger")
+			os.Exit(1)
+		}
+
+		category, err := client.GetCategory(categoryID)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error fetching category:", err)
+			os.Exit(1)
+		}
+		category.Type = categoryTypeToAPI(category.Type)
+		opts.category = category
+
+		if contactID, _ := cmd.Flags().GetUint("contact-id"); contactID > 0 {
+			contact, err := client.GetContact(contactID)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "Error fetching contact:", err)
+				os.Exit(1)
+			}
+			opts.contact = contact
+		}
+	}
+
+	opts.manifest = openUploadManifest(client)
+	uploader := &receipts.DedupUploader{Next: client, Manifest: opts.manifest, Force: opts.force}
+
+	failed := 0
+	var results []emailImportResult
+	for _, path := range args {
+		res := importEmail(client, uploader, path, opts)
+		if res.Error != "" {
+			failed++
+		}
+		results = append(results, res)
+	}
+
+	if outputFormat == "json" {
+		printJSON(results)
+	} else {
+		printEmailImportResults(results, opts.dryRun)
+	}
+
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// importEmail parses one .eml file, optionally creates its ledger entry and uploads its receipts.
+func importEmail(client *api.Client, uploader receipts.Uploader, path string, opts emailImportOptions) emailImportResult {
+	res := emailImportResult{Path: path}
+
+	f, err := os.Open(path)
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	email, err := receipts.ParseEmail(f)
+	f.Close()
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+
+	res.From = email.From
+	res.Contact = email.SenderName()
+	res.Subject = email.Subject
+	if !email.Date.IsZero() {
+		res.Date = email.Date.In(timeZone()).Format("2006-01-02")
+	}
+
+	res.Total = opts.amount
+	if res.Total == 0 {
+		if totals := receipts.FindTotals(email.BodyText()); len(totals) > 0 {
+			res.Total = totals[0].Amount
+		}
+	}
+
+	attachments := email.Attachments
+	if len(attachments) == 0 {
+		attachments = []receipts.Attachment{{Name: email.RenderName(), ContentType: "text/html", Data: email.RenderHTML()}}
+	}
+
+	if opts.dryRun {
+		for _, a := range attachments {
+			res.Files = append(res.Files, api.File{Name: a.Name, Type: a.ContentType, Size: int64(len(a.Data))})
+		}
+		return res
+	}
+
+	// An email whose receipts are all already uploaded was imported before;
+	// don't create a second ledger entry for it.
+	if !opts.force && allUploaded(opts.manifest, attachments) {
+		for _, a := range attachments {
+			rec, _ := opts.manifest.Lookup(receipts.HashBytes(a.Data))
+			res.Skipped = append(res.Skipped, fmt.Sprintf("%s: already uploaded as file %d", a.Name, rec.FileID))
+		}
+		return res
+	}
+
+	ledgerID := ""
+	attached := map[uint]bool{}
+	if opts.createLedger {
+		ledger, err := emailLedger(client, path, email, res, opts)
+		if err != nil {
+			res.Error = err.Error()
+			return res
+		}
+		res.LedgerID = ledger.ID
+		res.Contact = ledger.Contact.Name
+		ledgerID = strconv.FormatUint(uint64(ledger.ID), 10)
+		for _, f := range ledger.Files {
+			attached[f.ID] = true
+		}
+
+		// Receipts uploaded before, e.g. with another email, still belong on
+		// the new entry, so only skip the ones already attached to it.
+		uploader = &receipts.DedupUploader{Next: client, Manifest: opts.manifest, Force: true}
+	}
+
+	dir, err := os.MkdirTemp("", "skyclerk-eml-")
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	defer os.RemoveAll(dir)
+
+	for i, a := range attachments {
+		// A numbered folder per attachment keeps the original name even when two collide.
+		attachmentDir := filepath.Join(dir, strconv.Itoa(i))
+		os.Mkdir(attachmentDir, 0700)
+		tmp := filepath.Join(attachmentDir, a.Name)
+		if err := os.WriteFile(tmp, a.Data, 0600); err != nil {
+			res.Error = err.Error()
+			return res
+		}
+
+		if rec, ok := opts.manifest.Lookup(receipts.HashBytes(a.Data)); ok && attached[rec.FileID] {
+			res.Skipped = append(res.Skipped, fmt.Sprintf("%s: already attached as file %d", a.Name, rec.FileID))
+			continue
+		}
+
+		file, err := uploader.UploadFile(tmp, ledgerID)
+		if receipts.IsDuplicate(err) {
+			res.Skipped = append(res.Skipped, fmt.Sprintf("%s: %s", a.Name, err))
+			continue
+		}
+		if err != nil {
+			res.Error = strings.TrimSpace(err.Error())
+			return res
+		}
+		res.Files = append(res.Files, *file)
+	}
+
+	return res
+}
+
+// emailLedger returns the ledger entry for an email: the one an earlier,
+// interrupted import created, or a new one recorded in the manifest before
+// any receipt is uploaded so a retry doesn't create a second entry.
+func emailLedger(client *api.Client, path string, email *receipts.Email, res emailImportResult, opts emailImportOptions) (*api.Ledger, error) {
+	hash, err := receipts.HashFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if id, ok := opts.manifest.ImportedLedger(hash); ok && !opts.force {
+		if ledger, err := client.GetLedger(id); err == nil {
+			return ledger, nil
+		}
+	}
+
+	ledger, err := createEmailLedger(client, email, res, opts)
+	if err != nil {
+		return nil, err
+	}
+	recordChange(client, journal.KindLedger, journal.ActionCreate, ledger.ID, nil, ledger)
+
+	if err := opts.manifest.RecordImport(hash, ledger.ID); err != nil {
+		fmt.Fprintln(os.Stderr, "Warning:", err)
+	}
+
+	return ledger, nil
+}
+
+// allUploaded reports whether every attachment's content is already in the manifest.
+func allUploaded(manifest *receipts.UploadManifest, attachments []receipts.Attachment) bool {
+	for _, a := range attachments {
+		if _, ok := manifest.Lookup(receipts.HashBytes(a.Data)); !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// createEmailLedger creates the ledger entry for an imported email.
+func createEmailLedger(client *api.Client, email *receipts.Email, res emailImportResult, opts emailImportOptions) (*api.Ledger, error) {
+	if res.Total == 0 {
+		return nil, fmt.Errorf("no total found in email; pass --amount")
+	}
+
+	amount := -math.Abs(res.Total)
+	if opts.income {
+		amount = math.Abs(res.Total)
+	}
+
+	date := res.Date
+	if date == "" {
+		date = time.Now().Format("2006-01-02")
+	}
+
+	contact := opts.contact
+	if contact == nil {
+		contact = findOrNewContact(client, res.Contact)
+	}
+
+	return client.CreateLedger(&api.LedgerCreateRequest{
+		Amount:   amount,
+		Date:     formatDateForAPI(date),
+		Contact:  *contact,
+		Category: *opts.category,
+		Note:     email.Subject,
+	})
+}
+
+// findOrNewContact returns the existing contact with exactly this name, or a
+// new unsaved contact that the API creates along with the ledger entry.
+func findOrNewContact(client *api.Client, name string) *api.Contact {
+	contacts, err := client.GetContacts(map[string]string{"search": name})
+	if err == nil {
+		for _, c := range contacts {
+			if strings.EqualFold(c.Name, name) {
+				return &c
+			}
+		}
+	}
+
+	return &api.Contact{Name: name}
+}
+
+// printEmailImportResults renders the import results as a table.
+func printEmailImportResults(results []emailImportResult, dryRun bool) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "EMAIL\tDATE\tCONTACT\tTOTAL\tFILES\tLEDGER\tSTATUS")
+	for _, r := range results {
+		var names []string
+		for _, f := range r.Files {
+			if f.ID > 0 {
+				names = append(names, fmt.Sprintf("%s (#%d)", f.Name, f.ID))
+			} else {
+				names = append(names, f.Name)
+			}
+		}
+
+		total, ledger := "", ""
+		if r.Total != 0 {
+			total = fmt.Sprintf("%.2f", r.Total)
+		}
+		if r.LedgerID > 0 {
+			ledger = strconv.FormatUint(uint64(r.LedgerID), 10)
+		}
+
+		status := "imported"
+		if dryRun {
+			status = "dry run"
+		} else if len(r.Files) == 0 && len(r.Skipped) > 0 {
+			status = "duplicate"
+		}
+		if r.Error != "" {
+			status = "failed: " + r.Error
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", filepath.Base(r.Path), r.Date, r.Contact, total, strings.Join(names, ", "), ledger, status)
+	}
+	w.Flush()
+
+	for _, r := range results {
+		for _, s := range r.Skipped {
+			fmt.Fprintf(os.Stderr, "Skipped duplicate in %s: %s\n", filepath.Base(r.Path), s)
+		}
+	}
+}
@@ -0,0 +1,450 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+	"github.com/cloudmanic/skyclerk-cli/internal/receipts"
+	"github.com/spf13/cobra"
+)
+
+// matchChoices is how many candidate entries are offered per file when prompting.
+const matchChoices = 5
+
+// maxMatchTextSize bounds the text and HTML files downloaded to read their contents.
+const maxMatchTextSize = 1 << 20
+
+// filesMatchCmd links unattached files to the ledger entries they belong to.
+var filesMatchCmd = &cobra.Command{
+	Use:   "match [file-id...]",
+	Short: "Match unattached files to ledger entries",
+	Long: `Match files that are not attached to any ledger entry with the entries
+they most likely belong to.
+
+A date, amount and vendor are read from each file name (for example
+2026-03-03_acme_43.20.pdf) and from receipt text: the contents of text and
+HTML files, or a .txt sidecar (such as OCR output) named after the file in
+--text-dir or next to the file it was uploaded from. Ledger entries are scored
+out of 100 on amount, date proximity and contact name.
+
+By default you choose the entry for each file. With --auto the best entry is
+taken when it scores at least --min-score and no other entry ties it.
+
+The API cannot link a file after upload, so a matched file is uploaded again
+with the entry and the unattached copy is deleted; its file ID changes.`,
+	Run: runFilesMatch,
+}
+
+// matchResult is the outcome of matching one file.
+type matchResult struct {
+	FileID    uint           `json:"file_id"`
+	Name      string         `json:"name"`
+	Hints     receipts.Hints `json:"hints"`
+	LedgerID  uint           `json:"ledger_id,omitempty"`
+	Score     int            `json:"score,omitempty"`
+	NewFileID uint           `json:"new_file_id,omitempty"`
+	Status    string         `json:"status"`
+	Error     string         `json:"error,omitempty"`
+}
+
+// init registers the match command and its flags.
+func init() {
+	filesMatchCmd.Flags().Bool("auto", false, "Link the best match without prompting")
+	filesMatchCmd.Flags().Int("min-score", 80, "Lowest score (0-100) linked automatically with --auto")
+	filesMatchCmd.Flags().String("text-dir", "", "Directory of <file name>.txt receipt text, such as OCR output")
+	filesMatchCmd.Flags().Bool("dry-run", false, "Show the proposed links without changing anything")
+
+	filesCmd.AddCommand(filesMatchCmd)
+}
+
+// runFilesMatch proposes and makes links between unattached files and ledger entries.
+func runFilesMatch(cmd *cobra.Command, args []string) {
+	client := newClient()
+	manifest := openUploadManifest(client)
+
+	auto, _ := cmd.Flags().GetBool("auto")
+	minScore, _ := cmd.Flags().GetInt("min-score")
+	textDir, _ := cmd.Flags().GetString("text-dir")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	if outputFormat == "json" && !auto {
+		fmt.Fprintln(os.Stderr, "Error: --output json requires --auto")
+		os.Exit(1)
+	}
+
+	only := map[uint]bool{}
+	for _, arg := range args {
+		id, err := strconv.ParseUint(arg, 10, 64)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid file ID: %s\n", arg)
+			os.Exit(1)
+		}
+		only[uint(id)] = true
+	}
+
+	files, err := listAllFiles(client)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
+	ledgers, err := listAllLedgers(client)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
+	var unattached []api.File
+	for _, f := range receipts.Unattached(files, ledgers) {
+		if len(only) == 0 || only[f.ID] {
+			unattached = append(unattached, f)
+		}
+	}
+
+	if len(unattached) == 0 {
+		if outputFormat == "json" {
+			printJSON([]matchResult{})
+			return
+		}
+		fmt.Println("No unattached files.")
+		return
+	}
+
+	var results []matchResult
+	failed := 0
+	local := localizeLedgers(ledgers)
+	for _, f := range unattached {
+		res := matchResult{FileID: f.ID, Name: f.Name}
+
+		// A file linked by an earlier run whose original wasn't deleted only
+		// needs deleting now.
+		if link, ok := manifest.RelinkFor(f.ID); ok {
+			res.LedgerID, res.NewFileID, res.Status = link.LedgerID, link.Record.FileID, "proposed"
+			if !dryRun {
+				if err := finishRelink(client, manifest, f.ID, link); err != nil {
+					res.Status, res.Error = "failed", strings.TrimSpace(err.Error())
+					failed++
+				} else {
+					res.Status = "linked"
+				}
+			}
+			if !auto && outputFormat != "json" {
+				printMatchOutcome(res)
+			}
+			results = append(results, res)
+			continue
+		}
+
+		res.Hints = matchHints(client, manifest, f, textDir)
+		ranked := receipts.Rank(res.Hints, local)
+
+		var pick *receipts.Candidate
+		if auto {
+			pick = autoPick(ranked, minScore, &res)
+		} else {
+			var quit bool
+			pick, quit = promptPick(f, res.Hints, ranked, &res)
+			if quit {
+				break
+			}
+		}
+
+		if pick != nil {
+			res.LedgerID = pick.Ledger.ID
+			res.Score = pick.Score
+			res.Status = "proposed"
+
+			if !dryRun {
+				file, err := relinkFile(client, manifest, f, pick.Ledger.ID)
+				if file != nil {
+					res.NewFileID = file.ID
+				}
+				if err != nil {
+					res.Status = "failed"
+					res.Error = strings.TrimSpace(err.Error())
+					failed++
+				} else {
+					res.Status = "linked"
+				}
+			}
+
+			if !auto && outputFormat != "json" {
+				printMatchOutcome(res)
+			}
+		}
+
+		results = append(results, res)
+	}
+
+	if outputFormat == "json" {
+		printJSON(results)
+	} else {
+		printMatchResults(results, auto)
+	}
+
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// autoPick returns the best candidate when it clears minScore and is not tied.
+func autoPick(ranked []receipts.Candidate, minScore int, res *matchResult) *receipts.Candidate {
+	switch {
+	case len(ranked) == 0:
+		res.Status = "no match"
+		return nil
+	case ranked[0].Score < minScore:
+		res.LedgerID, res.Score = ranked[0].Ledger.ID, ranked[0].Score
+		res.Status = "below min score"
+		return nil
+	case len(ranked) > 1 && ranked[1].Score == ranked[0].Score:
+		res.Score = ranked[0].Score
+		res.Status = "ambiguous"
+		return nil
+	}
+
+	return &ranked[0]
+}
+
+// promptPick shows the best candidates for a file and asks which to link.
+// It reports quit when the user stops matching (or stdin is closed).
+func promptPick(f api.File, hints receipts.Hints, ranked []receipts.Candidate, res *matchResult) (*receipts.Candidate, bool) {
+	fmt.Printf("\nFile %d: %s\n", f.ID, f.Name)
+	fmt.Printf("  Found: %s\n", formatHints(hints))
+
+	if len(ranked) == 0 {
+		fmt.Println("  No matching ledger entries.")
+		res.Status = "no match"
+		return nil, false
+	}
+
+	if len(ranked) > matchChoices {
+		ranked = ranked[:matchChoices]
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for i, c := range ranked {
+		l := c.Ledger
+		fmt.Fprintf(w, "  %d)\tentry %d\t%s\t%.2f\t%s\tscore %d\t%s\n", i+1, l.ID, l.Date, l.Amount, l.Contact.Name, c.Score, strings.Join(c.Reasons, ", "))
+	}
+	w.Flush()
+
+	for {
+		fmt.Fprintf(os.Stderr, "Link to [1-%d], s to skip, q to quit: ", len(ranked))
+		var answer string
+		if _, err := fmt.Scanln(&answer); err == io.EOF {
+			fmt.Fprintln(os.Stderr)
+			return nil, true
+		}
+
+		switch answer = strings.ToLower(strings.TrimSpace(answer)); answer {
+		case "", "s":
+			res.Status = "skipped"
+			return nil, false
+		case "q":
+			return nil, true
+		}
+
+		if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(ranked) {
+			return &ranked[n-1], false
+		}
+	}
+}
+
+// matchHints collects what is known about a file from its name and any receipt text.
+func matchHints(client *api.Client, manifest *receipts.UploadManifest, f api.File, textDir string) receipts.Hints {
+	hints := receipts.ParseFileName(f.Name)
+
+	if text := matchText(client, manifest, f, textDir); text != "" {
+		found := receipts.ParseText(text)
+		hints = hints.Merge(found)
+
+		// Dates and amounts in a file name are deliberate, but leftover words
+		// are a weaker vendor guess than the receipt's own heading.
+		if found.Vendor != "" {
+			hints.Vendor = found.Vendor
+		}
+	}
+
+	return hints
+}
+
+// matchText returns receipt text for a file: a sidecar in textDir or next to
+// the uploaded original, or the file itself when it is a small text or HTML file.
+func matchText(client *api.Client, manifest *receipts.UploadManifest, f api.File, textDir string) string {
+	stem := strings.TrimSuffix(f.Name, filepath.Ext(f.Name))
+
+	var sidecars []string
+	if textDir != "" {
+		sidecars = append(sidecars, filepath.Join(textDir, f.Name+".txt"), filepath.Join(textDir, stem+".txt"))
+	}
+	if rec, ok := manifest.RecordFor(f.ID); ok && filepath.IsAbs(rec.Source) {
+		source := strings.TrimSuffix(rec.Source, filepath.Ext(rec.Source))
+		sidecars = append(sidecars, rec.Source+".txt", source+".txt")
+	}
+
+	for _, path := range sidecars {
+		if data, err := os.ReadFile(path); err == nil {
+			return string(data)
+		}
+	}
+
+	if !strings.HasPrefix(f.Type, "text/") || f.Size > maxMatchTextSize {
+		return ""
+	}
+
+	var buf bytes.Buffer
+	if _, err := client.DownloadFile(&f, false, &buf); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: unable to read file %d: %v\n", f.ID, err)
+		return ""
+	}
+
+	if strings.Contains(f.Type, "html") {
+		return receipts.HTMLToText(buf.String())
+	}
+
+	return buf.String()
+}
+
+// relinkFile attaches an existing file to a ledger entry. The API has no call
+// to link a file after upload, so the file is downloaded, uploaded again with
+// the entry's ID and the unattached original is deleted.
+func relinkFile(client *api.Client, manifest *receipts.UploadManifest, f api.File, ledgerID uint) (*api.File, error) {
+	dir, err := os.MkdirTemp("", "skyclerk-match-")
+	if err != nil {
+		return nil, fmt.Errorf("unable to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := downloadPath(dir, &f, false)
+	out, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create temp file: %w", err)
+	}
+
+	dl, err := client.DownloadFile(&f, false, out)
+	out.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := client.UploadFile(path, strconv.FormatUint(uint64(ledgerID), 10))
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the original source and its hash so sidecars and duplicate checks
+	// still work, since the uploaded bytes may have been optimized.
+	rec := receipts.UploadRecord{SHA256: dl.SHA256, Source: "remote"}
+	if old, ok := manifest.RecordFor(f.ID); ok && old.Source != "" {
+		rec.SHA256, rec.Source = old.SHA256, old.Source
+	}
+	rec.RemoteSHA256 = dl.SHA256
+	rec.Size = dl.Bytes
+	rec.FileID = file.ID
+	rec.Name = file.Name
+	rec.UploadedAt = time.Now().UTC()
+
+	link := receipts.Relink{LedgerID: ledgerID, Record: rec}
+	if err := finishRelink(client, manifest, f.ID, link); err != nil {
+		// Remember the copy so the next run deletes the original instead of
+		// matching it again and uploading another copy.
+		return file, errors.Join(err, manifest.Record(rec), manifest.RecordRelink(f.ID, link))
+	}
+
+	return file, nil
+}
+
+// finishRelink deletes the original of a relinked file and moves its manifest
+// record to the copy that replaced it.
+func finishRelink(client *api.Client, manifest *receipts.UploadManifest, fileID uint, link receipts.Relink) error {
+	if err := client.DeleteFile(fileID); err != nil {
+		return fmt.Errorf("linked as file %d but the original file %d was not deleted: %w", link.Record.FileID, fileID, err)
+	}
+
+	if err := manifest.Forget(fileID); err != nil {
+		return err
+	}
+
+	return manifest.Record(link.Record)
+}
+
+// formatHints describes what was read from a file for the prompt.
+func formatHints(h receipts.Hints) string {
+	if h.Empty() {
+		return "nothing"
+	}
+
+	var parts []string
+	if !h.Date.IsZero() {
+		parts = append(parts, "date "+h.Date.Format("2006-01-02"))
+	}
+	if h.Amount != 0 {
+		parts = append(parts, fmt.Sprintf("amount %.2f", h.Amount))
+	}
+	if h.Vendor != "" {
+		parts = append(parts, fmt.Sprintf("vendor %q", h.Vendor))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// printMatchOutcome reports a link made (or proposed) from the prompt.
+func printMatchOutcome(res matchResult) {
+	switch res.Status {
+	case "linked":
+		fmt.Printf("Linked %s to ledger entry %d (now file %d)\n", res.Name, res.LedgerID, res.NewFileID)
+	case "proposed":
+		fmt.Printf("Would link %s to ledger entry %d\n", res.Name, res.LedgerID)
+	case "failed":
+		fmt.Fprintf(os.Stderr, "Error linking %s to ledger entry %d: %s\n", res.Name, res.LedgerID, res.Error)
+	}
+}
+
+// printMatchResults renders the results table (for --auto) and a summary line.
+func printMatchResults(results []matchResult, auto bool) {
+	counts := map[string]int{}
+	for _, r := range results {
+		counts[r.Status]++
+	}
+
+	if auto {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "FILE ID\tNAME\tFOUND\tLEDGER\tSCORE\tSTATUS")
+		for _, r := range results {
+			ledger, score := "", ""
+			if r.LedgerID > 0 {
+				ledger = strconv.FormatUint(uint64(r.LedgerID), 10)
+			}
+			if r.Score > 0 {
+				score = strconv.Itoa(r.Score)
+			}
+
+			status := r.Status
+			if r.Status == "linked" {
+				status = fmt.Sprintf("linked (now file %d)", r.NewFileID)
+			}
+			if r.Error != "" {
+				status += ": " + r.Error
+			}
+
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n", r.FileID, r.Name, formatHints(r.Hints), ledger, score, status)
+		}
+		w.Flush()
+	}
+
+	unmatched := len(results) - counts["linked"] - counts["proposed"] - counts["failed"]
+	fmt.Printf("\n%d linked, %d proposed, %d unmatched, %d failed\n", counts["linked"], counts["proposed"], unmatched, counts["failed"])
+}
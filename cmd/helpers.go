@@ -6,20 +6,91 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/cloudmanic/skyclerk-cli/internal/api"
 	"github.com/cloudmanic/skyclerk-cli/internal/config"
+	"github.com/cloudmanic/skyclerk-cli/internal/receipts"
 )
 
-// newClient loads the config and creates a new authenticated API client.
-func newClient() *api.Client {
+// newAPIClient creates an API client with the transport selected by the global flags.
+func newAPIClient(baseURL string, accessToken string, accountID uint) *api.Client {
+	client := api.NewClient(baseURL, accessToken, accountID)
+
+	if replayDir != "" {
+		replayer, err := api.NewReplayer(replayDir)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+		client.SetTransport(replayer)
+	}
+
+	if recordDir != "" {
+		recorder, err := api.NewRecorder(recordDir, client.Transport())
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+		client.SetTransport(recorder)
+	}
+
+	if out := debugOutput(); out != nil {
+		client.SetTransport(api.NewDebugTransport(client.Transport(), out))
+	}
+
+	return client
+}
+
+// debugWriter is the open trace destination, shared by every client in the process.
+var debugWriter io.Writer
+
+// debugOutput returns where HTTP traces should go, or nil when tracing is off.
+func debugOutput() io.Writer {
+	env := os.Getenv("SKYCLERK_DEBUG")
+	enabled := debugHTTP || debugFile != "" || (env != "" && env != "0" && env != "false")
+	if !enabled {
+		return nil
+	}
+
+	if debugWriter != nil {
+		return debugWriter
+	}
+
+	debugWriter = os.Stderr
+	if debugFile != "" {
+		f, err := os.OpenFile(debugFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error opening debug file:", err)
+			os.Exit(1)
+		}
+		debugWriter = f
+	}
+
+	return debugWriter
+}
+
+// loadConfig loads the config, tolerating a missing login when replaying a cassette.
+func loadConfig() *config.Config {
 	cfg, err := config.Load()
 	if err != nil {
+		if replayDir != "" {
+			return &config.Config{}
+		}
 		fmt.Fprintln(os.Stderr, "Error:", err)
 		os.Exit(1)
 	}
 
+	return cfg
+}
+
+// newClient loads the config and creates a new authenticated API client.
+func newClient() *api.Client {
+	cfg := loadConfig()
+
 	accountID := cfg.DefaultAccountID
 	if accountOverride > 0 {
 		accountID = accountOverride
@@ -35,23 +106,74 @@ func newClient() *api.Client {
 		baseURL = config.DefaultApiURL
 	}
 
-	return api.NewClient(baseURL, cfg.AccessToken, accountID)
+	client := newAPIClient(baseURL, cfg.AccessToken, accountID)
+	if cache := newCache(cfg); cache != nil {
+		client.SetCache(cache)
+	}
+
+	return client
 }
 
-// newClientNoAccount loads the config and creates a client without requiring an account ID.
-func newClientNoAccount() (*api.Client, *config.Config) {
-	cfg, err := config.Load()
+// newCache returns the reference data cache, or nil when caching is disabled
+// or when recording/replaying (so cassettes capture every request).
+func newCache(cfg *config.Config) *api.Cache {
+	if noCache || recordDir != "" || replayDir != "" {
+		return nil
+	}
+
+	cache, err := openCache(cfg)
+	if err != nil {
+		return nil
+	}
+
+	return cache
+}
+
+// openCache opens the on-disk reference data cache using the TTL from the config.
+func openCache(cfg *config.Config) (*api.Cache, error) {
+	dir, err := config.GetCacheDir()
+	if err != nil {
+		return nil, err
+	}
+
+	var ttl time.Duration
+	if cfg.CacheTTL != "" {
+		ttl, err = time.ParseDuration(cfg.CacheTTL)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: invalid cache_ttl %q in config, using default\n", cfg.CacheTTL)
+		}
+	}
+
+	return api.NewCache(dir, ttl), nil
+}
+
+// openUploadManifest loads the upload manifest for the client's API URL and account.
+func openUploadManifest(client *api.Client) *receipts.UploadManifest {
+	dir, err := config.GetUploadsDir()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
+	manifest, err := receipts.LoadUploadManifest(config.AccountPath(dir, client.BaseURL(), client.AccountID()))
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "Error:", err)
 		os.Exit(1)
 	}
 
+	return manifest
+}
+
+// newClientNoAccount loads the config and creates a client without requiring an account ID.
+func newClientNoAccount() (*api.Client, *config.Config) {
+	cfg := loadConfig()
+
 	baseURL := cfg.ApiURL
 	if baseURL == "" {
 		baseURL = config.DefaultApiURL
 	}
 
-	return api.NewClient(baseURL, cfg.AccessToken, 0), cfg
+	return newAPIClient(baseURL, cfg.AccessToken, 0), cfg
 }
 
 // printJSON pretty-prints any value as indented JSON.
@@ -64,3 +186,38 @@ func printJSON(v interface{}) {
 
 	fmt.Println(string(data))
 }
+
+// printJSONLine outputs a value as a single line of JSON, for streamed events.
+func printJSONLine(v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error formatting JSON:", err)
+		os.Exit(1)
+	}
+
+	fmt.Println(string(data))
+}
+
+// confirm asks a yes/no question on stdin and reports whether the answer was
+// yes. The question goes to stderr so stdout holds only the command's output.
+func confirm(question string) bool {
+	fmt.Fprintf(os.Stderr, "%s [y/N]: ", question)
+	var answer string
+	fmt.Scanln(&answer)
+
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+// progress returns a function that prints a numbered line such as
+// "[3/10] ok receipt.jpg" as each of total items finishes. The lines go to
+// stderr so the output on stdout stays clean, and are left out for JSON.
+func progress(total int) func(status string, item any) {
+	done := 0
+	return func(status string, item any) {
+		done++
+		if outputFormat != "json" {
+			fmt.Fprintf(os.Stderr, "[%d/%d] %s %v\n", done, total, status, item)
+		}
+	}
+}
@@ -0,0 +1,509 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+	"github.com/cloudmanic/skyclerk-cli/internal/bulk"
+	"github.com/cloudmanic/skyclerk-cli/internal/config"
+	"github.com/cloudmanic/skyclerk-cli/internal/journal"
+	"github.com/spf13/cobra"
+)
+
+// historyCmd lists the mutating operations recorded in the undo journal.
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List recent changes made from this machine",
+	Long: `List the create, update and delete operations on ledger entries, contacts,
+categories and labels made with this CLI for the current account, newest first.
+Each one can be reversed with 'skyclerk undo <id>'.`,
+	Args: cobra.NoArgs,
+	Run:  runHistory,
+}
+
+// undoCmd reverses a recorded operation.
+var undoCmd = &cobra.Command{
+	Use:   "undo [op-id]",
+	Short: "Reverse a recorded change (default: the most recent)",
+	Long: `Reverse an operation from 'skyclerk history': created objects are deleted,
+updated objects are put back the way they were, and deleted objects are
+created again (with new IDs). Deleted ledger entries are restored from their
+delete backup, attached files included.
+
+Objects changed again since the operation are left alone unless --force is
+given. An undo is itself recorded, so it can be undone too.`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runUndo,
+}
+
+// errAlreadyUndone marks a change whose object is already back to its prior state.
+var errAlreadyUndone = errors.New("already undone")
+
+// undoVerbs describes each action in the undo report.
+var undoVerbs = map[string]string{
+	journal.ActionCreate: "Created",
+	journal.ActionUpdate: "Restored",
+	journal.ActionDelete: "Deleted",
+}
+
+// undoFailure is a change that could not be reversed.
+type undoFailure struct {
+	Change string `json:"change"`
+	Error  string `json:"error"`
+}
+
+// undoReport is the JSON shape of an undo run.
+type undoReport struct {
+	UndoOf  uint             `json:"undo_of"`
+	OpID    uint             `json:"op_id,omitempty"`
+	Changes []journal.Change `json:"changes"`
+	Skipped []string         `json:"skipped"`
+	Failed  []undoFailure    `json:"failed"`
+}
+
+// undoKind holds the client calls that reverse changes to one kind of object.
+type undoKind struct {
+	get    func(id uint) (interface{}, error)
+	create func(snapshot json.RawMessage) (uint, interface{}, error)
+	update func(id uint, snapshot json.RawMessage) (interface{}, error)
+	delete func(id uint) error
+
+	// guard, when set, can refuse to delete an object.
+	guard func(current interface{}) error
+}
+
+// init registers the history and undo commands and their flags.
+func init() {
+	historyCmd.Flags().Int("limit", 20, "Number of operations to show (0 for all)")
+
+	undoCmd.Flags().BoolP("yes", "y", false, "Don't ask for confirmation")
+	undoCmd.Flags().Bool("force", false, "Undo even if objects were changed since the operation")
+
+	rootCmd.AddCommand(historyCmd)
+	rootCmd.AddCommand(undoCmd)
+}
+
+// openJournal loads the undo journal for the client's API URL and account.
+func openJournal(client *api.Client) (*journal.Journal, error) {
+	dir, err := config.GetJournalDir()
+	if err != nil {
+		return nil, err
+	}
+
+	return journal.Load(config.AccountPath(dir, client.BaseURL(), client.AccountID()))
+}
+
+// recordOp adds a finished operation to the undo journal and refreshes any
+// remembered versions it changed. A journal that can't be written only
+// produces a warning; the change itself has been made.
+func recordOp(client *api.Client, op journal.Op) journal.Op {
+	if len(op.Changes) == 0 && op.UndoOf == 0 {
+		return op
+	}
+	refreshVersions(client, op.Changes)
+	if op.Command == "" {
+		op.Command = commandLine(os.Args[1:])
+	}
+
+	j, err := openJournal(client)
+	if err == nil {
+		op, err = j.Record(op)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Warning: unable to record this change for undo:", err)
+	}
+
+	return op
+}
+
+// commandLine formats the command's arguments for display, quoting any that
+// would not survive being pasted back into a shell.
+func commandLine(args []string) string {
+	parts := []string{"skyclerk"}
+	for _, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, " \t'\"&|;$*?<>()") {
+			arg = strconv.Quote(arg)
+		}
+		parts = append(parts, arg)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// recordChange records an operation that made a single change.
+func recordChange(client *api.Client, kind string, action string, id uint, before interface{}, after interface{}) {
+	recordOp(client, journal.Op{Changes: []journal.Change{journal.NewChange(kind, action, id, before, after)}})
+}
+
+// runHistory lists recorded operations, newest first.
+func runHistory(cmd *cobra.Command, args []string) {
+	limit, _ := cmd.Flags().GetInt("limit")
+
+	j, err := openJournal(newClient())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
+	var ops []journal.Op
+	for i := len(j.Ops) - 1; i >= 0 && (limit <= 0 || len(ops) < limit); i-- {
+		ops = append(ops, j.Ops[i])
+	}
+
+	if outputFormat == "json" {
+		if ops == nil {
+			ops = []journal.Op{}
+		}
+		printJSON(ops)
+		return
+	}
+
+	if len(ops) == 0 {
+		fmt.Println("No changes recorded yet.")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tTIME\tCHANGES\tSTATUS\tCOMMAND")
+	for _, op := range ops {
+		status := ""
+		switch {
+		case op.UndoneBy != 0:
+			status = fmt.Sprintf("undone by %d", op.UndoneBy)
+		case op.UndoOf != 0:
+			status = fmt.Sprintf("undo of %d", op.UndoOf)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n",
+			op.ID, op.Time.Local().Format("2006-01-02 15:04"), op.Summary(), status, op.Command)
+	}
+	w.Flush()
+}
+
+// runUndo reverses one operation and records the reversal.
+func runUndo(cmd *cobra.Command, args []string) {
+	yes, _ := cmd.Flags().GetBool("yes")
+	force, _ := cmd.Flags().GetBool("force")
+
+	client := newClient()
+	j, err := openJournal(client)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
+	var op journal.Op
+	var ok bool
+	if len(args) == 1 {
+		id, err := strconv.ParseUint(args[0], 10, 64)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error: invalid operation ID")
+			os.Exit(1)
+		}
+		op, ok = j.Find(uint(id))
+	} else {
+		op, ok = j.Latest()
+	}
+	if !ok {
+		fmt.Fprintln(os.Stderr, "Error: no operation to undo (see 'skyclerk history')")
+		os.Exit(1)
+	}
+	if op.UndoneBy != 0 {
+		fmt.Fprintf(os.Stderr, "Error: operation %d was already undone by operation %d\n", op.ID, op.UndoneBy)
+		os.Exit(1)
+	}
+
+	if outputFormat != "json" {
+		fmt.Printf("Undo %d: %s\n", op.ID, op.Command)
+		for i := len(op.Changes) - 1; i >= 0; i-- {
+			fmt.Printf("  %s\n", describeUndo(op.Changes[i]))
+		}
+	}
+	if !yes && !confirm("Apply?") {
+		fmt.Fprintln(os.Stderr, "Aborted.")
+		return
+	}
+
+	var backup *bulk.Backup
+	if op.Backup != "" {
+		if backup, err = bulk.ReadBackup(op.Backup); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v; deleted entries will be recreated without their files\n", err)
+		}
+	}
+
+	kinds := undoKinds(client, backup)
+	report := undoReport{UndoOf: op.ID, Changes: []journal.Change{}, Skipped: []string{}, Failed: []undoFailure{}}
+	for i := len(op.Changes) - 1; i >= 0; i-- {
+		c := op.Changes[i]
+		done, err := undoChange(kinds[c.Kind], op, c, force)
+		switch {
+		case errors.Is(err, errAlreadyUndone):
+			report.Skipped = append(report.Skipped, c.Describe())
+		case err != nil:
+			report.Failed = append(report.Failed, undoFailure{Change: c.Describe(), Error: strings.TrimSpace(err.Error())})
+		default:
+			report.Changes = append(report.Changes, done)
+		}
+	}
+
+	// A partial undo is still recorded so what was reversed can be undone, but
+	// the original is only marked undone once every change is reversed.
+	var undo journal.Op
+	if len(report.Changes) > 0 || len(report.Failed) == 0 {
+		undo = recordOp(client, journal.Op{Changes: report.Changes, UndoOf: op.ID})
+		report.OpID = undo.ID
+	}
+	if len(report.Failed) == 0 && undo.ID != 0 {
+		if j, err := openJournal(client); err == nil {
+			j.MarkUndone(op.ID, undo.ID)
+		}
+	}
+
+	if outputFormat == "json" {
+		printJSON(report)
+	} else {
+		printUndoReport(report)
+	}
+
+	if len(report.Failed) > 0 {
+		os.Exit(1)
+	}
+}
+
+// describeUndo says what undoing a change will do.
+func describeUndo(c journal.Change) string {
+	switch c.Action {
+	case journal.ActionCreate:
+		return fmt.Sprintf("delete %s %d", c.Kind, c.ID)
+	case journal.ActionUpdate:
+		return fmt.Sprintf("restore %s %d to its previous values", c.Kind, c.ID)
+	default:
+		return fmt.Sprintf("recreate %s %d", c.Kind, c.ID)
+	}
+}
+
+// undoChange reverses one change and returns the change that made.
+func undoChange(k undoKind, op journal.Op, c journal.Change, force bool) (journal.Change, error) {
+	if k.get == nil {
+		return journal.Change{}, fmt.Errorf("cannot undo changes to %s", c.Kind)
+	}
+
+	modified := fmt.Errorf("%s %d has changed since operation %d (use --force to undo anyway)", c.Kind, c.ID, op.ID)
+
+	switch c.Action {
+	case journal.ActionCreate:
+		current, err := k.get(c.ID)
+		if err != nil {
+			return journal.Change{}, err
+		}
+		if !force && journal.Modified(current, c.After) {
+			return journal.Change{}, modified
+		}
+		if k.guard != nil && !force {
+			if err := k.guard(current); err != nil {
+				return journal.Change{}, err
+			}
+		}
+		if err := k.delete(c.ID); err != nil {
+			return journal.Change{}, err
+		}
+		return journal.NewChange(c.Kind, journal.ActionDelete, c.ID, current, nil), nil
+
+	case journal.ActionUpdate:
+		current, err := k.get(c.ID)
+		if err != nil {
+			return journal.Change{}, err
+		}
+		if !journal.Modified(current, c.Before) {
+			return journal.Change{}, errAlreadyUndone
+		}
+		if !force && journal.Modified(current, c.After) {
+			return journal.Change{}, modified
+		}
+		updated, err := k.update(c.ID, c.Before)
+		if err != nil {
+			return journal.Change{}, err
+		}
+		return journal.NewChange(c.Kind, journal.ActionUpdate, c.ID, current, updated), nil
+
+	case journal.ActionDelete:
+		id, created, err := k.create(c.Before)
+		if err != nil {
+			return journal.Change{}, err
+		}
+		return journal.NewChange(c.Kind, journal.ActionCreate, id, nil, created), nil
+	}
+
+	return journal.Change{}, fmt.Errorf("unknown action %q", c.Action)
+}
+
+// undoKinds returns the client calls for each kind of object. Deleted ledger
+// entries found in backup are restored from it, files included.
+func undoKinds(client *api.Client, backup *bulk.Backup) map[string]undoKind {
+	return map[string]undoKind{
+		journal.KindLedger: {
+			get: func(id uint) (interface{}, error) { return client.GetLedger(id) },
+			create: func(snapshot json.RawMessage) (uint, interface{}, error) {
+				var l api.Ledger
+				if err := json.Unmarshal(snapshot, &l); err != nil {
+					return 0, nil, err
+				}
+				if backup != nil && backupHas(backup, l.ID) {
+					if newID := backup.Restored[l.ID]; newID != 0 {
+						return 0, nil, errAlreadyUndone
+					}
+					res, created := restoreLedger(client, backup, l)
+					if created == nil {
+						return 0, nil, errors.New(res.Error)
+					}
+					if res.Error != "" {
+						fmt.Fprintf(os.Stderr, "Warning: ledger entry %d was restored as %d but %s\n", l.ID, created.ID, res.Error)
+					}
+					return created.ID, created, nil
+				}
+				created, err := client.CreateLedger(ledgerCreateRequest(l))
+				if err != nil {
+					return 0, nil, err
+				}
+				return created.ID, created, nil
+			},
+			update: func(id uint, snapshot json.RawMessage) (interface{}, error) {
+				var l api.Ledger
+				if err := json.Unmarshal(snapshot, &l); err != nil {
+					return nil, err
+				}
+				req := ledgerCreateRequest(l)
+				labels := append([]api.Label{}, req.Labels...)
+				return client.UpdateLedger(id, &api.LedgerUpdateRequest{
+					Amount:   &req.Amount,
+					Date:     &req.Date,
+					Contact:  &req.Contact,
+					Category: &req.Category,
+					Labels:   &labels,
+					Note:     &req.Note,
+				})
+			},
+			delete: client.DeleteLedger,
+			guard: func(current interface{}) error {
+				if l := current.(*api.Ledger); len(l.Files) > 0 {
+					return fmt.Errorf("ledger %d has %d attached files that would be deleted (use --force to undo anyway)", l.ID, len(l.Files))
+				}
+				return nil
+			},
+		},
+		journal.KindContact: {
+			get: func(id uint) (interface{}, error) { return client.GetContact(id) },
+			create: func(snapshot json.RawMessage) (uint, interface{}, error) {
+				var c api.Contact
+				if err := json.Unmarshal(snapshot, &c); err != nil {
+					return 0, nil, err
+				}
+				created, err := client.CreateContact(&api.ContactCreateRequest{
+					Name: c.Name, FirstName: c.FirstName, LastName: c.LastName, Email: c.Email,
+					Phone: c.Phone, Address: c.Address, City: c.City, State: c.State, Zip: c.Zip,
+					Country: c.Country, Website: c.Website, AccountNumber: c.AccountNumber,
+				})
+				if err != nil {
+					return 0, nil, err
+				}
+				return created.ID, created, nil
+			},
+			update: func(id uint, snapshot json.RawMessage) (interface{}, error) {
+				var c api.Contact
+				if err := json.Unmarshal(snapshot, &c); err != nil {
+					return nil, err
+				}
+				return client.UpdateContact(id, &api.ContactUpdateRequest{
+					Name: &c.Name, FirstName: &c.FirstName, LastName: &c.LastName, Email: &c.Email,
+					Phone: &c.Phone, Address: &c.Address, City: &c.City, State: &c.State, Zip: &c.Zip,
+					Country: &c.Country, Website: &c.Website, AccountNumber: &c.AccountNumber,
+				})
+			},
+			delete: client.DeleteContact,
+		},
+		journal.KindCategory: {
+			get: func(id uint) (interface{}, error) { return client.GetCategory(id) },
+			create: func(snapshot json.RawMessage) (uint, interface{}, error) {
+				var c api.Category
+				if err := json.Unmarshal(snapshot, &c); err != nil {
+					return 0, nil, err
+				}
+				created, err := client.CreateCategory(&api.CategoryCreateRequest{Name: c.Name, Type: strings.ToLower(c.Type)})
+				if err != nil {
+					return 0, nil, err
+				}
+				return created.ID, created, nil
+			},
+			update: func(id uint, snapshot json.RawMessage) (interface{}, error) {
+				var c api.Category
+				if err := json.Unmarshal(snapshot, &c); err != nil {
+					return nil, err
+				}
+				return client.UpdateCategory(id, &api.CategoryUpdateRequest{Name: c.Name, Type: strings.ToLower(c.Type)})
+			},
+			delete: client.DeleteCategory,
+		},
+		journal.KindLabel: {
+			get: func(id uint) (interface{}, error) { return client.GetLabel(id) },
+			create: func(snapshot json.RawMessage) (uint, interface{}, error) {
+				var l api.Label
+				if err := json.Unmarshal(snapshot, &l); err != nil {
+					return 0, nil, err
+				}
+				created, err := client.CreateLabel(&api.LabelCreateRequest{Name: l.Name})
+				if err != nil {
+					return 0, nil, err
+				}
+				return created.ID, created, nil
+			},
+			update: func(id uint, snapshot json.RawMessage) (interface{}, error) {
+				var l api.Label
+				if err := json.Unmarshal(snapshot, &l); err != nil {
+					return nil, err
+				}
+				return client.UpdateLabel(id, &api.LabelUpdateRequest{Name: l.Name})
+			},
+			delete: client.DeleteLabel,
+		},
+	}
+}
+
+// backupHas reports whether a backup holds an entry.
+func backupHas(backup *bulk.Backup, id uint) bool {
+	for _, l := range backup.Ledgers {
+		if l.ID == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+// printUndoReport lists what the undo changed, skipped and failed to change.
+func printUndoReport(r undoReport) {
+	for _, c := range r.Changes {
+		fmt.Printf("%s %s %d\n", undoVerbs[c.Action], c.Kind, c.ID)
+	}
+	for _, s := range r.Skipped {
+		fmt.Printf("Skipped %s: already undone\n", s)
+	}
+	for _, f := range r.Failed {
+		fmt.Fprintf(os.Stderr, "Error: %s: %s\n", f.Change, f.Error)
+	}
+
+	if len(r.Failed) == 0 {
+		fmt.Printf("Undid operation %d", r.UndoOf)
+		if r.OpID != 0 {
+			fmt.Printf(" (undo it with 'skyclerk undo %d')", r.OpID)
+		}
+		fmt.Println()
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"text/tabwriter"
 
 	"github.com/cloudmanic/skyclerk-cli/internal/api"
+	"github.com/cloudmanic/skyclerk-cli/internal/journal"
 	"github.com/spf13/cobra"
 )
 
@@ -134,6 +135,7 @@ func runLabelsCreate(cmd *cobra.Command, args []string) {
 		fmt.Fprintln(os.Stderr, "Error:", err)
 		os.Exit(1)
 	}
+	recordChange(client, journal.KindLabel, journal.ActionCreate, label.ID, nil, label)
 
 	if outputFormat == "json" {
 		printJSON(label)
@@ -153,6 +155,12 @@ func runLabelsUpdate(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
+	before, err := client.GetLabel(uint(id))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
 	name, _ := cmd.Flags().GetString("name")
 
 	label, err := client.UpdateLabel(uint(id), &api.LabelUpdateRequest{Name: name})
@@ -160,6 +168,7 @@ func runLabelsUpdate(cmd *cobra.Command, args []string) {
 		fmt.Fprintln(os.Stderr, "Error:", err)
 		os.Exit(1)
 	}
+	recordChange(client, journal.KindLabel, journal.ActionUpdate, label.ID, before, label)
 
 	if outputFormat == "json" {
 		printJSON(label)
@@ -179,10 +188,17 @@ func runLabelsDelete(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
+	before, err := client.GetLabel(uint(id))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
 	if err := client.DeleteLabel(uint(id)); err != nil {
 		fmt.Fprintln(os.Stderr, "Error:", err)
 		os.Exit(1)
 	}
+	recordChange(client, journal.KindLabel, journal.ActionDelete, uint(id), before, nil)
 
 	fmt.Printf("Deleted label %d\n", id)
 }
@@ -6,12 +6,17 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
 	"text/tabwriter"
 
 	"strings"
 
 	"github.com/cloudmanic/skyclerk-cli/internal/api"
+	"github.com/cloudmanic/skyclerk-cli/internal/conflict"
+	"github.com/cloudmanic/skyclerk-cli/internal/dates"
+	"github.com/cloudmanic/skyclerk-cli/internal/journal"
+	"github.com/cloudmanic/skyclerk-cli/internal/receipts"
 	"github.com/spf13/cobra"
 )
 
@@ -28,11 +33,11 @@ func categoryTypeToAPI(t string) string {
 	}
 }
 
-// formatDateForAPI appends T00:00:00Z to a YYYY-MM-DD date string if it
-// doesn't already contain a time component.
+// formatDateForAPI turns a YYYY-MM-DD date string into the moment that day
+// starts in the user's time zone. Values with a time component pass through.
 func formatDateForAPI(date string) string {
 	if !strings.Contains(date, "T") {
-		return date + "T00:00:00Z"
+		return dates.Instant(date, timeZone())
 	}
 	return date
 }
@@ -47,7 +52,12 @@ var ledgerCmd = &cobra.Command{
 var ledgerListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List ledger entries",
-	Run:   runLedgerList,
+	Long: `List ledger entries, newest first.
+
+The filter flags (--category-id, --contact-id, --label-id, --start, --end,
+--type, --search) are applied after fetching every entry, so a filtered list
+takes longer on large accounts.`,
+	Run: runLedgerList,
 }
 
 // ledgerGetCmd retrieves a single ledger entry by ID.
@@ -62,7 +72,10 @@ var ledgerGetCmd = &cobra.Command{
 var ledgerCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a new ledger entry",
-	Run:   runLedgerCreate,
+	Long: `Create a new ledger entry. --amount, --contact-id and --category-id are
+required unless --template supplies them; flags given alongside a template
+replace its values (see 'skyclerk ledger template').`,
+	Run: runLedgerCreate,
 }
 
 // ledgerUpdateCmd updates an existing ledger entry.
@@ -73,12 +86,34 @@ var ledgerUpdateCmd = &cobra.Command{
 	Run:   runLedgerUpdate,
 }
 
-// ledgerDeleteCmd deletes a ledger entry.
-var ledgerDeleteCmd = &cobra.Command{
-	Use:   "delete [id]",
-	Short: "Delete a ledger entry",
+// ledgerFilesCmd lists the files attached to a ledger entry.
+var ledgerFilesCmd = &cobra.Command{
+	Use:   "files [ledger-id]",
+	Short: "List files attached to a ledger entry",
 	Args:  cobra.ExactArgs(1),
-	Run:   runLedgerDelete,
+	Run:   runLedgerFiles,
+}
+
+// ledgerAttachCmd uploads files and attaches them to a ledger entry.
+var ledgerAttachCmd = &cobra.Command{
+	Use:   "attach [ledger-id] [path|glob...]",
+	Short: "Upload files and attach them to a ledger entry",
+	Args:  cobra.MinimumNArgs(2),
+	Run:   runLedgerAttach,
+}
+
+// ledgerDetachCmd removes files from a ledger entry.
+var ledgerDetachCmd = &cobra.Command{
+	Use:   "detach [ledger-id] [file-id...]",
+	Short: "Delete attached files from a ledger entry",
+	Long: `Delete attached files from a ledger entry.
+
+The API has no way to unlink a file while keeping it, so detaching deletes the
+file from Skyclerk. The files are listed and must be confirmed, or --yes given.
+Pass --save-to to download a copy of each file first; a file whose download
+fails is not deleted.`,
+	Args: cobra.MinimumNArgs(2),
+	Run:  runLedgerDetach,
 }
 
 // ledgerSummaryCmd displays a ledger summary.
@@ -94,33 +129,45 @@ func init() {
 	ledgerListCmd.Flags().String("limit", "25", "Number of entries to return")
 	ledgerListCmd.Flags().String("page", "1", "Page number")
 	ledgerListCmd.Flags().String("sort", "DESC", "Sort direction (ASC or DESC)")
+	addLedgerFilterFlags(ledgerListCmd)
 
 	// Create flags.
 	ledgerCreateCmd.Flags().Float64("amount", 0, "Transaction amount (negative for expense)")
-	ledgerCreateCmd.Flags().String("date", "", "Transaction date (YYYY-MM-DD)")
+	ledgerCreateCmd.Flags().String("date", "", "Transaction date, e.g. 2026-03-05 or yesterday (see 'skyclerk help dates')")
 	ledgerCreateCmd.Flags().Uint("contact-id", 0, "Contact ID")
 	ledgerCreateCmd.Flags().Uint("category-id", 0, "Category ID")
 	ledgerCreateCmd.Flags().String("note", "", "Transaction note")
 	ledgerCreateCmd.Flags().UintSlice("label-id", nil, "Label ID (can be specified multiple times)")
-	ledgerCreateCmd.MarkFlagRequired("amount")
+	ledgerCreateCmd.Flags().StringSlice("attach", nil, "Upload and attach a receipt (can be specified multiple times)")
+	ledgerCreateCmd.Flags().String("template", "", "Start from a saved template (see 'skyclerk ledger template')")
 	ledgerCreateCmd.MarkFlagRequired("date")
-	ledgerCreateCmd.MarkFlagRequired("contact-id")
-	ledgerCreateCmd.MarkFlagRequired("category-id")
 
 	// Update flags.
 	ledgerUpdateCmd.Flags().Float64("amount", 0, "Transaction amount")
-	ledgerUpdateCmd.Flags().String("date", "", "Transaction date (YYYY-MM-DD)")
+	ledgerUpdateCmd.Flags().String("date", "", "Transaction date, e.g. 2026-03-05 or yesterday (see 'skyclerk help dates')")
 	ledgerUpdateCmd.Flags().Uint("contact-id", 0, "Contact ID")
 	ledgerUpdateCmd.Flags().Uint("category-id", 0, "Category ID")
-	ledgerUpdateCmd.Flags().String("note", "", "Transaction note")
-	ledgerUpdateCmd.Flags().UintSlice("label-id", nil, "Label ID (can be specified multiple times)")
+	ledgerUpdateCmd.Flags().String("note", "", "Transaction note (--note \"\" clears it)")
+	ledgerUpdateCmd.Flags().UintSlice("label-id", nil, "Label ID, replacing the entry's labels (can be specified multiple times)")
+	ledgerUpdateCmd.Flags().Bool("clear-labels", false, "Remove every label from the entry")
+	addConflictFlags(ledgerUpdateCmd, true)
+
+	// Attach flags.
+	ledgerAttachCmd.Flags().Bool("force", false, "Upload even if the same content was uploaded before")
+	ledgerAttachCmd.Flags().Int("parallel", 4, "Number of concurrent uploads")
+
+	// Detach flags.
+	ledgerDetachCmd.Flags().BoolP("yes", "y", false, "Don't ask for confirmation")
+	ledgerDetachCmd.Flags().String("save-to", "", "Download each file into this folder before deleting it")
 
 	ledgerCmd.AddCommand(ledgerListCmd)
 	ledgerCmd.AddCommand(ledgerGetCmd)
 	ledgerCmd.AddCommand(ledgerCreateCmd)
 	ledgerCmd.AddCommand(ledgerUpdateCmd)
-	ledgerCmd.AddCommand(ledgerDeleteCmd)
 	ledgerCmd.AddCommand(ledgerSummaryCmd)
+	ledgerCmd.AddCommand(ledgerFilesCmd)
+	ledgerCmd.AddCommand(ledgerAttachCmd)
+	ledgerCmd.AddCommand(ledgerDetachCmd)
 	rootCmd.AddCommand(ledgerCmd)
 }
 
@@ -138,7 +185,14 @@ func runLedgerList(cmd *cobra.Command, args []string) {
 		"sort":  sort,
 	}
 
-	ledgers, err := client.GetLedgers(params)
+	// The API has no filters, so filtered lists are built from every entry.
+	var ledgers []api.Ledger
+	var err error
+	if filter := ledgerFilterFromFlags(cmd, client); filter.Empty() {
+		ledgers, err = client.GetLedgers(params)
+	} else {
+		ledgers, err = listFilteredLedgers(client, filter, limit, page, sort)
+	}
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "Error:", err)
 		os.Exit(1)
@@ -153,7 +207,7 @@ func runLedgerList(cmd *cobra.Command, args []string) {
 	fmt.Fprintln(w, "ID\tDATE\tAMOUNT\tCONTACT\tCATEGORY\tNOTE")
 	for _, l := range ledgers {
 		fmt.Fprintf(w, "%d\t%s\t%.2f\t%s\t%s\t%s\n",
-			l.ID, l.Date, l.Amount, l.Contact.Name, l.Category.Name, l.Note)
+			l.ID, localDay(l.Date), l.Amount, l.Contact.Name, l.Category.Name, l.Note)
 	}
 	w.Flush()
 }
@@ -173,6 +227,7 @@ func runLedgerGet(cmd *cobra.Command, args []string) {
 		fmt.Fprintln(os.Stderr, "Error:", err)
 		os.Exit(1)
 	}
+	rememberVersion(client, journal.KindLedger, ledger.ID, ledger)
 
 	if outputFormat == "json" {
 		printJSON(ledger)
@@ -180,7 +235,7 @@ func runLedgerGet(cmd *cobra.Command, args []string) {
 	}
 
 	fmt.Printf("ID:        %d\n", ledger.ID)
-	fmt.Printf("Date:      %s\n", ledger.Date)
+	fmt.Printf("Date:      %s\n", localDay(ledger.Date))
 	fmt.Printf("Amount:    %.2f\n", ledger.Amount)
 	fmt.Printf("Contact:   %s\n", ledger.Contact.Name)
 	fmt.Printf("Category:  %s\n", ledger.Category.Name)
@@ -198,8 +253,12 @@ func runLedgerGet(cmd *cobra.Command, args []string) {
 		fmt.Println()
 	}
 	if len(ledger.Files) > 0 {
-		fmt.Printf("Files:     %d attached\n", len(ledger.Files))
+		fmt.Printf("Files:     %d attached (skyclerk ledger files %d)\n", len(ledger.Files), ledger.ID)
+	}
+	if ledger.UpdatedAt != "" {
+		fmt.Printf("Updated:   %s\n", localTime(ledger.UpdatedAt))
 	}
+	fmt.Printf("Version:   %s\n", conflict.Hash(ledger))
 }
 
 // runLedgerCreate creates a new ledger entry from flags.
@@ -207,10 +266,42 @@ func runLedgerCreate(cmd *cobra.Command, args []string) {
 	client := newClient()
 
 	amount, _ := cmd.Flags().GetFloat64("amount")
-	date, _ := cmd.Flags().GetString("date")
+	date := dayFlag(cmd, client, "date")
 	contactID, _ := cmd.Flags().GetUint("contact-id")
 	categoryID, _ := cmd.Flags().GetUint("category-id")
 	note, _ := cmd.Flags().GetString("note")
+	labelIDs, _ := cmd.Flags().GetUintSlice("label-id")
+
+	// Fill in what the flags leave out from the template.
+	if name, _ := cmd.Flags().GetString("template"); name != "" {
+		t := findTemplate(openTemplates(client), name)
+		if !cmd.Flags().Changed("amount") && t.Amount != nil {
+			amount = *t.Amount
+		}
+		if !cmd.Flags().Changed("contact-id") {
+			contactID = t.Contact.ID
+		}
+		if !cmd.Flags().Changed("category-id") {
+			categoryID = t.Category.ID
+		}
+		if !cmd.Flags().Changed("label-id") {
+			labelIDs = t.LabelIDs()
+		}
+		if !cmd.Flags().Changed("note") {
+			note = t.Note
+		}
+		if !cmd.Flags().Changed("amount") && t.Amount == nil {
+			fmt.Fprintf(os.Stderr, "Error: template %q has no amount; give one with --amount\n", t.Name)
+			os.Exit(1)
+		}
+	} else {
+		for _, name := range []string{"amount", "contact-id", "category-id"} {
+			if !cmd.Flags().Changed(name) {
+				fmt.Fprintf(os.Stderr, "Error: required flag \"%s\" not set (or use --template)\n", name)
+				os.Exit(1)
+			}
+		}
+	}
 
 	// Fetch the contact by ID.
 	contact, err := client.GetContact(contactID)
@@ -231,7 +322,6 @@ func runLedgerCreate(cmd *cobra.Command, args []string) {
 
 	// Fetch labels by ID if provided.
 	var labels []api.Label
-	labelIDs, _ := cmd.Flags().GetUintSlice("label-id")
 	for _, lid := range labelIDs {
 		label, err := client.GetLabel(lid)
 		if err != nil {
@@ -250,18 +340,53 @@ func runLedgerCreate(cmd *cobra.Command, args []string) {
 		Note:     note,
 	}
 
+	// Check receipts exist before creating anything.
+	attach, _ := cmd.Flags().GetStringSlice("attach")
+	for _, path := range attach {
+		if info, err := os.Stat(path); err != nil || !info.Mode().IsRegular() {
+			fmt.Fprintf(os.Stderr, "Error: file not found: %s\n", path)
+			os.Exit(1)
+		}
+	}
+
 	ledger, err := client.CreateLedger(req)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "Error:", err)
 		os.Exit(1)
 	}
+	recordChange(client, journal.KindLedger, journal.ActionCreate, ledger.ID, nil, ledger)
+
+	attached := attachNewReceipts(client, ledger.ID, attach)
+	ledger.Files = append(ledger.Files, attached...)
 
 	if outputFormat == "json" {
 		printJSON(ledger)
 		return
 	}
 
-	fmt.Printf("Created ledger entry %d (%.2f on %s)\n", ledger.ID, ledger.Amount, ledger.Date)
+	fmt.Printf("Created ledger entry %d (%.2f on %s)\n", ledger.ID, ledger.Amount, localDay(ledger.Date))
+
+	for _, f := range attached {
+		fmt.Printf("Attached file %d: %s\n", f.ID, f.Name)
+	}
+}
+
+// attachNewReceipts uploads receipts for a just-created ledger entry. Duplicate
+// content is still attached, since the user named these files explicitly.
+func attachNewReceipts(client *api.Client, ledgerID uint, paths []string) []api.File {
+	uploader := &receipts.DedupUploader{Next: client, Manifest: openUploadManifest(client), Force: true}
+
+	var files []api.File
+	for _, path := range paths {
+		file, err := uploader.UploadFile(path, strconv.FormatUint(uint64(ledgerID), 10))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: ledger entry %d was created but attaching %s failed: %v\n", ledgerID, path, err)
+			os.Exit(1)
+		}
+		files = append(files, *file)
+	}
+
+	return files
 }
 
 // runLedgerUpdate updates an existing ledger entry from flags.
@@ -274,15 +399,28 @@ func runLedgerUpdate(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
+	// Fetch the entry as it is now to check for conflicting edits and so the
+	// update can be undone.
+	before, err := client.GetLedger(uint(id))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+	checkUnmodified(cmd, client, journal.KindLedger, before.ID, before, before.UpdatedAt)
+
 	req := &api.LedgerUpdateRequest{}
 
 	if cmd.Flags().Changed("amount") {
 		amount, _ := cmd.Flags().GetFloat64("amount")
-		req.Amount = amount
+		req.Amount = &amount
 	}
 	if cmd.Flags().Changed("date") {
-		date, _ := cmd.Flags().GetString("date")
-		req.Date = formatDateForAPI(date)
+		date := dayFlag(cmd, client, "date")
+		if date == "" {
+			fmt.Fprintln(os.Stderr, "Error: --date can't be empty")
+			os.Exit(1)
+		}
+		req.Date = api.Ptr(formatDateForAPI(date))
 	}
 	if cmd.Flags().Changed("contact-id") {
 		contactID, _ := cmd.Flags().GetUint("contact-id")
@@ -291,7 +429,7 @@ func runLedgerUpdate(cmd *cobra.Command, args []string) {
 			fmt.Fprintln(os.Stderr, "Error fetching contact:", err)
 			os.Exit(1)
 		}
-		req.Contact = *contact
+		req.Contact = contact
 	}
 	if cmd.Flags().Changed("category-id") {
 		categoryID, _ := cmd.Flags().GetUint("category-id")
@@ -301,15 +439,22 @@ func runLedgerUpdate(cmd *cobra.Command, args []string) {
 			os.Exit(1)
 		}
 		category.Type = categoryTypeToAPI(category.Type)
-		req.Category = *category
+		req.Category = category
 	}
 	if cmd.Flags().Changed("note") {
 		note, _ := cmd.Flags().GetString("note")
-		req.Note = note
+		req.Note = &note
+	}
+	if clearLabels, _ := cmd.Flags().GetBool("clear-labels"); clearLabels {
+		if cmd.Flags().Changed("label-id") {
+			fmt.Fprintln(os.Stderr, "Error: use either --label-id or --clear-labels")
+			os.Exit(1)
+		}
+		req.Labels = &[]api.Label{}
 	}
 	if cmd.Flags().Changed("label-id") {
 		labelIDs, _ := cmd.Flags().GetUintSlice("label-id")
-		var labels []api.Label
+		labels := []api.Label{}
 		for _, lid := range labelIDs {
 			label, err := client.GetLabel(lid)
 			if err != nil {
@@ -318,7 +463,7 @@ func runLedgerUpdate(cmd *cobra.Command, args []string) {
 			}
 			labels = append(labels, *label)
 		}
-		req.Labels = labels
+		req.Labels = &labels
 	}
 
 	ledger, err := client.UpdateLedger(uint(id), req)
@@ -326,6 +471,7 @@ func runLedgerUpdate(cmd *cobra.Command, args []string) {
 		fmt.Fprintln(os.Stderr, "Error:", err)
 		os.Exit(1)
 	}
+	recordChange(client, journal.KindLedger, journal.ActionUpdate, ledger.ID, before, ledger)
 
 	if outputFormat == "json" {
 		printJSON(ledger)
@@ -335,24 +481,6 @@ func runLedgerUpdate(cmd *cobra.Command, args []string) {
 	fmt.Printf("Updated ledger entry %d\n", ledger.ID)
 }
 
-// runLedgerDelete deletes a ledger entry by ID.
-func runLedgerDelete(cmd *cobra.Command, args []string) {
-	client := newClient()
-
-	id, err := strconv.ParseUint(args[0], 10, 64)
-	if err != nil {
-		fmt.Fprintln(os.Stderr, "Error: invalid ledger ID")
-		os.Exit(1)
-	}
-
-	if err := client.DeleteLedger(uint(id)); err != nil {
-		fmt.Fprintln(os.Stderr, "Error:", err)
-		os.Exit(1)
-	}
-
-	fmt.Printf("Deleted ledger entry %d\n", id)
-}
-
 // runLedgerSummary displays the ledger summary.
 func runLedgerSummary(cmd *cobra.Command, args []string) {
 	client := newClient()
@@ -401,3 +529,184 @@ func runLedgerSummary(cmd *cobra.Command, args []string) {
 		w.Flush()
 	}
 }
+
+// runLedgerFiles lists the files attached to a ledger entry.
+func runLedgerFiles(cmd *cobra.Command, args []string) {
+	client := newClient()
+
+	id, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error: invalid ledger ID")
+		os.Exit(1)
+	}
+
+	ledger, err := client.GetLedger(uint(id))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
+	if outputFormat == "json" {
+		printJSON(ledger.Files)
+		return
+	}
+
+	if len(ledger.Files) == 0 {
+		fmt.Printf("No files attached to ledger entry %d\n", ledger.ID)
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tTYPE\tSIZE\tURL")
+	for _, f := range ledger.Files {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", f.ID, f.Name, f.Type, formatBytes(f.Size), f.URL)
+	}
+	w.Flush()
+}
+
+// runLedgerAttach uploads files and attaches them to an existing ledger entry.
+func runLedgerAttach(cmd *cobra.Command, args []string) {
+	client := newClient()
+
+	id, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error: invalid ledger ID")
+		os.Exit(1)
+	}
+
+	force, _ := cmd.Flags().GetBool("force")
+	parallel, _ := cmd.Flags().GetInt("parallel")
+
+	paths, err := receipts.ExpandPaths(args[1:], "")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
+	// Fail early with a clear message rather than once per file.
+	if _, err := client.GetLedger(uint(id)); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
+	// The ledger ID is explicit here, so sidecars and manifests don't apply.
+	jobs := make([]receipts.Job, len(paths))
+	for i, p := range paths {
+		jobs[i] = receipts.Job{Path: p, LedgerID: args[0]}
+	}
+
+	uploader := &receipts.DedupUploader{Next: client, Manifest: openUploadManifest(client), Force: force}
+
+	if len(jobs) == 1 {
+		uploadSingleFile(uploader, jobs[0])
+		return
+	}
+
+	uploadBatch(uploader, jobs, parallel)
+}
+
+// runLedgerDetach deletes files attached to a ledger entry after confirming
+// they belong to it.
+func runLedgerDetach(cmd *cobra.Command, args []string) {
+	client := newClient()
+
+	id, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error: invalid ledger ID")
+		os.Exit(1)
+	}
+
+	ledger, err := client.GetLedger(uint(id))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
+	attached := map[uint]api.File{}
+	for _, f := range ledger.Files {
+		attached[f.ID] = f
+	}
+
+	var targets []api.File
+	for _, arg := range args[1:] {
+		fileID, err := strconv.ParseUint(arg, 10, 64)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid file ID: %s\n", arg)
+			os.Exit(1)
+		}
+
+		f, ok := attached[uint(fileID)]
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Error: file %d is not attached to ledger entry %d\n", fileID, ledger.ID)
+			os.Exit(1)
+		}
+		targets = append(targets, f)
+	}
+
+	saveTo, _ := cmd.Flags().GetString("save-to")
+	if saveTo != "" {
+		if err := os.MkdirAll(saveTo, 0755); err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+	}
+
+	if yes, _ := cmd.Flags().GetBool("yes"); !yes {
+		fmt.Fprintf(os.Stderr, "These files will be deleted from Skyclerk, not just detached:\n")
+		for _, f := range targets {
+			fmt.Fprintf(os.Stderr, "  %d  %s (%s)\n", f.ID, f.Name, formatBytes(f.Size))
+		}
+		if saveTo == "" {
+			fmt.Fprintln(os.Stderr, "Pass --save-to <folder> to download copies first.")
+		}
+		if !confirm(fmt.Sprintf("Delete %d file(s) from ledger entry %d?", len(targets), ledger.ID)) {
+			fmt.Fprintln(os.Stderr, "Aborted.")
+			return
+		}
+	}
+
+	manifest := openUploadManifest(client)
+	for _, f := range targets {
+		if saveTo != "" {
+			// Never overwrite an earlier copy, such as another file of the same name.
+			dest := downloadPath(saveTo, &f, false)
+			if _, err := os.Stat(dest); err == nil {
+				dest = filepath.Join(saveTo, fmt.Sprintf("%d-%s", f.ID, filepath.Base(dest)))
+			}
+			if _, err := downloadFileTo(client, &f, false, dest); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: file %d was not deleted because it could not be saved: %v\n", f.ID, err)
+				os.Exit(1)
+			}
+			fmt.Printf("Saved file %d to %s\n", f.ID, dest)
+		}
+
+		if err := client.DeleteFile(f.ID); err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+		manifest.Forget(f.ID)
+		fmt.Printf("Detached file %d (%s) from ledger entry %d\n", f.ID, f.Name, ledger.ID)
+	}
+}
+
+// ledgerPageSize is the page size used when walking every ledger entry in an account.
+const ledgerPageSize = 100
+
+// listAllLedgers fetches every ledger entry in the account page by page.
+func listAllLedgers(client *api.Client) ([]api.Ledger, error) {
+	var all []api.Ledger
+	for page := 1; ; page++ {
+		ledgers, err := client.GetLedgers(map[string]string{
+			"limit": strconv.Itoa(ledgerPageSize),
+			"page":  strconv.Itoa(page),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		all = append(all, ledgers...)
+		if len(ledgers) < ledgerPageSize {
+			return all, nil
+		}
+	}
+}
@@ -0,0 +1,177 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/bulk"
+	"github.com/cloudmanic/skyclerk-cli/internal/receipts"
+	"github.com/spf13/cobra"
+)
+
+// ledgerAuditCmd reports ledger entries missing required receipts.
+var ledgerAuditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Find ledger entries missing required receipts",
+	Long: `Check every ledger entry against the receipt policy and report the
+entries without an attached file, grouped by month and contact.
+
+By default expenses over 75 need a receipt. The policy can be set in the
+"audit" section of the config file:
+
+  "audit": {
+    "receipt_threshold": 75,
+    "require_categories": ["Travel", "Equipment"],
+    "exempt_labels": ["Per diem"]
+  }
+
+Categories and labels may be given by name or ID. Flags override the config.
+Use --output csv for a spreadsheet. The command exits with status 1 when any
+entry violates the policy, so it can gate scripts and CI jobs.`,
+	Run: runLedgerAudit,
+}
+
+// init registers the audit command and its flags.
+func init() {
+	ledgerAuditCmd.Flags().Float64("threshold", receipts.DefaultReceiptThreshold, "Expenses above this amount need a receipt (0 disables)")
+	ledgerAuditCmd.Flags().StringSlice("require-category", nil, "Category name or ID that always needs a receipt (can be specified multiple times)")
+	ledgerAuditCmd.Flags().StringSlice("exempt-label", nil, "Label name or ID that exempts an entry (can be specified multiple times)")
+	ledgerAuditCmd.Flags().String("start", "", "Only check entries on or after this date (see 'skyclerk help dates')")
+	ledgerAuditCmd.Flags().String("end", "", "Only check entries on or before this date (see 'skyclerk help dates')")
+
+	ledgerCmd.AddCommand(ledgerAuditCmd)
+}
+
+// runLedgerAudit checks all ledger entries in range against the receipt policy.
+func runLedgerAudit(cmd *cobra.Command, args []string) {
+	policy := auditPolicy(cmd)
+	client := newClient()
+	start, end := dateRangeFlags(cmd, client)
+
+	ledgers, err := listAllLedgers(client)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
+	inRange := localizeLedgers(bulk.Filter{Start: start, End: end, Location: timeZone()}.Select(ledgers))
+
+	violations := receipts.Audit(inRange, policy)
+	groups := receipts.GroupViolations(violations)
+
+	switch outputFormat {
+	case "json":
+		printJSON(groups)
+	case "csv":
+		printAuditCSV(groups)
+	default:
+		printAuditTable(groups, policy, len(inRange), len(violations))
+	}
+
+	if len(violations) > 0 {
+		os.Exit(1)
+	}
+}
+
+// auditPolicy builds the receipt policy from the config file and flags.
+func auditPolicy(cmd *cobra.Command) receipts.Policy {
+	policy := receipts.Policy{Threshold: receipts.DefaultReceiptThreshold}
+
+	if cfg := loadConfig(); cfg.Audit != nil {
+		if cfg.Audit.ReceiptThreshold != nil {
+			policy.Threshold = *cfg.Audit.ReceiptThreshold
+		}
+		policy.RequireCategories = cfg.Audit.RequireCategories
+		policy.ExemptLabels = cfg.Audit.ExemptLabels
+	}
+
+	if cmd.Flags().Changed("threshold") {
+		policy.Threshold, _ = cmd.Flags().GetFloat64("threshold")
+	}
+	if cmd.Flags().Changed("require-category") {
+		policy.RequireCategories, _ = cmd.Flags().GetStringSlice("require-category")
+	}
+	if cmd.Flags().Changed("exempt-label") {
+		policy.ExemptLabels, _ = cmd.Flags().GetStringSlice("exempt-label")
+	}
+
+	return policy
+}
+
+// describePolicy summarizes the policy in one line.
+func describePolicy(p receipts.Policy) string {
+	var parts []string
+	if p.Threshold > 0 {
+		parts = append(parts, "expenses over "+strconv.FormatFloat(p.Threshold, 'f', -1, 64))
+	}
+	if len(p.RequireCategories) > 0 {
+		parts = append(parts, "categories "+strings.Join(p.RequireCategories, ", "))
+	}
+	if len(parts) == 0 {
+		parts = append(parts, "nothing")
+	}
+
+	desc := "Receipts required for " + strings.Join(parts, "; ")
+	if len(p.ExemptLabels) > 0 {
+		desc += " (exempt labels: " + strings.Join(p.ExemptLabels, ", ") + ")"
+	}
+
+	return desc
+}
+
+// printAuditTable renders the violations grouped by month and contact.
+func printAuditTable(groups []receipts.ViolationGroup, policy receipts.Policy, checked int, violations int) {
+	fmt.Println(describePolicy(policy))
+	fmt.Println()
+
+	if violations == 0 {
+		fmt.Printf("All %d entries pass.\n", checked)
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "MONTH\tCONTACT\tID\tDATE\tAMOUNT\tCATEGORY\tREASON")
+	for _, g := range groups {
+		month, contact := g.Month, g.Contact
+		for _, v := range g.Violations {
+			l := v.Ledger
+			fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%.2f\t%s\t%s\n", month, contact, l.ID, bulk.Day(l, timeZone()), l.Amount, l.Category.Name, v.Reason)
+			month, contact = "", ""
+		}
+		if len(g.Violations) > 1 {
+			fmt.Fprintf(w, "\t\t\t\t%.2f\t\t%d entries\n", g.Total, len(g.Violations))
+		}
+	}
+	w.Flush()
+
+	fmt.Printf("\n%d of %d entries are missing receipts.\n", violations, checked)
+}
+
+// printAuditCSV writes one CSV row per violation for spreadsheets.
+func printAuditCSV(groups []receipts.ViolationGroup) {
+	w := csv.NewWriter(os.Stdout)
+	w.Write([]string{"month", "contact", "ledger_id", "date", "amount", "category", "note", "reason"})
+	for _, g := range groups {
+		for _, v := range g.Violations {
+			l := v.Ledger
+			w.Write([]string{
+				g.Month,
+				g.Contact,
+				strconv.FormatUint(uint64(l.ID), 10),
+				bulk.Day(l, timeZone()),
+				strconv.FormatFloat(l.Amount, 'f', 2, 64),
+				l.Category.Name,
+				l.Note,
+				v.Reason,
+			})
+		}
+	}
+	w.Flush()
+}
@@ -0,0 +1,404 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+	"github.com/cloudmanic/skyclerk-cli/internal/bulk"
+	"github.com/cloudmanic/skyclerk-cli/internal/journal"
+	"github.com/spf13/cobra"
+)
+
+// ledgerBulkUpdateCmd applies the same change to many ledger entries.
+var ledgerBulkUpdateCmd = &cobra.Command{
+	Use:   "bulk-update [id...|-]",
+	Short: "Change the category, contact, labels or note of many ledger entries",
+	Long: `Change the category, contact, labels or note of many ledger entries at once.
+
+Entries are selected with the same filters as 'skyclerk ledger list', by ID,
+or both. Pass - to read IDs from stdin, one per line; the first number on each
+line is used, so the output of 'ledger list' can be filtered and piped in.
+Use --all to select every entry in the account.
+
+The planned changes are shown before anything is sent. Use --dry-run to stop
+there, or --yes to skip the confirmation.`,
+	Run: runLedgerBulkUpdate,
+}
+
+// bulkReport is the JSON shape of a bulk update run.
+type bulkReport struct {
+	Updated   []uint        `json:"updated"`
+	Failed    []bulkFailure `json:"failed"`
+	Unchanged []uint        `json:"unchanged"`
+}
+
+// bulkFailure is an entry that could not be updated.
+type bulkFailure struct {
+	ID    uint   `json:"id"`
+	Error string `json:"error"`
+}
+
+// init registers the bulk-update command and its flags.
+func init() {
+	addLedgerFilterFlags(ledgerBulkUpdateCmd)
+
+	ledgerBulkUpdateCmd.Flags().Uint("set-category", 0, "Category ID to move entries to")
+	ledgerBulkUpdateCmd.Flags().Uint("set-contact", 0, "Contact ID to assign")
+	ledgerBulkUpdateCmd.Flags().UintSlice("add-label", nil, "Label ID to add (can be specified multiple times)")
+	ledgerBulkUpdateCmd.Flags().UintSlice("remove-label", nil, "Label ID to remove (can be specified multiple times)")
+	ledgerBulkUpdateCmd.Flags().String("note-append", "", "Text to append to each entry's note")
+	ledgerBulkUpdateCmd.Flags().Bool("all", false, "Select every entry in the account")
+	ledgerBulkUpdateCmd.Flags().Bool("dry-run", false, "Show the planned changes without applying them")
+	ledgerBulkUpdateCmd.Flags().BoolP("yes", "y", false, "Don't ask for confirmation")
+	ledgerBulkUpdateCmd.Flags().Int("parallel", 4, "Number of concurrent updates")
+
+	ledgerCmd.AddCommand(ledgerBulkUpdateCmd)
+}
+
+// addLedgerFilterFlags adds the flags that select ledger entries by their fields.
+func addLedgerFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().Uint("category-id", 0, "Only entries in this category")
+	cmd.Flags().Uint("contact-id", 0, "Only entries for this contact")
+	cmd.Flags().Uint("label-id", 0, "Only entries with this label")
+	cmd.Flags().String("start", "", "Only entries on or after this date (see 'skyclerk help dates')")
+	cmd.Flags().String("end", "", "Only entries on or before this date (see 'skyclerk help dates')")
+	cmd.Flags().String("type", "", "Only income or expense entries")
+	cmd.Flags().String("search", "", "Only entries whose note or contact contains this text")
+}
+
+// ledgerFilterFromFlags builds the entry filter from the flags added by addLedgerFilterFlags.
+func ledgerFilterFromFlags(cmd *cobra.Command, client *api.Client) bulk.Filter {
+	var f bulk.Filter
+	f.CategoryID, _ = cmd.Flags().GetUint("category-id")
+	f.ContactID, _ = cmd.Flags().GetUint("contact-id")
+	f.LabelID, _ = cmd.Flags().GetUint("label-id")
+	f.Start, f.End = dateRangeFlags(cmd, client)
+	f.Location = timeZone()
+	f.Type, _ = cmd.Flags().GetString("type")
+	f.Search, _ = cmd.Flags().GetString("search")
+
+	if f.Type != "" && f.Type != "income" && f.Type != "expense" {
+		fmt.Fprintln(os.Stderr, "Error: --type must be income or expense")
+		os.Exit(1)
+	}
+
+	return f
+}
+
+// listFilteredLedgers fetches every entry, applies the filter and returns one
+// page sorted by date, the way the API would for an unfiltered list.
+func listFilteredLedgers(client *api.Client, filter bulk.Filter, limit string, page string, order string) ([]api.Ledger, error) {
+	all, err := listAllLedgers(client)
+	if err != nil {
+		return nil, err
+	}
+
+	ledgers := filter.Select(all)
+	asc := strings.EqualFold(order, "ASC")
+	sort.SliceStable(ledgers, func(i, j int) bool {
+		if asc {
+			return bulk.Day(ledgers[i], timeZone()) < bulk.Day(ledgers[j], timeZone())
+		}
+		return bulk.Day(ledgers[i], timeZone()) > bulk.Day(ledgers[j], timeZone())
+	})
+
+	n, err := strconv.Atoi(limit)
+	if err != nil || n < 1 {
+		return nil, fmt.Errorf("invalid limit %q", limit)
+	}
+	p, err := strconv.Atoi(page)
+	if err != nil || p < 1 {
+		return nil, fmt.Errorf("invalid page %q", page)
+	}
+
+	from := (p - 1) * n
+	if from >= len(ledgers) {
+		return []api.Ledger{}, nil
+	}
+
+	return ledgers[from:min(from+n, len(ledgers))], nil
+}
+
+// ledgerIDArgs parses ledger IDs from the arguments, reading them from stdin
+// for "-". It reports whether stdin was read.
+func ledgerIDArgs(args []string) ([]uint, bool) {
+	var ids []uint
+	fromStdin := false
+	for _, arg := range args {
+		if arg == "-" {
+			read, err := readIDs(os.Stdin)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "Error reading IDs:", err)
+				os.Exit(1)
+			}
+			ids = append(ids, read...)
+			fromStdin = true
+			continue
+		}
+		id, err := strconv.ParseUint(arg, 10, 64)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid ledger ID %q\n", arg)
+			os.Exit(1)
+		}
+		ids = append(ids, uint(id))
+	}
+
+	return ids, fromStdin
+}
+
+// readIDs reads ledger IDs from r, taking the first number on each line and
+// skipping lines without one (such as a table header).
+func readIDs(r io.Reader) ([]uint, error) {
+	var ids []uint
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if id, err := strconv.ParseUint(fields[0], 10, 64); err == nil {
+			ids = append(ids, uint(id))
+		}
+	}
+
+	return ids, scanner.Err()
+}
+
+// bulkChangeFromFlags resolves the --set-*, label and note flags into a change.
+func bulkChangeFromFlags(cmd *cobra.Command, client *api.Client) bulk.Change {
+	var c bulk.Change
+
+	if cmd.Flags().Changed("set-category") {
+		id, _ := cmd.Flags().GetUint("set-category")
+		category, err := client.GetCategory(id)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error fetching category:", err)
+			os.Exit(1)
+		}
+		category.Type = categoryTypeToAPI(category.Type)
+		c.Category = category
+	}
+
+	if cmd.Flags().Changed("set-contact") {
+		id, _ := cmd.Flags().GetUint("set-contact")
+		contact, err := client.GetContact(id)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error fetching contact:", err)
+			os.Exit(1)
+		}
+		c.Contact = contact
+	}
+
+	for _, name := range []string{"add-label", "remove-label"} {
+		ids, _ := cmd.Flags().GetUintSlice(name)
+		for _, id := range ids {
+			label, err := client.GetLabel(id)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "Error fetching label:", err)
+				os.Exit(1)
+			}
+			if name == "add-label" {
+				c.AddLabels = append(c.AddLabels, *label)
+			} else {
+				c.RemoveLabels = append(c.RemoveLabels, *label)
+			}
+		}
+	}
+
+	c.NoteAppend, _ = cmd.Flags().GetString("note-append")
+
+	return c
+}
+
+// runLedgerBulkUpdate selects entries, previews the change and applies it.
+func runLedgerBulkUpdate(cmd *cobra.Command, args []string) {
+	client := newClient()
+	filter := ledgerFilterFromFlags(cmd, client)
+	all, _ := cmd.Flags().GetBool("all")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	yes, _ := cmd.Flags().GetBool("yes")
+	parallel, _ := cmd.Flags().GetInt("parallel")
+
+	ids, fromStdin := ledgerIDArgs(args)
+
+	if len(args) == 0 && filter.Empty() && !all {
+		fmt.Fprintln(os.Stderr, "Error: select entries with filters, IDs or --all")
+		os.Exit(1)
+	}
+	if fromStdin && !yes && !dryRun {
+		fmt.Fprintln(os.Stderr, "Error: --yes is required when reading IDs from stdin")
+		os.Exit(1)
+	}
+
+	change := bulkChangeFromFlags(cmd, client)
+	if change.Empty() {
+		fmt.Fprintln(os.Stderr, "Error: nothing to change (use --set-category, --set-contact, --add-label, --remove-label or --note-append)")
+		os.Exit(1)
+	}
+
+	ledgers, err := listAllLedgers(client)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
+	selected, report := selectBulkLedgers(ledgers, ids, len(args) > 0, filter)
+
+	var updates []bulk.Update
+	for _, l := range selected {
+		if u, ok := change.Plan(l); ok {
+			updates = append(updates, u)
+		} else {
+			report.Unchanged = append(report.Unchanged, l.ID)
+		}
+	}
+
+	if outputFormat == "json" && dryRun {
+		printJSON(updates)
+		return
+	}
+	if outputFormat != "json" {
+		printBulkPlan(updates, report)
+	}
+
+	if dryRun || len(updates) == 0 {
+		if len(report.Failed) > 0 {
+			os.Exit(1)
+		}
+		return
+	}
+
+	if !yes && !confirm(fmt.Sprintf("Update %d ledger entries?", len(updates))) {
+		fmt.Fprintln(os.Stderr, "Aborted.")
+		return
+	}
+
+	tick := progress(len(updates))
+	outcomes := bulk.Apply(client, updates, parallel, func(o bulk.Outcome) {
+		status := "ok"
+		if o.Err != nil {
+			status = "failed"
+		}
+		tick(status, o.ID)
+	})
+
+	var changes []journal.Change
+	for _, o := range outcomes {
+		if o.Err != nil {
+			report.Failed = append(report.Failed, bulkFailure{ID: o.ID, Error: strings.TrimSpace(o.Err.Error())})
+		} else {
+			report.Updated = append(report.Updated, o.ID)
+			changes = append(changes, journal.NewChange(journal.KindLedger, journal.ActionUpdate, o.ID, o.Ledger, o.Result))
+		}
+	}
+	recordOp(client, journal.Op{Changes: changes})
+
+	if outputFormat == "json" {
+		printJSON(report)
+	} else {
+		printBulkReport(report)
+	}
+
+	if len(report.Failed) > 0 {
+		os.Exit(1)
+	}
+}
+
+// selectBulkLedgers returns the entries chosen by ID (when byID) and the
+// filter, in ID order when selecting by ID. IDs that don't exist are reported
+// as failures.
+func selectBulkLedgers(ledgers []api.Ledger, ids []uint, byID bool, filter bulk.Filter) ([]api.Ledger, bulkReport) {
+	report := bulkReport{Updated: []uint{}, Failed: []bulkFailure{}, Unchanged: []uint{}}
+	if !byID {
+		return filter.Select(ledgers), report
+	}
+
+	byLedgerID := map[uint]api.Ledger{}
+	for _, l := range ledgers {
+		byLedgerID[l.ID] = l
+	}
+
+	var selected []api.Ledger
+	seen := map[uint]bool{}
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		l, ok := byLedgerID[id]
+		if !ok {
+			report.Failed = append(report.Failed, bulkFailure{ID: id, Error: "not found"})
+			continue
+		}
+		if filter.Match(l) {
+			selected = append(selected, l)
+		}
+	}
+
+	return selected, report
+}
+
+// printBulkPlan shows each entry that will change and what changes.
+func printBulkPlan(updates []bulk.Update, report bulkReport) {
+	if len(updates) > 0 {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tDATE\tAMOUNT\tCONTACT\tCATEGORY\tCHANGES")
+		for _, u := range updates {
+			fmt.Fprintf(w, "%d\t%s\t%.2f\t%s\t%s\t%s\n",
+				u.ID, bulk.Day(u.Ledger, timeZone()), u.Ledger.Amount, u.Ledger.Contact.Name, u.Ledger.Category.Name, strings.Join(u.Changes, "; "))
+		}
+		w.Flush()
+		fmt.Println()
+	}
+
+	fmt.Printf("%d to update, %d already up to date", len(updates), len(report.Unchanged))
+	if len(report.Failed) > 0 {
+		fmt.Printf(", %d not found", len(report.Failed))
+	}
+	fmt.Println()
+}
+
+// printBulkReport lists failed entries and summarizes the run.
+func printBulkReport(report bulkReport) {
+	if len(report.Failed) > 0 {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "\nID\tERROR")
+		for _, f := range report.Failed {
+			fmt.Fprintf(w, "%d\t%s\n", f.ID, f.Error)
+		}
+		w.Flush()
+	}
+
+	fmt.Printf("\n%d updated, %d failed, %d unchanged\n", len(report.Updated), len(report.Failed), len(report.Unchanged))
+	if len(report.Updated) > 0 {
+		fmt.Println("Updated:", joinIDs(report.Updated))
+	}
+	if len(report.Failed) > 0 {
+		var failed []uint
+		for _, f := range report.Failed {
+			failed = append(failed, f.ID)
+		}
+		fmt.Println("Failed: ", joinIDs(failed))
+	}
+}
+
+// joinIDs formats IDs as a space-separated list.
+func joinIDs(ids []uint) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.FormatUint(uint64(id), 10)
+	}
+
+	return strings.Join(parts, " ")
+}
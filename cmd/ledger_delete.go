@@ -0,0 +1,424 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+	"github.com/cloudmanic/skyclerk-cli/internal/bulk"
+	"github.com/cloudmanic/skyclerk-cli/internal/config"
+	"github.com/cloudmanic/skyclerk-cli/internal/journal"
+	"github.com/cloudmanic/skyclerk-cli/internal/receipts"
+	"github.com/spf13/cobra"
+)
+
+// ledgerDeleteCmd deletes ledger entries after backing them up.
+var ledgerDeleteCmd = &cobra.Command{
+	Use:   "delete [id...|-]",
+	Short: "Delete ledger entries",
+	Long: `Delete ledger entries selected by ID, by the same filters as
+'skyclerk ledger list', or both. Pass - to read IDs from stdin.
+
+The entries are listed with their total and you are asked to confirm. Before
+anything is deleted a JSON backup of every entry is written, along with copies
+of their attached files (which the API deletes with the entry). Restore them
+with 'skyclerk ledger restore <backup.json>'.`,
+	Run: runLedgerDelete,
+}
+
+// ledgerRestoreCmd recreates ledger entries from a delete backup.
+var ledgerRestoreCmd = &cobra.Command{
+	Use:   "restore [backup.json]",
+	Short: "Recreate ledger entries from a delete backup",
+	Long: `Recreate the ledger entries saved by 'skyclerk ledger delete' and re-upload
+their attached files. Restored entries get new IDs.
+
+Entries that still exist, or were already restored from the same backup, are
+skipped, so an interrupted restore can be run again. Files that failed to
+upload are retried on their restored entry.`,
+	Args: cobra.ExactArgs(1),
+	Run:  runLedgerRestore,
+}
+
+// deleteReport is the JSON shape of a delete run.
+type deleteReport struct {
+	Backup  string        `json:"backup"`
+	Deleted []uint        `json:"deleted"`
+	Failed  []bulkFailure `json:"failed"`
+}
+
+// restoreResult is the outcome of restoring one entry.
+type restoreResult struct {
+	OldID  uint   `json:"old_id"`
+	NewID  uint   `json:"new_id,omitempty"`
+	Files  int    `json:"files"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// init registers the delete and restore commands and their flags.
+func init() {
+	addLedgerFilterFlags(ledgerDeleteCmd)
+
+	ledgerDeleteCmd.Flags().BoolP("yes", "y", false, "Don't ask for confirmation")
+	ledgerDeleteCmd.Flags().Bool("dry-run", false, "Show the entries that would be deleted without deleting them")
+	ledgerDeleteCmd.Flags().String("backup", "", "Write the backup here (default: a timestamped file in the config backups folder)")
+
+	ledgerRestoreCmd.Flags().Bool("dry-run", false, "Show the entries that would be restored without creating them")
+
+	ledgerCmd.AddCommand(ledgerDeleteCmd)
+	ledgerCmd.AddCommand(ledgerRestoreCmd)
+}
+
+// runLedgerDelete selects entries, confirms, backs them up and deletes them.
+func runLedgerDelete(cmd *cobra.Command, args []string) {
+	client := newClient()
+	filter := ledgerFilterFromFlags(cmd, client)
+	yes, _ := cmd.Flags().GetBool("yes")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	backupPath, _ := cmd.Flags().GetString("backup")
+
+	ids, fromStdin := ledgerIDArgs(args)
+	if len(args) == 0 && filter.Empty() {
+		fmt.Fprintln(os.Stderr, "Error: select entries with IDs or filters")
+		os.Exit(1)
+	}
+	if fromStdin && !yes && !dryRun {
+		fmt.Fprintln(os.Stderr, "Error: --yes is required when reading IDs from stdin")
+		os.Exit(1)
+	}
+
+	selected, failed := selectDeleteLedgers(client, ids, len(args) > 0, filter)
+
+	if outputFormat == "json" && dryRun {
+		printJSON(selected)
+		return
+	}
+	if outputFormat != "json" {
+		for _, f := range failed {
+			fmt.Fprintf(os.Stderr, "Error: ledger entry %d: %s\n", f.ID, f.Error)
+		}
+		printDeletePlan(selected)
+	}
+
+	if dryRun || len(selected) == 0 {
+		if len(failed) > 0 {
+			os.Exit(1)
+		}
+		return
+	}
+
+	if !yes && !confirm(fmt.Sprintf("Delete %d ledger entries totalling %.2f?", len(selected), ledgerTotal(selected))) {
+		fmt.Fprintln(os.Stderr, "Aborted.")
+		return
+	}
+
+	if backupPath == "" {
+		dir, err := config.GetBackupsDir()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+		backupPath = filepath.Join(dir, "ledger-delete-"+time.Now().Format("20060102-150405")+".json")
+	}
+
+	backup := bulk.NewBackup(backupPath, client.BaseURL(), client.AccountID(), selected)
+	if err := writeDeleteBackup(client, backup); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		fmt.Fprintln(os.Stderr, "Nothing was deleted.")
+		os.Exit(1)
+	}
+	if outputFormat != "json" {
+		fmt.Printf("Backup written to %s\n", backup.Path())
+	}
+
+	report := deleteReport{Backup: backup.Path(), Deleted: []uint{}, Failed: failed}
+	manifest := openUploadManifest(client)
+	op := journal.Op{Backup: backup.Path()}
+	for _, l := range selected {
+		if err := client.DeleteLedger(l.ID); err != nil {
+			report.Failed = append(report.Failed, bulkFailure{ID: l.ID, Error: strings.TrimSpace(err.Error())})
+			continue
+		}
+		for _, f := range l.Files {
+			manifest.Forget(f.ID)
+		}
+		report.Deleted = append(report.Deleted, l.ID)
+		op.Changes = append(op.Changes, journal.NewChange(journal.KindLedger, journal.ActionDelete, l.ID, l, nil))
+	}
+	recordOp(client, op)
+
+	if outputFormat == "json" {
+		printJSON(report)
+	} else {
+		fmt.Printf("Deleted %d ledger entries", len(report.Deleted))
+		if len(report.Failed) > 0 {
+			fmt.Printf(", %d failed", len(report.Failed))
+		}
+		fmt.Printf("\nRestore with: skyclerk ledger restore %s\n", backup.Path())
+	}
+
+	if len(report.Failed) > 0 {
+		os.Exit(1)
+	}
+}
+
+// selectDeleteLedgers returns the entries to delete. Plain ID lists are fetched
+// one by one; filters need every entry. IDs that can't be fetched are failures.
+func selectDeleteLedgers(client *api.Client, ids []uint, byID bool, filter bulk.Filter) ([]api.Ledger, []bulkFailure) {
+	failed := []bulkFailure{}
+
+	if byID && filter.Empty() {
+		var selected []api.Ledger
+		seen := map[uint]bool{}
+		for _, id := range ids {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+
+			l, err := client.GetLedger(id)
+			if err != nil {
+				failed = append(failed, bulkFailure{ID: id, Error: strings.TrimSpace(err.Error())})
+				continue
+			}
+			selected = append(selected, *l)
+		}
+		return selected, failed
+	}
+
+	ledgers, err := listAllLedgers(client)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
+	selected, report := selectBulkLedgers(ledgers, ids, byID, filter)
+	return selected, append(failed, report.Failed...)
+}
+
+// writeDeleteBackup saves copies of the entries' attached files, then the
+// backup itself. Any failure means the entries must not be deleted.
+func writeDeleteBackup(client *api.Client, backup *bulk.Backup) error {
+	var items []receipts.ArchiveItem
+	for _, l := range backup.Ledgers {
+		for _, f := range l.Files {
+			items = append(items, receipts.ArchiveItem{Ledger: l, File: f, Path: backup.FileName(f)})
+		}
+	}
+
+	if len(items) > 0 {
+		if err := os.MkdirAll(backup.FilesDir(), 0700); err != nil {
+			return fmt.Errorf("unable to create backup folder: %w", err)
+		}
+		for _, r := range receipts.FetchArchive(client, backup.FilesDir(), items, 4, nil) {
+			if r.Err != nil {
+				return fmt.Errorf("unable to back up file %d (%s): %w", r.File.ID, r.File.Name, r.Err)
+			}
+		}
+	}
+
+	return backup.Save()
+}
+
+// ledgerTotal returns the sum of the entries' amounts.
+func ledgerTotal(ledgers []api.Ledger) float64 {
+	var total float64
+	for _, l := range ledgers {
+		total += l.Amount
+	}
+
+	return total
+}
+
+// printDeletePlan lists the entries that will be deleted with their total.
+func printDeletePlan(ledgers []api.Ledger) {
+	if len(ledgers) == 0 {
+		fmt.Println("No ledger entries to delete.")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tDATE\tAMOUNT\tCONTACT\tCATEGORY\tFILES\tNOTE")
+	for _, l := range ledgers {
+		fmt.Fprintf(w, "%d\t%s\t%.2f\t%s\t%s\t%d\t%s\n",
+			l.ID, bulk.Day(l, timeZone()), l.Amount, l.Contact.Name, l.Category.Name, len(l.Files), l.Note)
+	}
+	w.Flush()
+
+	fmt.Printf("\n%d entries, total %.2f\n", len(ledgers), ledgerTotal(ledgers))
+}
+
+// runLedgerRestore recreates the entries in a backup that don't exist any more.
+func runLedgerRestore(cmd *cobra.Command, args []string) {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	backup, err := bulk.ReadBackup(args[0])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
+	client := newClient()
+	if backup.AccountID != client.AccountID() {
+		fmt.Fprintf(os.Stderr, "Error: the backup is from account %d (use --account %d)\n", backup.AccountID, backup.AccountID)
+		os.Exit(1)
+	}
+
+	existing, err := listAllLedgers(client)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+	exists := map[uint]bool{}
+	for _, l := range existing {
+		exists[l.ID] = true
+	}
+
+	var results []restoreResult
+	var changes []journal.Change
+	failed := false
+	for _, l := range backup.Ledgers {
+		res := restoreResult{OldID: l.ID, Files: len(l.Files)}
+
+		switch newID := backup.Restored[l.ID]; {
+		case newID != 0 && exists[newID] && len(backup.PendingFiles(l)) == 0:
+			res.NewID, res.Status = newID, "already restored"
+		case newID != 0 && exists[newID] && dryRun:
+			res.NewID, res.Status = newID, "would restore files"
+		case newID != 0 && exists[newID]:
+			// The entry came back in an earlier run but some files didn't.
+			res.NewID, res.Status = newID, "restored"
+			if err := restoreFiles(client, backup, l, newID); err != nil {
+				res.Error = err.Error()
+				failed = true
+			}
+		case exists[l.ID]:
+			res.Status = "still exists"
+		case dryRun:
+			res.Status = "would restore"
+		default:
+			var created *api.Ledger
+			res, created = restoreLedger(client, backup, l)
+			if created != nil {
+				changes = append(changes, journal.NewChange(journal.KindLedger, journal.ActionCreate, created.ID, nil, created))
+			}
+			if res.Error != "" {
+				failed = true
+			}
+		}
+
+		results = append(results, res)
+		if outputFormat != "json" && !dryRun && res.Status != "already restored" && res.Status != "still exists" {
+			fmt.Fprintf(os.Stderr, "[%d/%d] %s %d\n", len(results), len(backup.Ledgers), res.Status, l.ID)
+		}
+	}
+
+	recordOp(client, journal.Op{Changes: changes})
+
+	if outputFormat == "json" {
+		printJSON(results)
+	} else {
+		printRestoreResults(backup, results)
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// ledgerCreateRequest returns the request that recreates a saved entry.
+func ledgerCreateRequest(l api.Ledger) *api.LedgerCreateRequest {
+	category := l.Category
+	category.Type = categoryTypeToAPI(category.Type)
+
+	return &api.LedgerCreateRequest{
+		Amount:   l.Amount,
+		Date:     formatDateForAPI(l.Date),
+		Contact:  l.Contact,
+		Category: category,
+		Labels:   l.Labels,
+		Note:     l.Note,
+	}
+}
+
+// restoreLedger recreates one entry and re-uploads its files from the backup.
+// The created entry is returned whenever one was made, even if a file failed.
+func restoreLedger(client *api.Client, backup *bulk.Backup, l api.Ledger) (restoreResult, *api.Ledger) {
+	res := restoreResult{OldID: l.ID, Files: len(l.Files)}
+
+	created, err := client.CreateLedger(ledgerCreateRequest(l))
+	if err != nil {
+		res.Status, res.Error = "failed", strings.TrimSpace(err.Error())
+		return res, nil
+	}
+
+	res.NewID, res.Status = created.ID, "restored"
+	if err := backup.MarkRestored(l.ID, created.ID); err != nil {
+		res.Status, res.Error = "restored", err.Error()
+		return res, created
+	}
+
+	if err := restoreFiles(client, backup, l, created.ID); err != nil {
+		res.Error = err.Error()
+	}
+
+	return res, created
+}
+
+// restoreFiles re-uploads the entry's files that haven't been restored yet
+// to its recreated entry, recording each in the backup so a later run
+// retries only the ones that failed.
+func restoreFiles(client *api.Client, backup *bulk.Backup, l api.Ledger, newID uint) error {
+	ledgerID := strconv.FormatUint(uint64(newID), 10)
+
+	var errs []string
+	for _, f := range backup.PendingFiles(l) {
+		file, err := client.UploadFile(backup.FilePath(f), ledgerID)
+		if err == nil {
+			err = backup.MarkFileRestored(f.ID, file.ID)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("file %s: %s", f.Name, strings.TrimSpace(err.Error())))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+// printRestoreResults lists each entry's outcome and a summary.
+func printRestoreResults(backup *bulk.Backup, results []restoreResult) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "OLD ID\tNEW ID\tFILES\tSTATUS\tERROR")
+	counts := map[string]int{}
+	for _, r := range results {
+		newID := "-"
+		if r.NewID != 0 {
+			newID = strconv.FormatUint(uint64(r.NewID), 10)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%d\t%s\t%s\n", r.OldID, newID, r.Files, r.Status, r.Error)
+		counts[r.Status]++
+	}
+	w.Flush()
+
+	fmt.Printf("\n%d entries in %s (total %.2f): ", len(results), backup.Path(), ledgerTotal(backup.Ledgers))
+	var parts []string
+	for _, status := range []string{"restored", "would restore", "would restore files", "already restored", "still exists", "failed"} {
+		if counts[status] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[status], status))
+		}
+	}
+	fmt.Println(strings.Join(parts, ", "))
+}
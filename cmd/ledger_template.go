@@ -0,0 +1,261 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+	"github.com/cloudmanic/skyclerk-cli/internal/config"
+	"github.com/cloudmanic/skyclerk-cli/internal/templates"
+	"github.com/spf13/cobra"
+)
+
+// ledgerTemplateCmd is the parent command for saved ledger entry templates.
+var ledgerTemplateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Manage saved ledger entry templates",
+	Long: `Save the contact, category, labels, note and amount of entries you make again
+and again, such as rent or payroll, and create them with
+'skyclerk ledger create --template <name> --date <date>'. Templates are kept
+per account in the config directory.`,
+}
+
+// ledgerTemplateSaveCmd saves a template from an entry or from flags.
+var ledgerTemplateSaveCmd = &cobra.Command{
+	Use:   "save [name]",
+	Short: "Save a template from a ledger entry or from flags",
+	Long: `Save a template from an existing entry with --from, or from flags. With --from
+any flags given replace the entry's values. Without an amount, each entry made
+from the template must give its own with --amount.`,
+	Args: cobra.ExactArgs(1),
+	Run:  runLedgerTemplateSave,
+}
+
+// ledgerTemplateListCmd lists the saved templates.
+var ledgerTemplateListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List saved templates",
+	Run:   runLedgerTemplateList,
+}
+
+// ledgerTemplateShowCmd shows one template.
+var ledgerTemplateShowCmd = &cobra.Command{
+	Use:   "show [name]",
+	Short: "Show a saved template",
+	Args:  cobra.ExactArgs(1),
+	Run:   runLedgerTemplateShow,
+}
+
+// ledgerTemplateDeleteCmd deletes a template.
+var ledgerTemplateDeleteCmd = &cobra.Command{
+	Use:   "delete [name]",
+	Short: "Delete a saved template",
+	Args:  cobra.ExactArgs(1),
+	Run:   runLedgerTemplateDelete,
+}
+
+func init() {
+	ledgerTemplateSaveCmd.Flags().Uint("from", 0, "Copy the amount, contact, category, labels and note of this ledger entry")
+	ledgerTemplateSaveCmd.Flags().Float64("amount", 0, "Transaction amount (negative for expense)")
+	ledgerTemplateSaveCmd.Flags().Bool("no-amount", false, "Don't save an amount; each entry gives its own")
+	ledgerTemplateSaveCmd.Flags().Uint("contact-id", 0, "Contact ID")
+	ledgerTemplateSaveCmd.Flags().Uint("category-id", 0, "Category ID")
+	ledgerTemplateSaveCmd.Flags().UintSlice("label-id", nil, "Label ID (can be specified multiple times)")
+	ledgerTemplateSaveCmd.Flags().String("note", "", "Transaction note")
+	ledgerTemplateSaveCmd.Flags().Bool("force", false, "Replace an existing template with the same name")
+	ledgerTemplateSaveCmd.MarkFlagsMutuallyExclusive("amount", "no-amount")
+
+	ledgerTemplateCmd.AddCommand(ledgerTemplateSaveCmd)
+	ledgerTemplateCmd.AddCommand(ledgerTemplateListCmd)
+	ledgerTemplateCmd.AddCommand(ledgerTemplateShowCmd)
+	ledgerTemplateCmd.AddCommand(ledgerTemplateDeleteCmd)
+	ledgerCmd.AddCommand(ledgerTemplateCmd)
+}
+
+// openTemplates loads the template store for the client's API URL and account.
+func openTemplates(client *api.Client) *templates.Store {
+	dir, err := config.GetTemplatesDir()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
+	store, err := templates.Load(config.AccountPath(dir, client.BaseURL(), client.AccountID()))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
+	return store
+}
+
+// findTemplate returns a saved template by name, exiting if there is none.
+func findTemplate(store *templates.Store, name string) templates.Template {
+	t, ok := store.Get(name)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Error: no template named %q (see 'skyclerk ledger template list')\n", name)
+		os.Exit(1)
+	}
+
+	return t
+}
+
+// runLedgerTemplateSave builds a template from --from and the flags and saves it.
+func runLedgerTemplateSave(cmd *cobra.Command, args []string) {
+	name := args[0]
+	if err := templates.ValidName(name); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
+	client := newClient()
+	store := openTemplates(client)
+	if _, ok := store.Get(name); ok {
+		if force, _ := cmd.Flags().GetBool("force"); !force {
+			fmt.Fprintf(os.Stderr, "Error: template %q already exists (use --force to replace it)\n", name)
+			os.Exit(1)
+		}
+	}
+
+	t := templates.Template{Name: name}
+	if from, _ := cmd.Flags().GetUint("from"); from != 0 {
+		ledger, err := client.GetLedger(from)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+		t = templates.FromLedger(name, *ledger)
+	}
+
+	if cmd.Flags().Changed("amount") {
+		amount, _ := cmd.Flags().GetFloat64("amount")
+		t.Amount = &amount
+	}
+	if noAmount, _ := cmd.Flags().GetBool("no-amount"); noAmount {
+		t.Amount = nil
+	}
+	if cmd.Flags().Changed("contact-id") {
+		id, _ := cmd.Flags().GetUint("contact-id")
+		contact, err := client.GetContact(id)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error fetching contact:", err)
+			os.Exit(1)
+		}
+		t.Contact = templates.Ref{ID: contact.ID, Name: contact.Name}
+	}
+	if cmd.Flags().Changed("category-id") {
+		id, _ := cmd.Flags().GetUint("category-id")
+		category, err := client.GetCategory(id)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error fetching category:", err)
+			os.Exit(1)
+		}
+		t.Category = templates.Ref{ID: category.ID, Name: category.Name}
+	}
+	if cmd.Flags().Changed("label-id") {
+		ids, _ := cmd.Flags().GetUintSlice("label-id")
+		t.Labels = nil
+		for _, id := range ids {
+			label, err := client.GetLabel(id)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "Error fetching label:", err)
+				os.Exit(1)
+			}
+			t.Labels = append(t.Labels, templates.Ref{ID: label.ID, Name: label.Name})
+		}
+	}
+	if cmd.Flags().Changed("note") {
+		t.Note, _ = cmd.Flags().GetString("note")
+	}
+
+	if t.Contact.ID == 0 || t.Category.ID == 0 {
+		fmt.Fprintln(os.Stderr, "Error: a template needs a contact and a category: use --from or --contact-id and --category-id")
+		os.Exit(1)
+	}
+
+	if err := store.Put(t); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
+	if outputFormat == "json" {
+		saved, _ := store.Get(name)
+		printJSON(saved)
+		return
+	}
+
+	fmt.Printf("Saved template %q (use: skyclerk ledger create --template %s --date <date>)\n", t.Name, t.Name)
+}
+
+// runLedgerTemplateList lists the saved templates.
+func runLedgerTemplateList(cmd *cobra.Command, args []string) {
+	list := openTemplates(newClient()).List()
+
+	if outputFormat == "json" {
+		printJSON(list)
+		return
+	}
+
+	if len(list) == 0 {
+		fmt.Println("No templates. Save one with 'skyclerk ledger template save <name> --from <ledger-id>'.")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tAMOUNT\tCONTACT\tCATEGORY\tLABELS\tNOTE")
+	for _, t := range list {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			t.Name, templateAmount(t), t.Contact.Name, t.Category.Name, strings.Join(t.LabelNames(), ", "), t.Note)
+	}
+	w.Flush()
+}
+
+// runLedgerTemplateShow displays one template.
+func runLedgerTemplateShow(cmd *cobra.Command, args []string) {
+	t := findTemplate(openTemplates(newClient()), args[0])
+
+	if outputFormat == "json" {
+		printJSON(t)
+		return
+	}
+
+	fmt.Printf("Name:      %s\n", t.Name)
+	fmt.Printf("Amount:    %s\n", templateAmount(t))
+	fmt.Printf("Contact:   %s (%d)\n", t.Contact.Name, t.Contact.ID)
+	fmt.Printf("Category:  %s (%d)\n", t.Category.Name, t.Category.ID)
+	if len(t.Labels) > 0 {
+		fmt.Printf("Labels:    %s\n", strings.Join(t.LabelNames(), ", "))
+	}
+	if t.Note != "" {
+		fmt.Printf("Note:      %s\n", t.Note)
+	}
+	fmt.Printf("Saved:     %s\n", t.Saved.In(timeZone()).Format("2006-01-02 15:04"))
+}
+
+// runLedgerTemplateDelete deletes a template.
+func runLedgerTemplateDelete(cmd *cobra.Command, args []string) {
+	store := openTemplates(newClient())
+	t := findTemplate(store, args[0])
+
+	if _, err := store.Delete(t.Name); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Deleted template %q\n", t.Name)
+}
+
+// templateAmount formats a template's amount, or says it is given per entry.
+func templateAmount(t templates.Template) string {
+	if t.Amount == nil {
+		return "(per entry)"
+	}
+
+	return strconv.FormatFloat(*t.Amount, 'f', 2, 64)
+}
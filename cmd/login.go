@@ -8,7 +8,6 @@ import (
 	"os"
 	"syscall"
 
-	"github.com/cloudmanic/skyclerk-cli/internal/api"
 	"github.com/cloudmanic/skyclerk-cli/internal/config"
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
@@ -75,7 +74,7 @@ func runLogin(cmd *cobra.Command, args []string) {
 	}
 
 	// Authenticate with the API.
-	client := api.NewClient(apiURL, "", 0)
+	client := newAPIClient(apiURL, "", 0)
 	resp, err := client.Login(email, password, clientID)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "Error:", err)
@@ -83,7 +82,7 @@ func runLogin(cmd *cobra.Command, args []string) {
 	}
 
 	// Store the token temporarily to fetch user accounts.
-	client = api.NewClient(apiURL, resp.AccessToken, 0)
+	client = newAPIClient(apiURL, resp.AccessToken, 0)
 	user, err := client.GetAuthUser()
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "Error fetching user profile:", err)
@@ -126,7 +125,11 @@ func runLogin(cmd *cobra.Command, args []string) {
 		ClientID:         clientID,
 	}
 
-	if err := config.Save(cfg); err != nil {
+	// A replayed login carries the redacted token, which must not replace the
+	// user's real credentials.
+	if replayDir != "" {
+		fmt.Fprintln(os.Stderr, "Replay mode: the config was not changed.")
+	} else if err := config.Save(cfg); err != nil {
 		fmt.Fprintln(os.Stderr, "Error saving config:", err)
 		os.Exit(1)
 	}
@@ -148,13 +151,15 @@ func runLogout(cmd *cobra.Command, args []string) {
 	}
 
 	// Revoke the token on the server.
-	client := api.NewClient(baseURL, cfg.AccessToken, 0)
+	client := newAPIClient(baseURL, cfg.AccessToken, 0)
 	if err := client.Logout(); err != nil {
 		fmt.Fprintln(os.Stderr, "Warning: could not revoke token:", err)
 	}
 
-	// Delete the local config file.
-	if err := config.Delete(); err != nil {
+	// Delete the local config file, unless the logout was only replayed.
+	if replayDir != "" {
+		fmt.Fprintln(os.Stderr, "Replay mode: the config was not changed.")
+	} else if err := config.Delete(); err != nil {
 		fmt.Fprintln(os.Stderr, "Error:", err)
 		os.Exit(1)
 	}
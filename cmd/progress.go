@@ -0,0 +1,74 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// progressBarWidth is the number of characters in the drawn bar.
+const progressBarWidth = 30
+
+// progressBar draws a single-line upload progress bar on stdout.
+type progressBar struct {
+	label   string
+	lastPct int
+}
+
+// newProgressBar returns a progress bar, or nil when stdout is not a terminal
+// or JSON output is requested so scripts never see control characters.
+func newProgressBar(label string) *progressBar {
+	if outputFormat == "json" || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return nil
+	}
+
+	return &progressBar{label: label, lastPct: -1}
+}
+
+// Update redraws the bar; it matches api.ProgressFunc.
+func (p *progressBar) Update(sent int64, total int64) {
+	if total <= 0 {
+		fmt.Printf("\r%s  %s", p.label, formatBytes(sent))
+		return
+	}
+
+	pct := int(sent * 100 / total)
+	if pct == p.lastPct {
+		return
+	}
+	p.lastPct = pct
+
+	filled := pct * progressBarWidth / 100
+	bar := strings.Repeat("=", filled) + strings.Repeat(" ", progressBarWidth-filled)
+	fmt.Printf("\r%s [%s] %3d%% %s/%s", p.label, bar, pct, formatBytes(sent), formatBytes(total))
+}
+
+// Done clears the bar line. It is safe to call on a nil bar.
+func (p *progressBar) Done() {
+	if p == nil {
+		return
+	}
+
+	fmt.Print("\r\033[K")
+}
+
+// formatBytes renders a byte count using binary units.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -8,6 +8,7 @@ import (
 	"os"
 	"text/tabwriter"
 
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
 	"github.com/spf13/cobra"
 )
 
@@ -15,6 +16,12 @@ import (
 var reportsCmd = &cobra.Command{
 	Use:   "reports",
 	Short: "Generate financial reports",
+	Long: `Generate financial reports from the Skyclerk report endpoints.
+
+The endpoints take --start and --end as calendar days and can't be given a
+time zone, so a report counts each entry on its day in UTC. An entry made late
+in the evening west of UTC can fall on the next day in a report, while ledger
+listings show it on the day it was made in the configured time zone.`,
 }
 
 // reportsPnlCmd generates a P&L report for a date range.
@@ -62,20 +69,20 @@ var reportsExpensesByContactCmd = &cobra.Command{
 // init registers the reports commands and their flags.
 func init() {
 	// P&L date range flags.
-	reportsPnlCmd.Flags().String("start", "", "Start date (YYYY-MM-DD)")
-	reportsPnlCmd.Flags().String("end", "", "End date (YYYY-MM-DD)")
+	reportsPnlCmd.Flags().String("start", "", "Start date (see 'skyclerk help dates')")
+	reportsPnlCmd.Flags().String("end", "", "End date (see 'skyclerk help dates')")
 
-	reportsPnlByCategoryCmd.Flags().String("start", "", "Start date (YYYY-MM-DD)")
-	reportsPnlByCategoryCmd.Flags().String("end", "", "End date (YYYY-MM-DD)")
+	reportsPnlByCategoryCmd.Flags().String("start", "", "Start date (see 'skyclerk help dates')")
+	reportsPnlByCategoryCmd.Flags().String("end", "", "End date (see 'skyclerk help dates')")
 
-	reportsPnlByLabelCmd.Flags().String("start", "", "Start date (YYYY-MM-DD)")
-	reportsPnlByLabelCmd.Flags().String("end", "", "End date (YYYY-MM-DD)")
+	reportsPnlByLabelCmd.Flags().String("start", "", "Start date (see 'skyclerk help dates')")
+	reportsPnlByLabelCmd.Flags().String("end", "", "End date (see 'skyclerk help dates')")
 
-	reportsIncomeByContactCmd.Flags().String("start", "", "Start date (YYYY-MM-DD)")
-	reportsIncomeByContactCmd.Flags().String("end", "", "End date (YYYY-MM-DD)")
+	reportsIncomeByContactCmd.Flags().String("start", "", "Start date (see 'skyclerk help dates')")
+	reportsIncomeByContactCmd.Flags().String("end", "", "End date (see 'skyclerk help dates')")
 
-	reportsExpensesByContactCmd.Flags().String("start", "", "Start date (YYYY-MM-DD)")
-	reportsExpensesByContactCmd.Flags().String("end", "", "End date (YYYY-MM-DD)")
+	reportsExpensesByContactCmd.Flags().String("start", "", "Start date (see 'skyclerk help dates')")
+	reportsExpensesByContactCmd.Flags().String("end", "", "End date (see 'skyclerk help dates')")
 
 	reportsCmd.AddCommand(reportsPnlCmd)
 	reportsCmd.AddCommand(reportsPnlCurrentCmd)
@@ -86,13 +93,12 @@ func init() {
 	rootCmd.AddCommand(reportsCmd)
 }
 
-// getDateParams extracts start and end date params from flags.
-func getDateParams(cmd *cobra.Command) map[string]string {
+// getDateParams extracts the start and end days from flags. The report
+// endpoints take whole calendar days as the API counts them (see reportsCmd).
+func getDateParams(cmd *cobra.Command, client *api.Client) map[string]string {
 	params := map[string]string{}
 
-	start, _ := cmd.Flags().GetString("start")
-	end, _ := cmd.Flags().GetString("end")
-
+	start, end := dateRangeFlags(cmd, client)
 	if start != "" {
 		params["start"] = start
 	}
@@ -107,7 +113,7 @@ func getDateParams(cmd *cobra.Command) map[string]string {
 func runReportsPnl(cmd *cobra.Command, args []string) {
 	client := newClient()
 
-	report, err := client.GetPnlReport(getDateParams(cmd))
+	report, err := client.GetPnlReport(getDateParams(cmd, client))
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "Error:", err)
 		os.Exit(1)
@@ -157,7 +163,7 @@ func runReportsPnlCurrent(cmd *cobra.Command, args []string) {
 func runReportsPnlByCategory(cmd *cobra.Command, args []string) {
 	client := newClient()
 
-	report, err := client.GetPnlByCategory(getDateParams(cmd))
+	report, err := client.GetPnlByCategory(getDateParams(cmd, client))
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "Error:", err)
 		os.Exit(1)
@@ -187,7 +193,7 @@ func runReportsPnlByCategory(cmd *cobra.Command, args []string) {
 func runReportsPnlByLabel(cmd *cobra.Command, args []string) {
 	client := newClient()
 
-	report, err := client.GetPnlByLabel(getDateParams(cmd))
+	report, err := client.GetPnlByLabel(getDateParams(cmd, client))
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "Error:", err)
 		os.Exit(1)
@@ -217,7 +223,7 @@ func runReportsPnlByLabel(cmd *cobra.Command, args []string) {
 func runReportsIncomeByContact(cmd *cobra.Command, args []string) {
 	client := newClient()
 
-	report, err := client.GetIncomeByContact(getDateParams(cmd))
+	report, err := client.GetIncomeByContact(getDateParams(cmd, client))
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "Error:", err)
 		os.Exit(1)
@@ -245,7 +251,7 @@ func runReportsIncomeByContact(cmd *cobra.Command, args []string) {
 func runReportsExpensesByContact(cmd *cobra.Command, args []string) {
 	client := newClient()
 
-	report, err := client.GetExpensesByContact(getDateParams(cmd))
+	report, err := client.GetExpensesByContact(getDateParams(cmd, client))
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "Error:", err)
 		os.Exit(1)
@@ -19,6 +19,21 @@ var outputFormat string
 // accountOverride allows overriding the default account ID for a single command.
 var accountOverride uint
 
+// recordDir is the directory to record sanitized HTTP interactions to.
+var recordDir string
+
+// replayDir is the directory to replay recorded HTTP interactions from.
+var replayDir string
+
+// noCache disables the on-disk reference data cache for a single command.
+var noCache bool
+
+// debugHTTP enables HTTP request/response tracing (also enabled by SKYCLERK_DEBUG).
+var debugHTTP bool
+
+// debugFile is the file to append HTTP traces to instead of stderr.
+var debugFile string
+
 // rootCmd is the base command for the Skyclerk CLI.
 var rootCmd = &cobra.Command{
 	Use:   "skyclerk",
@@ -36,6 +51,12 @@ func Execute() {
 
 // init registers global persistent flags available to all commands.
 func init() {
-	rootCmd.PersistentFlags().StringVar(&outputFormat, "output", "table", "Output format: table or json")
+	rootCmd.PersistentFlags().StringVar(&outputFormat, "output", "table", "Output format: table or json (ledger audit also supports csv)")
 	rootCmd.PersistentFlags().UintVar(&accountOverride, "account", 0, "Override the default account ID")
+	rootCmd.PersistentFlags().StringVar(&recordDir, "record", "", "Record sanitized HTTP request/response pairs to this directory")
+	rootCmd.PersistentFlags().StringVar(&replayDir, "replay", "", "Serve HTTP responses from a recorded directory instead of the network")
+	rootCmd.MarkFlagsMutuallyExclusive("record", "replay")
+	rootCmd.PersistentFlags().BoolVar(&noCache, "no-cache", false, "Bypass the on-disk cache of contacts, categories, labels and account info")
+	rootCmd.PersistentFlags().BoolVar(&debugHTTP, "debug", false, "Trace HTTP requests and responses to stderr (or SKYCLERK_DEBUG=1)")
+	rootCmd.PersistentFlags().StringVar(&debugFile, "debug-file", "", "Append HTTP traces to this file instead of stderr (implies --debug)")
 }
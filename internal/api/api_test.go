@@ -4,6 +4,7 @@
 package api
 
 import (
+	"crypto/md5"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -544,7 +545,7 @@ func TestUpdateLedger(t *testing.T) {
 	})
 	defer server.Close()
 
-	ledger, err := client.UpdateLedger(42, &LedgerUpdateRequest{Amount: -30.00})
+	ledger, err := client.UpdateLedger(42, &LedgerUpdateRequest{Amount: Ptr(-30.00)})
 	if err != nil {
 		t.Fatalf("UpdateLedger() error = %v", err)
 	}
@@ -554,6 +555,29 @@ func TestUpdateLedger(t *testing.T) {
 	}
 }
 
+// TestUpdateRequestPresence verifies set fields are sent even when empty or
+// zero, and unset fields are left out.
+func TestUpdateRequestPresence(t *testing.T) {
+	tests := []struct {
+		req  interface{}
+		want string
+	}{
+		{&LedgerUpdateRequest{}, `{}`},
+		{&LedgerUpdateRequest{Amount: Ptr(0.0), Note: Ptr(""), Labels: Ptr([]Label{})}, `{"amount":0,"labels":[],"note":""}`},
+		{&ContactUpdateRequest{Phone: Ptr("")}, `{"phone":""}`},
+	}
+
+	for _, tt := range tests {
+		data, err := json.Marshal(tt.req)
+		if err != nil {
+			t.Fatalf("Marshal() error = %v", err)
+		}
+		if string(data) != tt.want {
+			t.Errorf("Marshal(%T) = %s, want %s", tt.req, data, tt.want)
+		}
+	}
+}
+
 // TestDeleteLedger verifies deleting a ledger entry.
 func TestDeleteLedger(t *testing.T) {
 	server, client := newTestServer(func(w http.ResponseWriter, r *http.Request) {
@@ -979,7 +1003,7 @@ func TestUpdateContact(t *testing.T) {
 	})
 	defer server.Close()
 
-	contact, err := client.UpdateContact(10, &ContactUpdateRequest{Name: "Updated Vendor"})
+	contact, err := client.UpdateContact(10, &ContactUpdateRequest{Name: Ptr("Updated Vendor")})
 	if err != nil {
 		t.Fatalf("UpdateContact() error = %v", err)
 	}
@@ -1092,6 +1116,109 @@ func TestUploadFileNoLedger(t *testing.T) {
 	}
 }
 
+// TestListFiles verifies listing files passes paging parameters.
+func TestListFiles(t *testing.T) {
+	server, client := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/1/files" {
+			t.Errorf("path = %s, want /api/v3/1/files", r.URL.Path)
+		}
+		if r.URL.Query().Get("page") != "2" {
+			t.Errorf("page = %q, want 2", r.URL.Query().Get("page"))
+		}
+
+		json.NewEncoder(w).Encode([]File{{ID: 1, Name: "a.jpg"}, {ID: 2, Name: "b.pdf"}})
+	})
+	defer server.Close()
+
+	files, err := client.ListFiles(map[string]string{"page": "2"})
+	if err != nil {
+		t.Fatalf("ListFiles() error = %v", err)
+	}
+
+	if len(files) != 2 || files[1].Name != "b.pdf" {
+		t.Errorf("ListFiles() = %+v", files)
+	}
+}
+
+// TestDeleteFile verifies deleting a file by ID.
+func TestDeleteFile(t *testing.T) {
+	server, client := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" || r.URL.Path != "/api/v3/1/files/9" {
+			t.Errorf("request = %s %s, want DELETE /api/v3/1/files/9", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	defer server.Close()
+
+	if err := client.DeleteFile(9); err != nil {
+		t.Fatalf("DeleteFile() error = %v", err)
+	}
+}
+
+// TestDownloadFileVerifiesChecksum verifies downloads are checked against the
+// advertised MD5 ETag and file size.
+func TestDownloadFileVerifiesChecksum(t *testing.T) {
+	content := "receipt bytes"
+	etag := fmt.Sprintf(`"%x"`, md5.Sum([]byte(content)))
+
+	server, client := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Errorf("Authorization = %q, want bearer token for API host", r.Header.Get("Authorization"))
+		}
+		if r.URL.Path == "/corrupt" {
+			w.Header().Set("ETag", `"00000000000000000000000000000000"`)
+		} else {
+			w.Header().Set("ETag", etag)
+		}
+		io.WriteString(w, content)
+	})
+	defer server.Close()
+
+	var buf strings.Builder
+	file := &File{ID: 1, URL: server.URL + "/ok", Size: int64(len(content))}
+	dl, err := client.DownloadFile(file, false, &buf)
+	if err != nil {
+		t.Fatalf("DownloadFile() error = %v", err)
+	}
+	if buf.String() != content || dl.Bytes != int64(len(content)) || len(dl.SHA256) != 64 {
+		t.Errorf("DownloadFile() = %+v, body %q", dl, buf.String())
+	}
+
+	file.URL = server.URL + "/corrupt"
+	if _, err := client.DownloadFile(file, false, io.Discard); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Errorf("expected checksum mismatch, got %v", err)
+	}
+
+	file.URL = server.URL + "/ok"
+	file.Size = 999
+	if _, err := client.DownloadFile(file, false, io.Discard); err == nil {
+		t.Error("expected size mismatch error")
+	}
+}
+
+// TestDownloadFileForeignHost verifies credentials are not sent to storage hosts.
+func TestDownloadFileForeignHost(t *testing.T) {
+	storage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			t.Errorf("Authorization sent to storage host: %q", r.Header.Get("Authorization"))
+		}
+		io.WriteString(w, "thumb")
+	}))
+	defer storage.Close()
+
+	server, client := newTestServer(func(w http.ResponseWriter, r *http.Request) {})
+	defer server.Close()
+
+	file := &File{ID: 1, URL: storage.URL + "/full", Thumb600By600: storage.URL + "/thumb", Size: 100}
+	dl, err := client.DownloadFile(file, true, io.Discard)
+	if err != nil {
+		t.Fatalf("DownloadFile() error = %v", err)
+	}
+	if dl.Bytes != 5 {
+		t.Errorf("Bytes = %d, want 5", dl.Bytes)
+	}
+}
+
 // --- Activity Tests ---
 
 // TestGetActivities verifies fetching account activities.
@@ -0,0 +1,253 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultCacheTTL is how long cached reference data is served without revalidation.
+const DefaultCacheTTL = 15 * time.Minute
+
+// cacheGroups are the account resources whose GET responses are cached.
+// Any create, update or delete under a group invalidates the whole group.
+var cacheGroups = []string{"contacts", "categories", "labels", "account"}
+
+// CacheEntry is a single cached GET response stored on disk.
+type CacheEntry struct {
+	BaseURL   string    `json:"base_url"`
+	AccountID uint      `json:"account_id"`
+	Group     string    `json:"group"`
+	Key       string    `json:"key"`
+	ETag      string    `json:"etag,omitempty"`
+	StoredAt  time.Time `json:"stored_at"`
+	Body      string    `json:"body"`
+}
+
+// Cache stores reference data (contacts, categories, labels and account info)
+// on disk, keyed by API URL and account ID, with a TTL.
+type Cache struct {
+	dir string
+	ttl time.Duration
+	now func() time.Time
+}
+
+// NewCache creates a cache rooted at dir. A zero ttl uses DefaultCacheTTL.
+func NewCache(dir string, ttl time.Duration) *Cache {
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+
+	return &Cache{dir: dir, ttl: ttl, now: time.Now}
+}
+
+// Dir returns the directory the cache is stored in.
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+// TTL returns how long entries stay fresh.
+func (c *Cache) TTL() time.Duration {
+	return c.ttl
+}
+
+// Fresh reports whether an entry can be served without asking the server.
+func (c *Cache) Fresh(e *CacheEntry) bool {
+	return c.now().Sub(e.StoredAt) < c.ttl
+}
+
+// Get returns the cached entry for a request key, if one exists.
+func (c *Cache) Get(baseURL string, accountID uint, group string, key string) (*CacheEntry, bool) {
+	data, err := os.ReadFile(c.entryPath(baseURL, accountID, group, key))
+	if err != nil {
+		return nil, false
+	}
+
+	var e CacheEntry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, false
+	}
+
+	return &e, true
+}
+
+// Put writes an entry to disk, stamping it with the current time.
+func (c *Cache) Put(e *CacheEntry) error {
+	e.StoredAt = c.now()
+
+	path := c.entryPath(e.BaseURL, e.AccountID, e.Group, e.Key)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("unable to create cache directory: %w", err)
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("unable to marshal cache entry: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("unable to write cache entry: %w", err)
+	}
+
+	return nil
+}
+
+// Invalidate removes every cached entry in a group for the given API URL and account.
+func (c *Cache) Invalidate(baseURL string, accountID uint, group string) error {
+	if err := os.RemoveAll(filepath.Join(c.accountDir(baseURL, accountID), group)); err != nil {
+		return fmt.Errorf("unable to invalidate cache: %w", err)
+	}
+
+	return nil
+}
+
+// Clear removes the entire cache directory.
+func (c *Cache) Clear() error {
+	if err := os.RemoveAll(c.dir); err != nil {
+		return fmt.Errorf("unable to clear cache: %w", err)
+	}
+
+	return nil
+}
+
+// Entries returns every entry in the cache.
+func (c *Cache) Entries() ([]CacheEntry, error) {
+	var entries []CacheEntry
+
+	err := filepath.Walk(c.dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if info.IsDir() || filepath.Ext(path) != ".json" {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		var e CacheEntry
+		if err := json.Unmarshal(data, &e); err == nil {
+			entries = append(entries, e)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to read cache: %w", err)
+	}
+
+	return entries, nil
+}
+
+// accountDir returns the directory holding one API URL and account's entries.
+func (c *Cache) accountDir(baseURL string, accountID uint) string {
+	return filepath.Join(c.dir, hashKey(fmt.Sprintf("%s|%d", baseURL, accountID)))
+}
+
+// entryPath returns the file an entry is stored in.
+func (c *Cache) entryPath(baseURL string, accountID uint, group string, key string) string {
+	return filepath.Join(c.accountDir(baseURL, accountID), group, hashKey(key)+".json")
+}
+
+// hashKey returns a filesystem-safe hash of a cache key.
+func hashKey(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:16])
+}
+
+// SetCache enables the on-disk reference data cache for this client.
+func (c *Client) SetCache(cache *Cache) {
+	c.cache = cache
+}
+
+// cacheGroup returns the cache group for an account path, or "" if the path is not cached.
+func (c *Client) cacheGroup(path string) string {
+	rest, ok := strings.CutPrefix(path, c.accountPath(""))
+	if !ok {
+		return ""
+	}
+
+	// Only /account itself is cached; billing and other sub-resources are not.
+	if rest == "/account" {
+		return "account"
+	}
+
+	for _, g := range cacheGroups {
+		if g != "account" && (rest == "/"+g || strings.HasPrefix(rest, "/"+g+"/")) {
+			return g
+		}
+	}
+
+	return ""
+}
+
+// cachedGet serves a GET from the cache when fresh, revalidates with
+// If-None-Match when stale, and stores successful responses.
+func (c *Client) cachedGet(req *http.Request, group string) ([]byte, error) {
+	key := req.URL.RequestURI()
+
+	entry, ok := c.cache.Get(c.baseURL, c.accountID, group, key)
+	if ok && c.cache.Fresh(entry) {
+		return []byte(entry.Body), nil
+	}
+
+	if ok && entry.ETag != "" {
+		req.Header.Set("If-None-Match", entry.ETag)
+	}
+
+	resp, body, err := c.send(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && ok {
+		c.cache.Put(entry)
+		return []byte(entry.Body), nil
+	}
+
+	if err := checkStatus(resp, body); err != nil {
+		return nil, err
+	}
+
+	c.cache.Put(&CacheEntry{
+		BaseURL:   c.baseURL,
+		AccountID: c.accountID,
+		Group:     group,
+		Key:       key,
+		ETag:      resp.Header.Get("ETag"),
+		Body:      string(body),
+	})
+
+	return body, nil
+}
+
+// Invalidate drops cached responses for the group an account path such as
+// "/contacts/12" belongs to, so the next read of it goes to the server.
+func (c *Client) Invalidate(path string) {
+	c.invalidate(c.accountPath(path))
+}
+
+// invalidate drops the cache group a mutated path belongs to.
+func (c *Client) invalidate(path string) {
+	if c.cache == nil {
+		return
+	}
+
+	if group := c.cacheGroup(path); group != "" {
+		c.cache.Invalidate(c.baseURL, c.accountID, group)
+	}
+}
@@ -0,0 +1,198 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// newCachedTestServer returns a client with a cache and a counter of requests per path.
+func newCachedTestServer(t *testing.T, handler http.HandlerFunc) (*Client, *Cache, map[string]int) {
+	t.Helper()
+
+	hits := map[string]int{}
+	server, client := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		hits[r.Method+" "+r.URL.Path]++
+		handler(w, r)
+	})
+	t.Cleanup(server.Close)
+
+	cache := NewCache(t.TempDir(), time.Minute)
+	client.SetCache(cache)
+
+	return client, cache, hits
+}
+
+// TestCacheServesFreshEntries verifies repeated reads within the TTL hit the server once.
+func TestCacheServesFreshEntries(t *testing.T) {
+	client, _, hits := newCachedTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([]Category{{ID: 1, Name: "Meals"}})
+	})
+
+	for i := 0; i < 3; i++ {
+		categories, err := client.GetCategories(nil)
+		if err != nil {
+			t.Fatalf("GetCategories() error = %v", err)
+		}
+		if len(categories) != 1 || categories[0].Name != "Meals" {
+			t.Errorf("GetCategories() = %+v", categories)
+		}
+	}
+
+	if hits["GET /api/v3/1/categories"] != 1 {
+		t.Errorf("server hits = %d, want 1", hits["GET /api/v3/1/categories"])
+	}
+}
+
+// TestCacheRevalidatesWithETag verifies stale entries send If-None-Match and reuse the body on 304.
+func TestCacheRevalidatesWithETag(t *testing.T) {
+	var sawETag string
+	client, cache, hits := newCachedTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		sawETag = r.Header.Get("If-None-Match")
+		if sawETag == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		json.NewEncoder(w).Encode(Contact{ID: 5, Name: "Acme"})
+	})
+
+	if _, err := client.GetContact(5); err != nil {
+		t.Fatalf("GetContact() error = %v", err)
+	}
+
+	// Move the clock past the TTL so the entry must be revalidated.
+	cache.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+
+	contact, err := client.GetContact(5)
+	if err != nil {
+		t.Fatalf("GetContact() error = %v", err)
+	}
+
+	if sawETag != `"v1"` {
+		t.Errorf("If-None-Match = %q, want %q", sawETag, `"v1"`)
+	}
+	if contact.Name != "Acme" {
+		t.Errorf("Name = %q, want Acme from cache", contact.Name)
+	}
+	if hits["GET /api/v3/1/contacts/5"] != 2 {
+		t.Errorf("server hits = %d, want 2", hits["GET /api/v3/1/contacts/5"])
+	}
+}
+
+// TestCacheInvalidatedByMutation verifies creates, updates and deletes drop the cached group.
+func TestCacheInvalidatedByMutation(t *testing.T) {
+	client, _, hits := newCachedTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			json.NewEncoder(w).Encode([]Label{})
+			return
+		}
+		json.NewEncoder(w).Encode(Label{ID: 9, Name: "new"})
+	})
+
+	client.GetLabels(nil)
+	client.CreateLabel(&LabelCreateRequest{Name: "new"})
+	client.GetLabels(nil)
+	client.UpdateLabel(9, &LabelUpdateRequest{Name: "renamed"})
+	client.GetLabels(nil)
+	client.DeleteLabel(9)
+	client.GetLabels(nil)
+
+	if hits["GET /api/v3/1/labels"] != 4 {
+		t.Errorf("list hits = %d, want 4 (one per invalidation)", hits["GET /api/v3/1/labels"])
+	}
+}
+
+// TestCacheInvalidatedByNewContact verifies a ledger entry that creates its
+// contact drops the cached contacts, while one with an existing contact doesn't.
+func TestCacheInvalidatedByNewContact(t *testing.T) {
+	client, _, hits := newCachedTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			json.NewEncoder(w).Encode([]Contact{})
+			return
+		}
+		json.NewEncoder(w).Encode(Ledger{ID: 3})
+	})
+
+	search := map[string]string{"search": "Acme"}
+	client.GetContacts(search)
+	client.CreateLedger(&LedgerCreateRequest{Contact: Contact{ID: 5}})
+	client.GetContacts(search)
+	client.CreateLedger(&LedgerCreateRequest{Contact: Contact{Name: "Acme"}})
+	client.GetContacts(search)
+
+	if hits["GET /api/v3/1/contacts"] != 2 {
+		t.Errorf("contacts hits = %d, want 2", hits["GET /api/v3/1/contacts"])
+	}
+}
+
+// TestCacheInvalidate verifies an explicit invalidation makes the next read go to the server.
+func TestCacheInvalidate(t *testing.T) {
+	client, _, hits := newCachedTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(Contact{ID: 5, Name: "Acme"})
+	})
+
+	client.GetContact(5)
+	client.GetContact(5)
+	client.Invalidate("/contacts/5")
+	client.GetContact(5)
+
+	if hits["GET /api/v3/1/contacts/5"] != 2 {
+		t.Errorf("server hits = %d, want 2", hits["GET /api/v3/1/contacts/5"])
+	}
+}
+
+// TestCacheSkipsUncachedResources verifies ledger and billing reads are never cached.
+func TestCacheSkipsUncachedResources(t *testing.T) {
+	client, _, hits := newCachedTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	})
+
+	client.GetLedger(1)
+	client.GetLedger(1)
+	client.GetBilling()
+	client.GetBilling()
+	client.GetAccount()
+	client.GetAccount()
+
+	if hits["GET /api/v3/1/ledger/1"] != 2 {
+		t.Errorf("ledger hits = %d, want 2", hits["GET /api/v3/1/ledger/1"])
+	}
+	if hits["GET /api/v3/1/account/billing"] != 2 {
+		t.Errorf("billing hits = %d, want 2", hits["GET /api/v3/1/account/billing"])
+	}
+	if hits["GET /api/v3/1/account"] != 1 {
+		t.Errorf("account hits = %d, want 1", hits["GET /api/v3/1/account"])
+	}
+}
+
+// TestCacheKeyedByAccount verifies accounts never see each other's cached data.
+func TestCacheKeyedByAccount(t *testing.T) {
+	client, cache, _ := newCachedTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	})
+
+	client.GetCategories(nil)
+	client.SetAccountID(2)
+	client.GetCategories(nil)
+
+	entries, err := cache.Entries()
+	if err != nil {
+		t.Fatalf("Entries() error = %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("entries = %d, want 2", len(entries))
+	}
+
+	if err := cache.Clear(); err != nil {
+		t.Fatalf("Clear() error = %v", err)
+	}
+	entries, _ = cache.Entries()
+	if len(entries) != 0 {
+		t.Errorf("entries after Clear() = %d, want 0", len(entries))
+	}
+}
@@ -0,0 +1,338 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package api
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Redacted replaces secrets in recorded cassettes.
+const Redacted = "REDACTED"
+
+// sensitiveKeys are JSON body keys and query parameters whose values are redacted.
+var sensitiveKeys = map[string]bool{
+	"access_token":     true,
+	"password":         true,
+	"current_password": true,
+	"new_password":     true,
+
+	// Presigned S3 links for file downloads.
+	"X-Amz-Algorithm":      true,
+	"X-Amz-Credential":     true,
+	"X-Amz-Date":           true,
+	"X-Amz-Expires":        true,
+	"X-Amz-Security-Token": true,
+	"X-Amz-Signature":      true,
+	"X-Amz-SignedHeaders":  true,
+}
+
+// Interaction is a single recorded request/response pair stored in a cassette directory.
+type Interaction struct {
+	Method           string      `json:"method"`
+	Path             string      `json:"path"`
+	Query            string      `json:"query"`
+	RequestHeaders   http.Header `json:"request_headers"`
+	RequestBody      string      `json:"request_body,omitempty"`
+	RequestEncoding  string      `json:"request_encoding,omitempty"`
+	Status           int         `json:"status"`
+	ResponseHeaders  http.Header `json:"response_headers"`
+	ResponseBody     string      `json:"response_body,omitempty"`
+	ResponseEncoding string      `json:"response_encoding,omitempty"`
+}
+
+// key returns the string used to match a replayed request to this interaction.
+func (i *Interaction) key() string {
+	return i.Method + " " + i.Path + "?" + i.Query
+}
+
+// Recorder is an http.RoundTripper that saves sanitized request/response
+// pairs to a directory while passing requests through to the next transport.
+type Recorder struct {
+	dir  string
+	next http.RoundTripper
+	mu   sync.Mutex
+	seq  int
+}
+
+// NewRecorder creates a recorder writing to dir. A nil next uses http.DefaultTransport.
+// Numbering continues after any interactions already in the directory.
+func NewRecorder(dir string, next http.RoundTripper) (*Recorder, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("unable to create cassette directory: %w", err)
+	}
+
+	existing, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read cassette directory: %w", err)
+	}
+
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &Recorder{dir: dir, next: next, seq: len(existing)}, nil
+}
+
+// RoundTrip performs the request and records the exchange.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Streamed uploads are not buffered; only their size is recorded.
+	var reqBody []byte
+	if isMultipart(req.Header) {
+		reqBody = []byte(fmt.Sprintf("[multipart body, %d bytes]", req.ContentLength))
+	} else {
+		body, err := readAndRestore(&req.Body)
+		if err != nil {
+			return nil, err
+		}
+		reqBody = body
+	}
+
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Downloads and other non-JSON responses pass through unbuffered, recording only their size.
+	var respBody []byte
+	if isJSON(resp.Header) {
+		body, err := readAndRestore(&resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		respBody = body
+	} else if resp.ContentLength != 0 {
+		respBody = []byte(fmt.Sprintf("[%s body, %d bytes]", resp.Header.Get("Content-Type"), resp.ContentLength))
+	}
+
+	interaction := &Interaction{
+		Method:          req.Method,
+		Path:            req.URL.Path,
+		Query:           canonicalQuery(req.URL.Query()),
+		RequestHeaders:  sanitizeHeaders(req.Header),
+		Status:          resp.StatusCode,
+		ResponseHeaders: sanitizeHeaders(resp.Header),
+	}
+	interaction.RequestBody, interaction.RequestEncoding = encodeBody(sanitizeBody(reqBody))
+	interaction.ResponseBody, interaction.ResponseEncoding = encodeBody(sanitizeBody(respBody))
+
+	if err := r.save(interaction); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// save writes an interaction to the next numbered file in the cassette directory.
+func (r *Recorder) save(i *Interaction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.seq++
+	slug := strings.Trim(strings.ReplaceAll(i.Path, "/", "-"), "-")
+	name := fmt.Sprintf("%04d-%s-%s.json", r.seq, i.Method, slug)
+
+	data, err := json.MarshalIndent(i, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to marshal interaction: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(r.dir, name), data, 0600); err != nil {
+		return fmt.Errorf("unable to write interaction: %w", err)
+	}
+
+	return nil
+}
+
+// Replayer is an http.RoundTripper that serves recorded interactions instead
+// of touching the network. Requests match on method, path and query; repeated
+// requests are answered in recorded order, repeating the last once exhausted.
+type Replayer struct {
+	mu     sync.Mutex
+	queues map[string][]*Interaction
+}
+
+// NewReplayer loads every interaction from a cassette directory.
+func NewReplayer(dir string) (*Replayer, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read cassette directory: %w", err)
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no recorded interactions found in %s", dir)
+	}
+
+	sort.Strings(files)
+
+	r := &Replayer{queues: map[string][]*Interaction{}}
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read interaction: %w", err)
+		}
+
+		var i Interaction
+		if err := json.Unmarshal(data, &i); err != nil {
+			return nil, fmt.Errorf("unable to parse interaction %s: %w", filepath.Base(f), err)
+		}
+
+		r.queues[i.key()] = append(r.queues[i.key()], &i)
+	}
+
+	return r, nil
+}
+
+// RoundTrip returns the recorded response matching the request.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	key := req.Method + " " + req.URL.Path + "?" + canonicalQuery(req.URL.Query())
+
+	r.mu.Lock()
+	queue := r.queues[key]
+	if len(queue) == 0 {
+		r.mu.Unlock()
+		return nil, fmt.Errorf("no recorded interaction for %s", key)
+	}
+
+	i := queue[0]
+	if len(queue) > 1 {
+		r.queues[key] = queue[1:]
+	}
+	r.mu.Unlock()
+
+	body, err := decodeBody(i.ResponseBody, i.ResponseEncoding)
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", i.Status, http.StatusText(i.Status)),
+		StatusCode:    i.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        i.ResponseHeaders.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// readAndRestore drains a body and replaces it with an equivalent reader.
+func readAndRestore(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+
+	data, err := io.ReadAll(*body)
+	(*body).Close()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read body: %w", err)
+	}
+
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+// canonicalQuery encodes query parameters in sorted order with secrets redacted.
+func canonicalQuery(q url.Values) string {
+	clean := url.Values{}
+	for k, v := range q {
+		if sensitiveKeys[k] {
+			clean[k] = []string{Redacted}
+			continue
+		}
+		clean[k] = v
+	}
+
+	return clean.Encode()
+}
+
+// sanitizeHeaders copies headers, redacting credentials.
+func sanitizeHeaders(h http.Header) http.Header {
+	clean := h.Clone()
+	if clean == nil {
+		return http.Header{}
+	}
+
+	if clean.Get("Authorization") != "" {
+		clean.Set("Authorization", "Bearer "+Redacted)
+	}
+	clean.Del("Set-Cookie")
+	clean.Del("Cookie")
+
+	return clean
+}
+
+// sanitizeBody redacts sensitive keys from JSON bodies and returns other bodies unchanged.
+func sanitizeBody(body []byte) []byte {
+	var v interface{}
+	if len(body) == 0 || json.Unmarshal(body, &v) != nil {
+		return body
+	}
+
+	clean, err := json.Marshal(redactJSON(v))
+	if err != nil {
+		return body
+	}
+
+	return clean
+}
+
+// redactJSON walks a decoded JSON value replacing sensitive keys.
+func redactJSON(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, val := range t {
+			if sensitiveKeys[k] {
+				t[k] = Redacted
+				continue
+			}
+			t[k] = redactJSON(val)
+		}
+	case []interface{}:
+		for i, val := range t {
+			t[i] = redactJSON(val)
+		}
+	}
+
+	return v
+}
+
+// encodeBody returns text bodies as-is and binary bodies as base64.
+func encodeBody(body []byte) (string, string) {
+	if utf8.Valid(body) {
+		return string(body), ""
+	}
+
+	return base64.StdEncoding.EncodeToString(body), "base64"
+}
+
+// decodeBody reverses encodeBody.
+func decodeBody(body string, encoding string) ([]byte, error) {
+	if encoding == "base64" {
+		data, err := base64.StdEncoding.DecodeString(body)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode recorded body: %w", err)
+		}
+		return data, nil
+	}
+
+	return []byte(body), nil
+}
@@ -0,0 +1,169 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestRecorderSanitizesSecrets verifies recorded interactions never contain tokens or passwords.
+func TestRecorderSanitizesSecrets(t *testing.T) {
+	server, client := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(LoginResponse{AccessToken: "secret-token-value", UserID: 7})
+	})
+	defer server.Close()
+
+	dir := t.TempDir()
+	recorder, err := NewRecorder(dir, nil)
+	if err != nil {
+		t.Fatalf("NewRecorder() error = %v", err)
+	}
+	client.SetTransport(recorder)
+
+	if _, err := client.Login("user@example.com", "hunter2", "client"); err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+	if _, err := client.get("/oauth/logout", map[string]string{"access_token": "test-token"}); err != nil {
+		t.Fatalf("get() error = %v", err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 2 {
+		t.Fatalf("recorded %d interactions, want 2", len(files))
+	}
+
+	for _, f := range files {
+		data, _ := os.ReadFile(f)
+		for _, secret := range []string{"hunter2", "secret-token-value", "test-token"} {
+			if strings.Contains(string(data), secret) {
+				t.Errorf("%s contains secret %q", filepath.Base(f), secret)
+			}
+		}
+	}
+}
+
+// TestRecorderSkipsDownloadBodies verifies a download is passed through whole
+// but recorded only by size, with its presigned link's signature redacted.
+func TestRecorderSkipsDownloadBodies(t *testing.T) {
+	content := strings.Repeat("%PDF receipt ", 100)
+	server, _ := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Write([]byte(content))
+	})
+	defer server.Close()
+
+	dir := t.TempDir()
+	recorder, err := NewRecorder(dir, nil)
+	if err != nil {
+		t.Fatalf("NewRecorder() error = %v", err)
+	}
+
+	httpClient := &http.Client{Transport: recorder}
+	resp, err := httpClient.Get(server.URL + "/receipt.pdf?X-Amz-Credential=AKIAEXAMPLE&X-Amz-Signature=abc123")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != content {
+		t.Errorf("body = %d bytes, want the full %d", len(body), len(content))
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 1 {
+		t.Fatalf("recorded %d interactions, want 1", len(files))
+	}
+	data, _ := os.ReadFile(files[0])
+	for _, secret := range []string{"AKIAEXAMPLE", "abc123", "%PDF"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("interaction contains %q", secret)
+		}
+	}
+	if want := fmt.Sprintf("[application/pdf body, %d bytes]", len(content)); !strings.Contains(string(data), want) {
+		t.Errorf("interaction = %s, want placeholder %q", data, want)
+	}
+}
+
+// TestReplayServesRecordedResponses verifies a replayed run returns the recorded payloads offline.
+func TestReplayServesRecordedResponses(t *testing.T) {
+	calls := 0
+	server, client := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]Ledger{{ID: uint(calls), Amount: -4.5}})
+	})
+
+	dir := t.TempDir()
+	recorder, _ := NewRecorder(dir, nil)
+	client.SetTransport(recorder)
+
+	params := map[string]string{"page": "1", "limit": "25"}
+	client.GetLedgers(params)
+	client.GetLedgers(params)
+	server.Close()
+
+	replayer, err := NewReplayer(dir)
+	if err != nil {
+		t.Fatalf("NewReplayer() error = %v", err)
+	}
+
+	offline := NewClient("http://replay.invalid", "other-token", 1)
+	offline.SetTransport(replayer)
+
+	// Responses come back in recorded order, repeating the last once exhausted.
+	for _, want := range []uint{1, 2, 2} {
+		ledgers, err := offline.GetLedgers(map[string]string{"limit": "25", "page": "1"})
+		if err != nil {
+			t.Fatalf("GetLedgers() error = %v", err)
+		}
+		if len(ledgers) != 1 || ledgers[0].ID != want {
+			t.Errorf("GetLedgers() = %+v, want ID %d", ledgers, want)
+		}
+	}
+
+	if _, err := offline.GetLedgers(map[string]string{"page": "2"}); err == nil {
+		t.Error("GetLedgers() with unrecorded query expected error, got nil")
+	}
+}
+
+// TestReplayPreservesErrors verifies recorded API errors replay as errors.
+func TestReplayPreservesErrors(t *testing.T) {
+	server, client := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"bad amount"}`))
+	})
+
+	dir := t.TempDir()
+	recorder, _ := NewRecorder(dir, nil)
+	client.SetTransport(recorder)
+	client.CreateLedger(&LedgerCreateRequest{Amount: 1})
+	server.Close()
+
+	replayer, err := NewReplayer(dir)
+	if err != nil {
+		t.Fatalf("NewReplayer() error = %v", err)
+	}
+	client.SetTransport(replayer)
+
+	_, err = client.CreateLedger(&LedgerCreateRequest{Amount: 1})
+	if err == nil || !strings.Contains(err.Error(), "bad amount") {
+		t.Errorf("CreateLedger() error = %v, want recorded 400", err)
+	}
+}
+
+// TestNewReplayerEmptyDir verifies replaying an empty directory fails early.
+func TestNewReplayerEmptyDir(t *testing.T) {
+	if _, err := NewReplayer(t.TempDir()); err == nil {
+		t.Fatal("NewReplayer() expected error for empty directory, got nil")
+	}
+}
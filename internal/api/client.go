@@ -5,10 +5,12 @@ package api
 
 import (
 	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
-	"mime/multipart"
 	"net/http"
 	"net/url"
 	"os"
@@ -18,10 +20,11 @@ import (
 
 // Client manages HTTP interactions with the Skyclerk API.
 type Client struct {
-	baseURL    string
+	baseURL     string
 	accessToken string
-	accountID  uint
-	httpClient *http.Client
+	accountID   uint
+	httpClient  *http.Client
+	cache       *Cache
 }
 
 // NewClient creates a new API client with the given access token and base URL.
@@ -46,6 +49,30 @@ func (c *Client) SetAccountID(id uint) {
 	c.accountID = id
 }
 
+// BaseURL returns the API base URL.
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// AccountID returns the account ID used for API calls.
+func (c *Client) AccountID() uint {
+	return c.accountID
+}
+
+// SetTransport replaces the HTTP transport used for all requests (e.g. a cassette recorder or replayer).
+func (c *Client) SetTransport(rt http.RoundTripper) {
+	c.httpClient.Transport = rt
+}
+
+// Transport returns the HTTP transport used for all requests, never nil.
+func (c *Client) Transport() http.RoundTripper {
+	if c.httpClient.Transport == nil {
+		return http.DefaultTransport
+	}
+
+	return c.httpClient.Transport
+}
+
 // accountPath builds a URL path with the account ID prefix.
 func (c *Client) accountPath(path string) string {
 	return fmt.Sprintf("/api/v3/%d%s", c.accountID, path)
@@ -74,6 +101,10 @@ func (c *Client) get(path string, params map[string]string) ([]byte, error) {
 	req.Header.Set("Authorization", "Bearer "+c.accessToken)
 	req.Header.Set("Accept", "application/json")
 
+	if group := c.cacheGroup(path); group != "" && c.cache != nil {
+		return c.cachedGet(req, group)
+	}
+
 	return c.doRequest(req)
 }
 
@@ -93,7 +124,7 @@ func (c *Client) post(path string, body interface{}) ([]byte, error) {
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 
-	return c.doRequest(req)
+	return c.doMutation(path, req)
 }
 
 // postNoAuth performs a POST request without authentication (for login).
@@ -130,7 +161,7 @@ func (c *Client) put(path string, body interface{}) ([]byte, error) {
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 
-	return c.doRequest(req)
+	return c.doMutation(path, req)
 }
 
 // delete performs an authenticated DELETE request.
@@ -143,67 +174,128 @@ func (c *Client) delete(path string) ([]byte, error) {
 	req.Header.Set("Authorization", "Bearer "+c.accessToken)
 	req.Header.Set("Accept", "application/json")
 
-	return c.doRequest(req)
+	return c.doMutation(path, req)
 }
 
-// uploadFile performs an authenticated multipart file upload.
-func (c *Client) uploadFile(path string, filePath string, fields map[string]string) ([]byte, error) {
+// uploadFile performs an authenticated multipart file upload, streaming the
+// file from disk rather than buffering it in memory.
+func (c *Client) uploadFile(path string, filePath string, fields map[string]string, opts ...UploadOption) ([]byte, error) {
+	var options uploadOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	file, err := os.Open(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("unable to open file: %w", err)
 	}
 	defer file.Close()
 
-	var buf bytes.Buffer
-	writer := multipart.NewWriter(&buf)
+	// Regular files have a known size, so the request gets an exact Content-Length.
+	size := int64(-1)
+	if info, err := file.Stat(); err == nil && info.Mode().IsRegular() {
+		size = info.Size()
+	}
 
-	// Add additional form fields.
-	for k, v := range fields {
-		if err := writer.WriteField(k, v); err != nil {
-			return nil, fmt.Errorf("unable to write form field: %w", err)
-		}
+	var content io.Reader = file
+	hash := sha256.New()
+	if options.contentHash != nil {
+		content = io.TeeReader(file, hash)
 	}
 
-	// Add the file field.
-	part, err := writer.CreateFormFile("file", filepath.Base(filePath))
+	body, contentType, length, err := multipartBody(fields, filepath.Base(filePath), content, size)
 	if err != nil {
-		return nil, fmt.Errorf("unable to create form file: %w", err)
+		return nil, err
 	}
 
-	if _, err := io.Copy(part, file); err != nil {
-		return nil, fmt.Errorf("unable to copy file data: %w", err)
+	if options.progress != nil {
+		body = &progressReader{r: body, total: length, progress: options.progress}
 	}
 
-	writer.Close()
+	// Give up only when the upload stops moving, not after a fixed time.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	watch := newStallWatch("upload", stallTimeout, cancel)
+	defer watch.stop()
 
-	req, err := http.NewRequest("POST", c.baseURL+path, &buf)
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+path, watch.reader(body))
 	if err != nil {
 		return nil, fmt.Errorf("unable to create request: %w", err)
 	}
+	req.ContentLength = length
 
 	req.Header.Set("Authorization", "Bearer "+c.accessToken)
-	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("Content-Type", contentType)
 	req.Header.Set("Accept", "application/json")
 
-	return c.doRequest(req)
+	resp, data, err := c.sendWith(c.streamClient(), req)
+	if err != nil {
+		return nil, watch.err(err)
+	}
+
+	if err := checkStatus(resp, data); err != nil {
+		return nil, err
+	}
+
+	if options.contentHash != nil {
+		options.contentHash(hex.EncodeToString(hash.Sum(nil)))
+	}
+
+	return data, nil
 }
 
 // doRequest executes an HTTP request and returns the response body.
 func (c *Client) doRequest(req *http.Request) ([]byte, error) {
-	resp, err := c.httpClient.Do(req)
+	resp, body, err := c.send(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkStatus(resp, body); err != nil {
+		return nil, err
+	}
+
+	return body, nil
+}
+
+// doMutation executes a create, update or delete and invalidates any cached data it affects.
+func (c *Client) doMutation(path string, req *http.Request) ([]byte, error) {
+	body, err := c.doRequest(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, err
+	}
+
+	c.invalidate(path)
+
+	return body, nil
+}
+
+// send executes an HTTP request and reads the full response body.
+func (c *Client) send(req *http.Request) (*http.Response, []byte, error) {
+	return c.sendWith(c.httpClient, req)
+}
+
+// sendWith executes an HTTP request with the given client and reads the full response body.
+func (c *Client) sendWith(client *http.Client, req *http.Request) (*http.Response, []byte, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("unable to read response body: %w", err)
+		return nil, nil, fmt.Errorf("unable to read response body: %w", err)
 	}
 
+	return resp, body, nil
+}
+
+// checkStatus returns an error for non-2xx responses.
+func checkStatus(resp *http.Response, body []byte) error {
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+		return fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
 	}
 
-	return body, nil
+	return nil
 }
@@ -0,0 +1,164 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package api
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/config"
+)
+
+// DebugBodyLimit is the maximum number of body bytes printed per request or response.
+const DebugBodyLimit = 4096
+
+// DebugTransport is an http.RoundTripper that logs every request and response
+// (method, URL, headers, bodies and latency) before passing it on.
+type DebugTransport struct {
+	next http.RoundTripper
+	out  io.Writer
+	mu   sync.Mutex
+	now  func() time.Time
+}
+
+// NewDebugTransport wraps next, writing trace output to out. A nil next uses http.DefaultTransport.
+func NewDebugTransport(next http.RoundTripper, out io.Writer) *DebugTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &DebugTransport{next: next, out: out, now: time.Now}
+}
+
+// RoundTrip logs the request, performs it, and logs the response with its latency.
+func (d *DebugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "--> %s %s\n", req.Method, maskURL(req.URL.String()))
+	writeHeaders(&b, req.Header)
+
+	if isMultipart(req.Header) {
+		fmt.Fprintf(&b, "    [multipart body, %d bytes]\n", req.ContentLength)
+	} else {
+		body, err := readAndRestore(&req.Body)
+		if err != nil {
+			return nil, err
+		}
+		writeBody(&b, body)
+	}
+
+	start := d.now()
+	resp, err := d.next.RoundTrip(req)
+	elapsed := d.now().Sub(start).Round(time.Millisecond)
+
+	if err != nil {
+		fmt.Fprintf(&b, "<-- error after %s: %v\n", elapsed, err)
+		d.write(b.String())
+		return nil, err
+	}
+
+	fmt.Fprintf(&b, "<-- %s (%s)\n", resp.Status, elapsed)
+	writeHeaders(&b, resp.Header)
+
+	// Downloads pass through untouched; only JSON and text bodies are buffered.
+	if isText(resp.Header) {
+		body, err := readAndRestore(&resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		writeBody(&b, body)
+	} else if resp.ContentLength != 0 {
+		fmt.Fprintf(&b, "    [binary body, %d bytes]\n", resp.ContentLength)
+	}
+
+	d.write(b.String())
+	return resp, nil
+}
+
+// write emits one complete trace block so concurrent requests don't interleave.
+func (d *DebugTransport) write(s string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	fmt.Fprintln(d.out, s)
+}
+
+// writeHeaders prints headers in sorted order with the Authorization token masked.
+func writeHeaders(b *strings.Builder, h http.Header) {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		for _, v := range h[k] {
+			if k == "Authorization" {
+				v = "Bearer " + config.MaskString(strings.TrimPrefix(v, "Bearer "))
+			}
+			fmt.Fprintf(b, "    %s: %s\n", k, v)
+		}
+	}
+}
+
+// writeBody prints a body, truncated to DebugBodyLimit and summarized when binary.
+func writeBody(b *strings.Builder, body []byte) {
+	if len(body) == 0 {
+		return
+	}
+
+	if !utf8.Valid(body) {
+		fmt.Fprintf(b, "    [binary body, %d bytes]\n", len(body))
+		return
+	}
+
+	text := string(sanitizeBody(body))
+	if len(text) > DebugBodyLimit {
+		text = fmt.Sprintf("%s... [truncated, %d bytes total]", text[:DebugBodyLimit], len(body))
+	}
+
+	fmt.Fprintf(b, "    %s\n", text)
+}
+
+// isJSON reports whether headers describe a JSON body.
+func isJSON(h http.Header) bool {
+	mediaType, _, _ := mime.ParseMediaType(h.Get("Content-Type"))
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// isText reports whether headers describe a JSON or text body worth printing.
+func isText(h http.Header) bool {
+	mediaType, _, _ := mime.ParseMediaType(h.Get("Content-Type"))
+	return isJSON(h) || strings.HasPrefix(mediaType, "text/")
+}
+
+// isMultipart reports whether a request streams a multipart body that should not be buffered.
+func isMultipart(h http.Header) bool {
+	return strings.HasPrefix(h.Get("Content-Type"), "multipart/")
+}
+
+// maskURL masks an access_token query parameter in a URL.
+func maskURL(u string) string {
+	i := strings.Index(u, "access_token=")
+	if i < 0 {
+		return u
+	}
+
+	start := i + len("access_token=")
+	end := strings.IndexByte(u[start:], '&')
+	if end < 0 {
+		end = len(u)
+	} else {
+		end += start
+	}
+
+	return u[:start] + config.MaskString(u[start:end]) + u[end:]
+}
@@ -0,0 +1,139 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestDebugTransportLogsExchange verifies method, URL, masked auth, bodies and status are traced.
+func TestDebugTransportLogsExchange(t *testing.T) {
+	server, _ := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"category type mismatch"}`))
+	})
+	defer server.Close()
+
+	client := NewClient(server.URL, "abcdefghijklmnop", 1)
+
+	var out bytes.Buffer
+	client.SetTransport(NewDebugTransport(nil, &out))
+
+	client.CreateLedger(&LedgerCreateRequest{Amount: -10, Note: "lunch"})
+
+	trace := out.String()
+	for _, want := range []string{
+		"--> POST " + server.URL + "/api/v3/1/ledger",
+		"Authorization: Bearer abcd****mnop",
+		`"note":"lunch"`,
+		"<-- 400 Bad Request (",
+		"category type mismatch",
+	} {
+		if !strings.Contains(trace, want) {
+			t.Errorf("trace missing %q:\n%s", want, trace)
+		}
+	}
+
+	if strings.Contains(trace, "abcdefghijklmnop") {
+		t.Error("trace contains the unmasked access token")
+	}
+}
+
+// TestDebugTransportTruncatesBodies verifies large bodies are cut at DebugBodyLimit.
+func TestDebugTransportTruncatesBodies(t *testing.T) {
+	big := strings.Repeat("x", DebugBodyLimit*2)
+	server, client := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(big))
+	})
+	defer server.Close()
+
+	var out bytes.Buffer
+	client.SetTransport(NewDebugTransport(nil, &out))
+	client.get("/test", nil)
+
+	if strings.Contains(out.String(), big) {
+		t.Error("trace contains the full body, want truncated")
+	}
+	if !strings.Contains(out.String(), "truncated") {
+		t.Error("trace missing truncation marker")
+	}
+}
+
+// TestDebugTransportSkipsMultipartBody verifies uploads are summarized rather than buffered.
+func TestDebugTransportSkipsMultipartBody(t *testing.T) {
+	server, client := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":1}`))
+	})
+	defer server.Close()
+
+	var out bytes.Buffer
+	client.SetTransport(NewDebugTransport(nil, &out))
+
+	path := filepath.Join(t.TempDir(), "receipt.jpg")
+	os.WriteFile(path, []byte("secret receipt bytes"), 0644)
+
+	if _, err := client.UploadFile(path, ""); err != nil {
+		t.Fatalf("UploadFile() error = %v", err)
+	}
+
+	if strings.Contains(out.String(), "secret receipt bytes") {
+		t.Error("trace contains the uploaded file contents")
+	}
+	if !strings.Contains(out.String(), "[multipart body") {
+		t.Error("trace missing multipart summary")
+	}
+}
+
+// TestDebugTransportSkipsDownloadBody verifies binary responses are summarized
+// from Content-Length and reach the caller whole.
+func TestDebugTransportSkipsDownloadBody(t *testing.T) {
+	content := strings.Repeat("\x89PNG receipt ", 100)
+	server, _ := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte(content))
+	})
+	defer server.Close()
+
+	var out bytes.Buffer
+	httpClient := &http.Client{Transport: NewDebugTransport(nil, &out)}
+	resp, err := httpClient.Get(server.URL + "/receipt.png")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if string(body) != content {
+		t.Errorf("body = %d bytes, want the full %d", len(body), len(content))
+	}
+	if want := fmt.Sprintf("[binary body, %d bytes]", len(content)); !strings.Contains(out.String(), want) {
+		t.Errorf("trace missing %q:\n%s", want, out.String())
+	}
+	if strings.Contains(out.String(), "receipt ") {
+		t.Error("trace contains the downloaded file contents")
+	}
+}
+
+// TestDebugTransportMasksQueryToken verifies access tokens in query strings are masked.
+func TestDebugTransportMasksQueryToken(t *testing.T) {
+	server, client := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	defer server.Close()
+
+	var out bytes.Buffer
+	client.SetTransport(NewDebugTransport(nil, &out))
+	client.get("/oauth/logout", map[string]string{"access_token": "abcdefghijklmnop"})
+
+	if strings.Contains(out.String(), "abcdefghijklmnop") {
+		t.Errorf("trace contains the unmasked query token:\n%s", out.String())
+	}
+}
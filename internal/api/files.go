@@ -4,18 +4,34 @@
 package api
 
 import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
 )
 
+// Download describes a completed file download.
+type Download struct {
+	Bytes  int64  `json:"bytes"`
+	SHA256 string `json:"sha256"`
+	MD5    string `json:"md5"`
+}
+
 // UploadFile uploads a file to the current account, optionally associating it with a ledger entry.
-func (c *Client) UploadFile(filePath string, ledgerID string) (*File, error) {
+func (c *Client) UploadFile(filePath string, ledgerID string, opts ...UploadOption) (*File, error) {
 	fields := map[string]string{}
 	if ledgerID != "" {
 		fields["ledger_id"] = ledgerID
 	}
 
-	data, err := c.uploadFile(c.accountPath("/files"), filePath, fields)
+	data, err := c.uploadFile(c.accountPath("/files"), filePath, fields, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to upload file: %w", err)
 	}
@@ -27,3 +43,157 @@ func (c *Client) UploadFile(filePath string, ledgerID string) (*File, error) {
 
 	return &file, nil
 }
+
+// ListFiles retrieves a paginated list of files for the current account.
+func (c *Client) ListFiles(params map[string]string) ([]File, error) {
+	data, err := c.get(c.accountPath("/files"), params)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get files: %w", err)
+	}
+
+	var files []File
+	if err := json.Unmarshal(data, &files); err != nil {
+		return nil, fmt.Errorf("unable to parse files response: %w", err)
+	}
+
+	return files, nil
+}
+
+// GetFile retrieves a single file's metadata by ID.
+func (c *Client) GetFile(id uint) (*File, error) {
+	path := fmt.Sprintf("/files/%d", id)
+	data, err := c.get(c.accountPath(path), nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get file: %w", err)
+	}
+
+	var file File
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("unable to parse file response: %w", err)
+	}
+
+	return &file, nil
+}
+
+// DeleteFile deletes a file by ID.
+func (c *Client) DeleteFile(id uint) error {
+	path := fmt.Sprintf("/files/%d", id)
+	_, err := c.delete(c.accountPath(path))
+	if err != nil {
+		return fmt.Errorf("unable to delete file: %w", err)
+	}
+
+	return nil
+}
+
+// DownloadFile streams a file's contents (or its thumbnail) to w and verifies
+// the result: the byte count must match Content-Length and, for originals, the
+// file's recorded size; a Content-MD5 header or an S3-style MD5 ETag must match
+// the received bytes. The returned Download carries the SHA-256 and MD5.
+func (c *Client) DownloadFile(file *File, thumb bool, w io.Writer) (*Download, error) {
+	src := file.URL
+	if thumb {
+		src = file.Thumb600By600
+	}
+	if src == "" {
+		return nil, fmt.Errorf("file %d has no download URL", file.ID)
+	}
+
+	// No overall deadline: a large receipt on a slow link may take minutes,
+	// so the download is only abandoned when it stops making progress.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	watch := newStallWatch("download", stallTimeout, cancel)
+	defer watch.stop()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", src, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create request: %w", err)
+	}
+
+	// Only send credentials to the API host, never to a storage provider.
+	if sameHost(src, c.baseURL) {
+		req.Header.Set("Authorization", "Bearer "+c.accessToken)
+	}
+
+	resp, err := c.streamClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to download file: request failed: %w", watch.err(err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return nil, fmt.Errorf("unable to download file: %w", checkStatus(resp, body))
+	}
+
+	sha := sha256.New()
+	sum := md5.New()
+	n, err := io.Copy(io.MultiWriter(w, sha, sum), watch.reader(resp.Body))
+	if err != nil {
+		return nil, fmt.Errorf("unable to download file: %w", watch.err(err))
+	}
+
+	dl := &Download{
+		Bytes:  n,
+		SHA256: hex.EncodeToString(sha.Sum(nil)),
+		MD5:    hex.EncodeToString(sum.Sum(nil)),
+	}
+
+	if err := verifyDownload(resp, dl, file, thumb); err != nil {
+		return nil, err
+	}
+
+	return dl, nil
+}
+
+// verifyDownload checks a finished download against the sizes and digests the
+// server advertised.
+func verifyDownload(resp *http.Response, dl *Download, file *File, thumb bool) error {
+	if resp.ContentLength >= 0 && resp.ContentLength != dl.Bytes {
+		return fmt.Errorf("checksum mismatch: received %d of %d bytes", dl.Bytes, resp.ContentLength)
+	}
+
+	if !thumb && file.Size > 0 && file.Size != dl.Bytes {
+		return fmt.Errorf("checksum mismatch: received %d bytes, file size is %d", dl.Bytes, file.Size)
+	}
+
+	if v := resp.Header.Get("Content-MD5"); v != "" {
+		want, err := base64.StdEncoding.DecodeString(v)
+		if err != nil || hex.EncodeToString(want) != dl.MD5 {
+			return fmt.Errorf("checksum mismatch: Content-MD5 %s does not match received data", v)
+		}
+	}
+
+	// S3 ETags for single-part objects are the hex MD5 of the content.
+	if etag := strings.Trim(resp.Header.Get("ETag"), `"`); isHexMD5(etag) && !strings.EqualFold(etag, dl.MD5) {
+		return fmt.Errorf("checksum mismatch: ETag %s does not match received data", etag)
+	}
+
+	return nil
+}
+
+// isHexMD5 reports whether s looks like a hex-encoded MD5 digest.
+func isHexMD5(s string) bool {
+	if len(s) != 32 {
+		return false
+	}
+
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+// sameHost reports whether two URLs share a scheme and host.
+func sameHost(a string, b string) bool {
+	ua, err := url.Parse(a)
+	if err != nil {
+		return false
+	}
+
+	ub, err := url.Parse(b)
+	if err != nil {
+		return false
+	}
+
+	return ua.Scheme == ub.Scheme && ua.Host == ub.Host
+}
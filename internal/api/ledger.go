@@ -46,6 +46,11 @@ func (c *Client) CreateLedger(req *LedgerCreateRequest) (*Ledger, error) {
 		return nil, fmt.Errorf("unable to create ledger: %w", err)
 	}
 
+	// A contact without an ID is created by the API along with the entry.
+	if req.Contact.ID == 0 {
+		c.Invalidate("/contacts")
+	}
+
 	var ledger Ledger
 	if err := json.Unmarshal(data, &ledger); err != nil {
 		return nil, fmt.Errorf("unable to parse ledger response: %w", err)
@@ -62,6 +67,10 @@ func (c *Client) UpdateLedger(id uint, req *LedgerUpdateRequest) (*Ledger, error
 		return nil, fmt.Errorf("unable to update ledger: %w", err)
 	}
 
+	if req.Contact != nil && req.Contact.ID == 0 {
+		c.Invalidate("/contacts")
+	}
+
 	var ledger Ledger
 	if err := json.Unmarshal(data, &ledger); err != nil {
 		return nil, fmt.Errorf("unable to parse ledger response: %w", err)
@@ -93,13 +93,20 @@ type LedgerCreateRequest struct {
 }
 
 // LedgerUpdateRequest represents the payload for updating a ledger entry.
+// Nil fields are left out and stay unchanged; set fields are always sent, so
+// an empty note or label list clears it and an amount of 0 is stored.
 type LedgerUpdateRequest struct {
-	Amount   float64  `json:"amount,omitempty"`
-	Date     string   `json:"date,omitempty"`
-	Contact  Contact  `json:"contact,omitempty"`
-	Category Category `json:"category,omitempty"`
-	Labels   []Label  `json:"labels,omitempty"`
-	Note     string   `json:"note,omitempty"`
+	Amount   *float64  `json:"amount,omitempty"`
+	Date     *string   `json:"date,omitempty"`
+	Contact  *Contact  `json:"contact,omitempty"`
+	Category *Category `json:"category,omitempty"`
+	Labels   *[]Label  `json:"labels,omitempty"`
+	Note     *string   `json:"note,omitempty"`
+}
+
+// Ptr returns a pointer to v, for setting fields of update requests.
+func Ptr[T any](v T) *T {
+	return &v
 }
 
 // LedgerSummary represents a summary of ledger data grouped by year, label, and category.
@@ -196,20 +203,21 @@ type ContactCreateRequest struct {
 	AccountNumber string `json:"account_number,omitempty"`
 }
 
-// ContactUpdateRequest represents the payload for updating a contact.
+// ContactUpdateRequest represents the payload for updating a contact. Nil
+// fields stay unchanged; an empty string clears the field.
 type ContactUpdateRequest struct {
-	Name          string `json:"name,omitempty"`
-	FirstName     string `json:"first_name,omitempty"`
-	LastName      string `json:"last_name,omitempty"`
-	Email         string `json:"email,omitempty"`
-	Phone         string `json:"phone,omitempty"`
-	Address       string `json:"address,omitempty"`
-	City          string `json:"city,omitempty"`
-	State         string `json:"state,omitempty"`
-	Zip           string `json:"zip,omitempty"`
-	Country       string `json:"country,omitempty"`
-	Website       string `json:"website,omitempty"`
-	AccountNumber string `json:"account_number,omitempty"`
+	Name          *string `json:"name,omitempty"`
+	FirstName     *string `json:"first_name,omitempty"`
+	LastName      *string `json:"last_name,omitempty"`
+	Email         *string `json:"email,omitempty"`
+	Phone         *string `json:"phone,omitempty"`
+	Address       *string `json:"address,omitempty"`
+	City          *string `json:"city,omitempty"`
+	State         *string `json:"state,omitempty"`
+	Zip           *string `json:"zip,omitempty"`
+	Country       *string `json:"country,omitempty"`
+	Website       *string `json:"website,omitempty"`
+	AccountNumber *string `json:"account_number,omitempty"`
 }
 
 // File represents an uploaded file/receipt.
@@ -0,0 +1,216 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"sort"
+	"sync/atomic"
+	"time"
+)
+
+// stallTimeout is how long an upload or download may go without moving a
+// byte, or wait for a response, before it is abandoned. Transfers have no
+// overall deadline, since a large file on a slow link takes as long as it takes.
+var stallTimeout = 60 * time.Second
+
+// ProgressFunc is called as an upload is sent with the bytes sent so far and
+// the total request size, or -1 when the size is not known in advance.
+type ProgressFunc func(sent int64, total int64)
+
+// UploadOption configures a file upload.
+type UploadOption func(*uploadOptions)
+
+// uploadOptions holds the settings applied by UploadOption values.
+type uploadOptions struct {
+	progress    ProgressFunc
+	contentHash func(sha256 string)
+}
+
+// WithProgress reports upload progress to fn.
+func WithProgress(fn ProgressFunc) UploadOption {
+	return func(o *uploadOptions) {
+		o.progress = fn
+	}
+}
+
+// WithContentHash reports the hex SHA-256 of the file bytes actually sent to
+// fn once the upload succeeds.
+func WithContentHash(fn func(sha256 string)) UploadOption {
+	return func(o *uploadOptions) {
+		o.contentHash = fn
+	}
+}
+
+// switchWriter forwards writes to whichever writer is currently selected,
+// letting a single multipart.Writer split its output across buffers.
+type switchWriter struct {
+	w io.Writer
+}
+
+// Write forwards to the selected writer.
+func (s *switchWriter) Write(p []byte) (int, error) {
+	return s.w.Write(p)
+}
+
+// multipartBody streams a multipart form containing fields and one file part
+// without buffering the file. When size is known (>= 0) the body is built from
+// pre-rendered head and tail sections around the file so the exact length is
+// returned; otherwise the form is written through an io.Pipe and length is -1.
+func multipartBody(fields map[string]string, fileName string, file io.Reader, size int64) (io.Reader, string, int64, error) {
+	if size < 0 {
+		return pipedMultipartBody(fields, fileName, file)
+	}
+
+	var head, tail bytes.Buffer
+	sw := &switchWriter{w: &head}
+	writer := multipart.NewWriter(sw)
+
+	if err := writeFormFields(writer, fields); err != nil {
+		return nil, "", 0, err
+	}
+
+	if _, err := writer.CreateFormFile("file", fileName); err != nil {
+		return nil, "", 0, fmt.Errorf("unable to create form file: %w", err)
+	}
+
+	// Everything the writer emits after the file part belongs after the file contents.
+	sw.w = &tail
+	if err := writer.Close(); err != nil {
+		return nil, "", 0, fmt.Errorf("unable to close multipart writer: %w", err)
+	}
+
+	length := int64(head.Len()) + size + int64(tail.Len())
+	body := io.MultiReader(&head, io.LimitReader(file, size), &tail)
+
+	return body, writer.FormDataContentType(), length, nil
+}
+
+// pipedMultipartBody writes the multipart form through an io.Pipe for sources of unknown size.
+func pipedMultipartBody(fields map[string]string, fileName string, file io.Reader) (io.Reader, string, int64, error) {
+	pr, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
+
+	go func() {
+		if err := writeFormFields(writer, fields); err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+
+		part, err := writer.CreateFormFile("file", fileName)
+		if err != nil {
+			pw.CloseWithError(fmt.Errorf("unable to create form file: %w", err))
+			return
+		}
+
+		if _, err := io.Copy(part, file); err != nil {
+			pw.CloseWithError(fmt.Errorf("unable to copy file data: %w", err))
+			return
+		}
+
+		pw.CloseWithError(writer.Close())
+	}()
+
+	return pr, writer.FormDataContentType(), -1, nil
+}
+
+// writeFormFields writes form fields in sorted order so bodies are deterministic.
+func writeFormFields(writer *multipart.Writer, fields map[string]string) error {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if err := writer.WriteField(k, fields[k]); err != nil {
+			return fmt.Errorf("unable to write form field: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// progressReader reports how many bytes have been read through it.
+type progressReader struct {
+	r        io.Reader
+	sent     int64
+	total    int64
+	progress ProgressFunc
+}
+
+// Read reads from the underlying reader and reports progress.
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	if n > 0 {
+		p.sent += int64(n)
+		p.progress(p.sent, p.total)
+	}
+
+	return n, err
+}
+
+// streamClient returns an HTTP client for uploads and downloads that shares
+// the client's transport but not its overall request timeout.
+func (c *Client) streamClient() *http.Client {
+	return &http.Client{Transport: c.httpClient.Transport}
+}
+
+// stallWatch cancels a request once it has made no progress for a while.
+type stallWatch struct {
+	what    string // "upload" or "download", for the error
+	timeout time.Duration
+	timer   *time.Timer
+	fired   atomic.Bool
+}
+
+// newStallWatch starts a watch that calls cancel after timeout without progress.
+func newStallWatch(what string, timeout time.Duration, cancel func()) *stallWatch {
+	w := &stallWatch{what: what, timeout: timeout}
+	w.timer = time.AfterFunc(timeout, func() {
+		w.fired.Store(true)
+		cancel()
+	})
+
+	return w
+}
+
+// reader returns r, restarting the watch whenever bytes are read through it.
+func (w *stallWatch) reader(r io.Reader) io.Reader {
+	return &stallReader{r: r, watch: w}
+}
+
+// stop ends the watch.
+func (w *stallWatch) stop() {
+	w.timer.Stop()
+}
+
+// err explains a request error caused by the watch cancelling the request.
+func (w *stallWatch) err(err error) error {
+	if w.fired.Load() {
+		return fmt.Errorf("%s stalled: nothing sent or received for %s", w.what, w.timeout)
+	}
+
+	return err
+}
+
+// stallReader restarts a stallWatch on every read that returns data.
+type stallReader struct {
+	r     io.Reader
+	watch *stallWatch
+}
+
+// Read reads from the underlying reader and restarts the watch on progress.
+func (s *stallReader) Read(b []byte) (int, error) {
+	n, err := s.r.Read(b)
+	if n > 0 {
+		s.watch.timer.Reset(s.watch.timeout)
+	}
+
+	return n, err
+}
@@ -0,0 +1,184 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// readMultipart parses a multipart body and returns its fields and file contents.
+func readMultipart(t *testing.T, body io.Reader, contentType string) (map[string]string, string) {
+	t.Helper()
+
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		t.Fatalf("ParseMediaType() error = %v", err)
+	}
+
+	fields := map[string]string{}
+	var file string
+
+	reader := multipart.NewReader(body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("NextPart() error = %v", err)
+		}
+
+		data, _ := io.ReadAll(part)
+		if part.FormName() == "file" {
+			file = string(data)
+			continue
+		}
+		fields[part.FormName()] = string(data)
+	}
+
+	return fields, file
+}
+
+// TestMultipartBodyKnownSize verifies the computed length matches the streamed body exactly.
+func TestMultipartBodyKnownSize(t *testing.T) {
+	content := strings.Repeat("receipt-", 1000)
+	body, contentType, length, err := multipartBody(map[string]string{"ledger_id": "42"}, "r.jpg", strings.NewReader(content), int64(len(content)))
+	if err != nil {
+		t.Fatalf("multipartBody() error = %v", err)
+	}
+
+	data, _ := io.ReadAll(body)
+	if int64(len(data)) != length {
+		t.Errorf("body length = %d, computed length = %d", len(data), length)
+	}
+
+	fields, file := readMultipart(t, strings.NewReader(string(data)), contentType)
+	if fields["ledger_id"] != "42" || file != content {
+		t.Errorf("fields = %v, file length = %d", fields, len(file))
+	}
+}
+
+// TestMultipartBodyUnknownSize verifies sources of unknown size stream through a pipe.
+func TestMultipartBodyUnknownSize(t *testing.T) {
+	body, contentType, length, err := multipartBody(nil, "r.pdf", strings.NewReader("pdf bytes"), -1)
+	if err != nil {
+		t.Fatalf("multipartBody() error = %v", err)
+	}
+
+	if length != -1 {
+		t.Errorf("length = %d, want -1", length)
+	}
+
+	_, file := readMultipart(t, body, contentType)
+	if file != "pdf bytes" {
+		t.Errorf("file = %q, want %q", file, "pdf bytes")
+	}
+}
+
+// TestUploadFileStreamsWithProgress verifies Content-Length is sent and progress reaches the total.
+func TestUploadFileStreamsWithProgress(t *testing.T) {
+	var gotLength int64
+	server, client := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		gotLength = r.ContentLength
+		fields, file := readMultipart(t, r.Body, r.Header.Get("Content-Type"))
+		if fields["ledger_id"] != "7" || len(file) != 64*1024 {
+			t.Errorf("fields = %v, file length = %d", fields, len(file))
+		}
+		json.NewEncoder(w).Encode(File{ID: 1})
+	})
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "scan.pdf")
+	os.WriteFile(path, make([]byte, 64*1024), 0644)
+
+	var lastSent, lastTotal int64
+	var hash string
+	calls := 0
+	_, err := client.UploadFile(path, "7", WithProgress(func(sent, total int64) {
+		calls++
+		lastSent, lastTotal = sent, total
+	}), WithContentHash(func(sha string) { hash = sha }))
+	if err != nil {
+		t.Fatalf("UploadFile() error = %v", err)
+	}
+	if sum := sha256.Sum256(make([]byte, 64*1024)); hash != hex.EncodeToString(sum[:]) {
+		t.Errorf("content hash = %q, want the SHA-256 of the file", hash)
+	}
+
+	if gotLength <= 64*1024 {
+		t.Errorf("Content-Length = %d, want known length above file size", gotLength)
+	}
+	if calls == 0 || lastSent != lastTotal || lastTotal != gotLength {
+		t.Errorf("progress = %d/%d after %d calls, want %d/%d", lastSent, lastTotal, calls, gotLength, gotLength)
+	}
+}
+
+// TestUploadFileStall verifies uploads outlive the client's request timeout
+// while they make progress, and are abandoned once they stall.
+func TestUploadFileStall(t *testing.T) {
+	defer func(d time.Duration) { stallTimeout = d }(stallTimeout)
+
+	delay := 150 * time.Millisecond
+	server, client := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		time.Sleep(delay)
+		json.NewEncoder(w).Encode(File{ID: 1})
+	})
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "scan.pdf")
+	os.WriteFile(path, []byte("pdf bytes"), 0644)
+
+	client.httpClient.Timeout = 50 * time.Millisecond
+	stallTimeout = time.Second
+	if _, err := client.UploadFile(path, "7"); err != nil {
+		t.Errorf("UploadFile() error = %v, want the request timeout not to apply", err)
+	}
+
+	stallTimeout = 50 * time.Millisecond
+	if _, err := client.UploadFile(path, "7"); err == nil || !strings.Contains(err.Error(), "stalled") {
+		t.Errorf("UploadFile() error = %v, want a stall", err)
+	}
+}
+
+// TestDownloadFileStall verifies downloads outlive the client's request
+// timeout while bytes keep arriving, and are abandoned once they stall.
+func TestDownloadFileStall(t *testing.T) {
+	defer func(d time.Duration) { stallTimeout = d }(stallTimeout)
+
+	// The pause between chunks comes from the URL, e.g. /receipt.pdf?gap=40ms.
+	server, client := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		gap, _ := time.ParseDuration(r.URL.Query().Get("gap"))
+		for i := 0; i < 4; i++ {
+			io.WriteString(w, "chunk")
+			w.(http.Flusher).Flush()
+			time.Sleep(gap)
+		}
+	})
+	defer server.Close()
+
+	file := &File{ID: 1, URL: server.URL + "/receipt.pdf?gap=40ms", Size: 20}
+	client.httpClient.Timeout = 100 * time.Millisecond
+	stallTimeout = time.Second
+	if dl, err := client.DownloadFile(file, false, io.Discard); err != nil || dl.Bytes != 20 {
+		t.Errorf("DownloadFile() = %+v, %v; want the request timeout not to apply", dl, err)
+	}
+
+	file.URL = server.URL + "/receipt.pdf?gap=150ms"
+	stallTimeout = 50 * time.Millisecond
+	if _, err := client.DownloadFile(file, false, io.Discard); err == nil || !strings.Contains(err.Error(), "download stalled") {
+		t.Errorf("DownloadFile() error = %v, want a stall", err)
+	}
+}
@@ -0,0 +1,215 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package apitest
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+)
+
+// handleGetAccount returns the account from the request path.
+func (s *Server) handleGetAccount(w http.ResponseWriter, r *http.Request, userID uint, accountID uint) {
+	writeJSON(w, http.StatusOK, s.accounts[accountID])
+}
+
+// handleUpdateAccount replaces the editable account fields.
+func (s *Server) handleUpdateAccount(w http.ResponseWriter, r *http.Request, userID uint, accountID uint) {
+	var req api.Account
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	req.ID = accountID
+	req.OwnerID = s.accounts[accountID].OwnerID
+	s.accounts[accountID] = &req
+
+	writeJSON(w, http.StatusOK, req)
+}
+
+// handleGetBilling returns the account billing profile.
+func (s *Server) handleGetBilling(w http.ResponseWriter, r *http.Request, userID uint, accountID uint) {
+	billing, ok := s.billing[accountID]
+	if !ok {
+		writeError(w, http.StatusNotFound, "billing not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, billing)
+}
+
+// handleGetMe returns the authenticated user's profile.
+func (s *Server) handleGetMe(w http.ResponseWriter, r *http.Request, userID uint, accountID uint) {
+	writeJSON(w, http.StatusOK, meResponse(s.users[userID].User))
+}
+
+// handleUpdateMe updates the authenticated user's profile.
+func (s *Server) handleUpdateMe(w http.ResponseWriter, r *http.Request, userID uint, accountID uint) {
+	var req api.MeUpdateRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	user := &s.users[userID].User
+	if req.FirstName != "" {
+		user.FirstName = req.FirstName
+	}
+	if req.LastName != "" {
+		user.LastName = req.LastName
+	}
+	if req.Email != "" {
+		user.Email = req.Email
+	}
+
+	writeJSON(w, http.StatusOK, meResponse(*user))
+}
+
+// handleChangePassword verifies the current password and stores the new one.
+func (s *Server) handleChangePassword(w http.ResponseWriter, r *http.Request, userID uint, accountID uint) {
+	var req api.ChangePasswordRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	rec := s.users[userID]
+	if req.CurrentPassword != rec.Password {
+		writeError(w, http.StatusBadRequest, "current password is incorrect")
+		return
+	}
+	if req.NewPassword == "" {
+		writeError(w, http.StatusBadRequest, "new password is required")
+		return
+	}
+
+	rec.Password = req.NewPassword
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleListUsers returns every user belonging to the account.
+func (s *Server) handleListUsers(w http.ResponseWriter, r *http.Request, userID uint, accountID uint) {
+	users := []api.User{}
+	for id, rec := range s.users {
+		if s.userInAccount(id, accountID) {
+			users = append(users, rec.User)
+		}
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+
+	writeJSON(w, http.StatusOK, users)
+}
+
+// handleRemoveUser removes a user from the account (but never the owner).
+func (s *Server) handleRemoveUser(w http.ResponseWriter, r *http.Request, userID uint, accountID uint) {
+	id, ok := pathID(r)
+	if !ok || !s.userInAccount(id, accountID) {
+		writeError(w, http.StatusNotFound, "user not found")
+		return
+	}
+
+	if s.accounts[accountID].OwnerID == id {
+		writeError(w, http.StatusBadRequest, "the account owner can not be removed")
+		return
+	}
+
+	rec := s.users[id]
+	kept := []api.Account{}
+	for _, a := range rec.User.Accounts {
+		if a.ID != accountID {
+			kept = append(kept, a)
+		}
+	}
+	rec.User.Accounts = kept
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleListInvites returns the pending invitations for the account.
+func (s *Server) handleListInvites(w http.ResponseWriter, r *http.Request, userID uint, accountID uint) {
+	invites := []api.Invite{}
+	for _, inv := range s.invites {
+		if inv.AccountID == accountID {
+			invites = append(invites, *inv)
+		}
+	}
+	sort.Slice(invites, func(i, j int) bool { return invites[i].ID < invites[j].ID })
+
+	writeJSON(w, http.StatusOK, invites)
+}
+
+// handleCreateInvite creates a pending invitation that expires in a week.
+func (s *Server) handleCreateInvite(w http.ResponseWriter, r *http.Request, userID uint, accountID uint) {
+	var req api.InviteCreateRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if req.Email == "" || req.FirstName == "" || req.LastName == "" {
+		writeError(w, http.StatusBadRequest, "email, first_name and last_name are required")
+		return
+	}
+
+	invite := &api.Invite{
+		ID:        s.newID(),
+		AccountID: accountID,
+		Email:     req.Email,
+		FirstName: req.FirstName,
+		LastName:  req.LastName,
+		Message:   req.Message,
+		ExpiresAt: time.Now().UTC().Add(7 * 24 * time.Hour).Format(time.RFC3339),
+		CreatedAt: now(),
+	}
+	s.invites[invite.ID] = invite
+
+	writeJSON(w, http.StatusCreated, invite)
+}
+
+// handleCancelInvite deletes a pending invitation.
+func (s *Server) handleCancelInvite(w http.ResponseWriter, r *http.Request, userID uint, accountID uint) {
+	id, ok := pathID(r)
+	if !ok || s.invites[id] == nil || s.invites[id].AccountID != accountID {
+		writeError(w, http.StatusNotFound, "invite not found")
+		return
+	}
+
+	delete(s.invites, id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleListActivities returns the most recent activities, newest first by default.
+func (s *Server) handleListActivities(w http.ResponseWriter, r *http.Request, userID uint, accountID uint) {
+	activities := []api.Activity{}
+	for _, a := range s.activities {
+		if a.AccountID == accountID {
+			activities = append(activities, a)
+		}
+	}
+
+	asc := r.URL.Query().Get("sort") == "ASC"
+	sort.Slice(activities, func(i, j int) bool {
+		if asc {
+			return activities[i].ID < activities[j].ID
+		}
+		return activities[i].ID > activities[j].ID
+	})
+
+	if limit, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && limit > 0 && limit < len(activities) {
+		activities = activities[:limit]
+	}
+
+	writeJSON(w, http.StatusOK, activities)
+}
+
+// meResponse converts a user into the /me response shape.
+func meResponse(u api.User) api.MeResponse {
+	return api.MeResponse{
+		ID:        u.ID,
+		FirstName: u.FirstName,
+		LastName:  u.LastName,
+		Email:     u.Email,
+		Status:    u.Status,
+	}
+}
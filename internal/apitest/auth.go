@@ -0,0 +1,60 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package apitest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+)
+
+// handleToken implements the OAuth password grant.
+func (s *Server) handleToken(w http.ResponseWriter, r *http.Request) {
+	var req api.LoginRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if req.GrantType != "password" || req.ClientID == "" {
+		writeError(w, http.StatusBadRequest, "invalid grant")
+		return
+	}
+
+	for _, rec := range s.users {
+		if rec.User.Email == req.Username && rec.Password == req.Password {
+			buf := make([]byte, 16)
+			rand.Read(buf)
+			token := hex.EncodeToString(buf)
+			s.tokens[token] = rec.User.ID
+
+			writeJSON(w, http.StatusOK, api.LoginResponse{
+				AccessToken: token,
+				UserID:      rec.User.ID,
+				TokenType:   "bearer",
+			})
+			return
+		}
+	}
+
+	writeError(w, http.StatusUnauthorized, "invalid credentials")
+}
+
+// handleLogout revokes the access token passed as a query parameter.
+func (s *Server) handleLogout(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("access_token")
+	if _, ok := s.tokens[token]; !ok {
+		writeError(w, http.StatusUnauthorized, "invalid access token")
+		return
+	}
+
+	delete(s.tokens, token)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleAuthUser returns the authenticated user along with their accounts.
+func (s *Server) handleAuthUser(w http.ResponseWriter, r *http.Request, userID uint) {
+	writeJSON(w, http.StatusOK, s.users[userID].User)
+}
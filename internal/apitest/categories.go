@@ -0,0 +1,146 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package apitest
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+)
+
+// normalizeCategoryType maps the numeric type the write endpoints accept
+// ("1" expense, "2" income) to the name the read endpoints return.
+func normalizeCategoryType(t string) (string, bool) {
+	switch strings.ToLower(t) {
+	case "1", "expense":
+		return "expense", true
+	case "2", "income":
+		return "income", true
+	default:
+		return "", false
+	}
+}
+
+// categoryCount returns the number of ledger entries using the category.
+func (s *Server) categoryCount(id uint) int {
+	count := 0
+	for _, l := range s.ledgers {
+		if l.Category.ID == id {
+			count++
+		}
+	}
+
+	return count
+}
+
+// findCategory returns the category if it exists in the account.
+func (s *Server) findCategory(accountID uint, id uint) (*api.Category, bool) {
+	c, ok := s.categories[id]
+	if !ok || c.AccountID != accountID {
+		return nil, false
+	}
+
+	return c, true
+}
+
+// handleListCategories returns every category in the account sorted by name.
+func (s *Server) handleListCategories(w http.ResponseWriter, r *http.Request, userID uint, accountID uint) {
+	categories := []api.Category{}
+	for _, c := range s.categories {
+		if c.AccountID == accountID {
+			cat := *c
+			cat.Count = s.categoryCount(c.ID)
+			categories = append(categories, cat)
+		}
+	}
+	sort.Slice(categories, func(i, j int) bool { return categories[i].Name < categories[j].Name })
+
+	writeJSON(w, http.StatusOK, categories)
+}
+
+// handleGetCategory returns a single category.
+func (s *Server) handleGetCategory(w http.ResponseWriter, r *http.Request, userID uint, accountID uint) {
+	id, _ := pathID(r)
+	c, ok := s.findCategory(accountID, id)
+	if !ok {
+		writeError(w, http.StatusNotFound, "category not found")
+		return
+	}
+
+	cat := *c
+	cat.Count = s.categoryCount(c.ID)
+	writeJSON(w, http.StatusOK, cat)
+}
+
+// handleCreateCategory creates a new category.
+func (s *Server) handleCreateCategory(w http.ResponseWriter, r *http.Request, userID uint, accountID uint) {
+	var req api.CategoryCreateRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	catType, ok := normalizeCategoryType(req.Type)
+	if req.Name == "" || !ok {
+		writeError(w, http.StatusBadRequest, "name and a valid type are required")
+		return
+	}
+
+	c := &api.Category{ID: s.newID(), AccountID: accountID, Name: req.Name, Type: catType}
+	s.categories[c.ID] = c
+	s.logActivity(api.Activity{AccountID: accountID, UserID: userID, CategoryID: c.ID, Action: "create", SubAction: "category", Message: "created category " + c.Name})
+
+	writeJSON(w, http.StatusCreated, c)
+}
+
+// handleUpdateCategory updates the non-empty fields of a category.
+func (s *Server) handleUpdateCategory(w http.ResponseWriter, r *http.Request, userID uint, accountID uint) {
+	id, _ := pathID(r)
+	c, ok := s.findCategory(accountID, id)
+	if !ok {
+		writeError(w, http.StatusNotFound, "category not found")
+		return
+	}
+
+	var req api.CategoryUpdateRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if req.Name != "" {
+		c.Name = req.Name
+	}
+	if req.Type != "" {
+		catType, ok := normalizeCategoryType(req.Type)
+		if !ok {
+			writeError(w, http.StatusBadRequest, "invalid category type")
+			return
+		}
+		c.Type = catType
+	}
+	s.logActivity(api.Activity{AccountID: accountID, UserID: userID, CategoryID: c.ID, Action: "update", SubAction: "category", Message: "updated category " + c.Name})
+
+	writeJSON(w, http.StatusOK, c)
+}
+
+// handleDeleteCategory deletes a category that is not used by any ledger entry.
+func (s *Server) handleDeleteCategory(w http.ResponseWriter, r *http.Request, userID uint, accountID uint) {
+	id, _ := pathID(r)
+	c, ok := s.findCategory(accountID, id)
+	if !ok {
+		writeError(w, http.StatusNotFound, "category not found")
+		return
+	}
+
+	if s.categoryCount(id) > 0 {
+		writeError(w, http.StatusBadRequest, "category is in use by ledger entries")
+		return
+	}
+
+	delete(s.categories, id)
+	s.logActivity(api.Activity{AccountID: accountID, UserID: userID, CategoryID: id, Action: "delete", SubAction: "category", Message: "deleted category " + c.Name})
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,168 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package apitest
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+)
+
+// findContact returns the contact if it exists in the account.
+func (s *Server) findContact(accountID uint, id uint) (*api.Contact, bool) {
+	c, ok := s.contacts[id]
+	if !ok || c.AccountID != accountID {
+		return nil, false
+	}
+
+	return c, true
+}
+
+// handleListContacts returns contacts sorted by name, filtered by ?search.
+func (s *Server) handleListContacts(w http.ResponseWriter, r *http.Request, userID uint, accountID uint) {
+	search := strings.ToLower(r.URL.Query().Get("search"))
+
+	contacts := []api.Contact{}
+	for _, c := range s.contacts {
+		if c.AccountID != accountID {
+			continue
+		}
+		if search != "" && !strings.Contains(strings.ToLower(c.Name), search) {
+			continue
+		}
+		contacts = append(contacts, *c)
+	}
+	sort.Slice(contacts, func(i, j int) bool { return contacts[i].Name < contacts[j].Name })
+
+	writeJSON(w, http.StatusOK, contacts)
+}
+
+// handleGetContact returns a single contact.
+func (s *Server) handleGetContact(w http.ResponseWriter, r *http.Request, userID uint, accountID uint) {
+	id, _ := pathID(r)
+	c, ok := s.findContact(accountID, id)
+	if !ok {
+		writeError(w, http.StatusNotFound, "contact not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, c)
+}
+
+// handleCreateContact creates a new contact.
+func (s *Server) handleCreateContact(w http.ResponseWriter, r *http.Request, userID uint, accountID uint) {
+	var req api.ContactCreateRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if req.Name == "" {
+		writeError(w, http.StatusBadRequest, "name is required")
+		return
+	}
+
+	c := &api.Contact{
+		ID:            s.newID(),
+		AccountID:     accountID,
+		Name:          req.Name,
+		FirstName:     req.FirstName,
+		LastName:      req.LastName,
+		Email:         req.Email,
+		Phone:         req.Phone,
+		Address:       req.Address,
+		City:          req.City,
+		State:         req.State,
+		Zip:           req.Zip,
+		Country:       req.Country,
+		Website:       req.Website,
+		AccountNumber: req.AccountNumber,
+	}
+	s.contacts[c.ID] = c
+	s.logActivity(api.Activity{AccountID: accountID, UserID: userID, ContactID: c.ID, Action: "create", SubAction: "contact", Message: "created contact " + c.Name})
+
+	writeJSON(w, http.StatusCreated, c)
+}
+
+// handleUpdateContact applies every field present in the JSON body, so
+// explicit empty strings clear values while omitted keys are left alone.
+func (s *Server) handleUpdateContact(w http.ResponseWriter, r *http.Request, userID uint, accountID uint) {
+	id, _ := pathID(r)
+	c, ok := s.findContact(accountID, id)
+	if !ok {
+		writeError(w, http.StatusNotFound, "contact not found")
+		return
+	}
+
+	var fields map[string]json.RawMessage
+	if !decodeJSON(w, r, &fields) {
+		return
+	}
+
+	updated := *c
+	targets := map[string]*string{
+		"name":           &updated.Name,
+		"first_name":     &updated.FirstName,
+		"last_name":      &updated.LastName,
+		"email":          &updated.Email,
+		"phone":          &updated.Phone,
+		"fax":            &updated.Fax,
+		"address":        &updated.Address,
+		"city":           &updated.City,
+		"state":          &updated.State,
+		"zip":            &updated.Zip,
+		"country":        &updated.Country,
+		"website":        &updated.Website,
+		"account_number": &updated.AccountNumber,
+	}
+	for key, raw := range fields {
+		target, ok := targets[key]
+		if !ok {
+			continue
+		}
+		if err := json.Unmarshal(raw, target); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid value for "+key)
+			return
+		}
+	}
+
+	if updated.Name == "" {
+		writeError(w, http.StatusBadRequest, "name can not be blank")
+		return
+	}
+
+	*c = updated
+	for _, entry := range s.ledgers {
+		if entry.Contact.ID == c.ID {
+			entry.Contact = *c
+		}
+	}
+	s.logActivity(api.Activity{AccountID: accountID, UserID: userID, ContactID: c.ID, Action: "update", SubAction: "contact", Message: "updated contact " + c.Name})
+
+	writeJSON(w, http.StatusOK, c)
+}
+
+// handleDeleteContact deletes a contact that is not used by any ledger entry.
+func (s *Server) handleDeleteContact(w http.ResponseWriter, r *http.Request, userID uint, accountID uint) {
+	id, _ := pathID(r)
+	c, ok := s.findContact(accountID, id)
+	if !ok {
+		writeError(w, http.StatusNotFound, "contact not found")
+		return
+	}
+
+	for _, entry := range s.ledgers {
+		if entry.Contact.ID == id {
+			writeError(w, http.StatusBadRequest, "contact is in use by ledger entries")
+			return
+		}
+	}
+
+	delete(s.contacts, id)
+	s.logActivity(api.Activity{AccountID: accountID, UserID: userID, ContactID: id, Action: "delete", SubAction: "contact", Message: "deleted contact " + c.Name})
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,188 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package apitest
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+)
+
+// fileURL builds the download URL for a stored file on this server.
+func fileURL(r *http.Request, id uint, name string) string {
+	return fmt.Sprintf("http://%s/fake-files/%d/%s", r.Host, id, url.PathEscape(name))
+}
+
+// handleUploadFile stores a multipart upload and optionally attaches it to a ledger entry.
+func (s *Server) handleUploadFile(w http.ResponseWriter, r *http.Request, userID uint, accountID uint) {
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid multipart form")
+		return
+	}
+
+	part, header, err := r.FormFile("file")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "file is required")
+		return
+	}
+	defer part.Close()
+
+	data, err := io.ReadAll(part)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "unable to read file")
+		return
+	}
+
+	var ledger *api.Ledger
+	if v := r.FormValue("ledger_id"); v != "" {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid ledger_id")
+			return
+		}
+		l, ok := s.findLedger(accountID, uint(id))
+		if !ok {
+			writeError(w, http.StatusBadRequest, "ledger entry not found")
+			return
+		}
+		ledger = l
+	}
+
+	fileType := mime.TypeByExtension(filepath.Ext(header.Filename))
+	if fileType == "" {
+		fileType = http.DetectContentType(data)
+	}
+
+	id := s.newID()
+	stored := &storedFile{
+		File: api.File{
+			ID:        id,
+			AccountID: accountID,
+			Name:      header.Filename,
+			Type:      fileType,
+			Size:      int64(len(data)),
+			URL:       fileURL(r, id, header.Filename),
+			CreatedAt: now(),
+		},
+		Data: data,
+	}
+	stored.File.Thumb600By600 = stored.File.URL
+	stored.File.UpdatedAt = stored.File.CreatedAt
+
+	if ledger != nil {
+		stored.LedgerID = ledger.ID
+		ledger.Files = append(ledger.Files, stored.File)
+	}
+	s.files[id] = stored
+
+	writeJSON(w, http.StatusCreated, stored.File)
+}
+
+// accountFiles returns an account's files, newest first.
+func (s *Server) accountFiles(accountID uint) []api.File {
+	var list []api.File
+	for _, f := range s.files {
+		if f.File.AccountID == accountID {
+			list = append(list, f.File)
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i].ID > list[j].ID })
+	return list
+}
+
+// findFile returns a stored file belonging to the account.
+func (s *Server) findFile(accountID uint, id uint) (*storedFile, bool) {
+	f, ok := s.files[id]
+	if !ok || f.File.AccountID != accountID {
+		return nil, false
+	}
+
+	return f, true
+}
+
+// handleListFiles returns a page of files, newest first.
+func (s *Server) handleListFiles(w http.ResponseWriter, r *http.Request, userID uint, accountID uint) {
+	list := s.accountFiles(accountID)
+
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = 25
+	}
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil || page <= 0 {
+		page = 1
+	}
+
+	start := (page - 1) * limit
+	if start > len(list) {
+		start = len(list)
+	}
+	end := start + limit
+	if end > len(list) {
+		end = len(list)
+	}
+
+	writeJSON(w, http.StatusOK, list[start:end])
+}
+
+// handleGetFile returns a single file's metadata.
+func (s *Server) handleGetFile(w http.ResponseWriter, r *http.Request, userID uint, accountID uint) {
+	id, _ := pathID(r)
+	f, ok := s.findFile(accountID, id)
+	if !ok {
+		writeError(w, http.StatusNotFound, "file not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, f.File)
+}
+
+// handleDeleteFile deletes a file and detaches it from its ledger entry.
+func (s *Server) handleDeleteFile(w http.ResponseWriter, r *http.Request, userID uint, accountID uint) {
+	id, _ := pathID(r)
+	f, ok := s.findFile(accountID, id)
+	if !ok {
+		writeError(w, http.StatusNotFound, "file not found")
+		return
+	}
+
+	if l, ok := s.findLedger(accountID, f.LedgerID); ok {
+		kept := l.Files[:0]
+		for _, lf := range l.Files {
+			if lf.ID != id {
+				kept = append(kept, lf)
+			}
+		}
+		l.Files = kept
+	}
+
+	delete(s.files, id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleFileContent serves the raw bytes of a stored file.
+func (s *Server) handleFileContent(w http.ResponseWriter, r *http.Request) {
+	id, _ := pathID(r)
+	f, ok := s.files[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "file not found")
+		return
+	}
+
+	sum := md5.Sum(f.Data)
+	w.Header().Set("Content-MD5", base64.StdEncoding.EncodeToString(sum[:]))
+	w.Header().Set("Content-Type", f.File.Type)
+	w.Header().Set("Content-Length", strconv.Itoa(len(f.Data)))
+	w.Write(f.Data)
+}
@@ -0,0 +1,123 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package apitest
+
+import "github.com/cloudmanic/skyclerk-cli/internal/api"
+
+// seed loads the default fixtures: one user, one account, and a small but
+// realistic set of contacts, categories, labels and ledger entries.
+func (s *Server) seed() {
+	account := api.Account{
+		ID:       DefaultAccountID,
+		OwnerID:  DefaultUserID,
+		Name:     "Demo Company",
+		Address:  "100 Main St",
+		City:     "Portland",
+		State:    "OR",
+		Zip:      "97201",
+		Country:  "US",
+		Locale:   "en-US",
+		Currency: "USD",
+	}
+	s.accounts[account.ID] = &account
+
+	s.billing[account.ID] = &api.Billing{
+		ID:                 1,
+		PaymentProcessor:   "Stripe",
+		Subscription:       "Monthly",
+		Status:             "Active",
+		CardBrand:          "Visa",
+		CardLast4:          "4242",
+		CardExpMonth:       12,
+		CardExpYear:        2030,
+		CurrentPeriodStart: "2026-10-01T00:00:00Z",
+		CurrentPeriodEnd:   "2026-11-01T00:00:00Z",
+	}
+
+	s.users[DefaultUserID] = &userRecord{
+		User: api.User{
+			ID:        DefaultUserID,
+			FirstName: "Demo",
+			LastName:  "User",
+			Email:     DefaultEmail,
+			Status:    "Active",
+			Accounts:  []api.Account{account},
+		},
+		Password: DefaultPassword,
+	}
+	s.tokens[DefaultToken] = DefaultUserID
+
+	categories := []api.Category{
+		{ID: 1, Name: "Sales", Type: "income"},
+		{ID: 2, Name: "Consulting", Type: "income"},
+		{ID: 3, Name: "Software", Type: "expense"},
+		{ID: 4, Name: "Meals", Type: "expense"},
+		{ID: 5, Name: "Office Supplies", Type: "expense"},
+		{ID: 6, Name: "Rent", Type: "expense"},
+	}
+	for i := range categories {
+		categories[i].AccountID = account.ID
+		s.categories[categories[i].ID] = &categories[i]
+	}
+
+	labels := []api.Label{
+		{ID: 1, Name: "client-x"},
+		{ID: 2, Name: "travel"},
+		{ID: 3, Name: "tax-deductible"},
+	}
+	for i := range labels {
+		labels[i].AccountID = account.ID
+		s.labels[labels[i].ID] = &labels[i]
+	}
+
+	contacts := []api.Contact{
+		{ID: 1, Name: "Acme Corp", Email: "billing@acme.com", Phone: "555-1234", City: "Seattle", State: "WA"},
+		{ID: 2, Name: "Starbucks"},
+		{ID: 3, Name: "GitHub", Website: "https://github.com"},
+		{ID: 4, Name: "Main Street Properties", FirstName: "Pat", LastName: "Lee", Email: "pat@mainstreet.example"},
+	}
+	for i := range contacts {
+		contacts[i].AccountID = account.ID
+		s.contacts[contacts[i].ID] = &contacts[i]
+	}
+
+	ledgers := []struct {
+		ID         uint
+		Amount     float64
+		Date       string
+		ContactID  uint
+		CategoryID uint
+		LabelIDs   []uint
+		Note       string
+	}{
+		{1, 5000.00, "2026-01-15T00:00:00Z", 1, 2, []uint{1}, "January retainer"},
+		{2, -4.50, "2026-01-16T00:00:00Z", 2, 4, nil, "Coffee"},
+		{3, -21.00, "2026-01-20T00:00:00Z", 3, 3, []uint{3}, "GitHub Team"},
+		{4, -1800.00, "2026-02-01T00:00:00Z", 4, 6, nil, "February rent"},
+		{5, 5000.00, "2026-02-15T00:00:00Z", 1, 2, []uint{1}, "February retainer"},
+		{6, -86.40, "2026-02-18T00:00:00Z", 2, 4, []uint{1, 2}, "Client lunch"},
+		{7, -21.00, "2026-02-20T00:00:00Z", 3, 3, []uint{3}, "GitHub Team"},
+		{8, 1250.00, "2026-03-02T00:00:00Z", 1, 1, nil, "License sale"},
+	}
+	for _, l := range ledgers {
+		entry := &api.Ledger{
+			ID:        l.ID,
+			AccountID: account.ID,
+			AddedByID: DefaultUserID,
+			Amount:    l.Amount,
+			Date:      l.Date,
+			Contact:   *s.contacts[l.ContactID],
+			Category:  *s.categories[l.CategoryID],
+			Labels:    []api.Label{},
+			Files:     []api.File{},
+			Note:      l.Note,
+			CreatedAt: l.Date,
+			UpdatedAt: l.Date,
+		}
+		for _, lid := range l.LabelIDs {
+			entry.Labels = append(entry.Labels, *s.labels[lid])
+		}
+		s.ledgers[entry.ID] = entry
+	}
+}
@@ -0,0 +1,131 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package apitest
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+)
+
+// labelCount returns the number of ledger entries tagged with the label.
+func (s *Server) labelCount(id uint) int {
+	count := 0
+	for _, l := range s.ledgers {
+		for _, lb := range l.Labels {
+			if lb.ID == id {
+				count++
+				break
+			}
+		}
+	}
+
+	return count
+}
+
+// findLabel returns the label if it exists in the account.
+func (s *Server) findLabel(accountID uint, id uint) (*api.Label, bool) {
+	l, ok := s.labels[id]
+	if !ok || l.AccountID != accountID {
+		return nil, false
+	}
+
+	return l, true
+}
+
+// handleListLabels returns every label in the account sorted by name.
+func (s *Server) handleListLabels(w http.ResponseWriter, r *http.Request, userID uint, accountID uint) {
+	labels := []api.Label{}
+	for _, l := range s.labels {
+		if l.AccountID == accountID {
+			label := *l
+			label.Count = s.labelCount(l.ID)
+			labels = append(labels, label)
+		}
+	}
+	sort.Slice(labels, func(i, j int) bool { return labels[i].Name < labels[j].Name })
+
+	writeJSON(w, http.StatusOK, labels)
+}
+
+// handleGetLabel returns a single label.
+func (s *Server) handleGetLabel(w http.ResponseWriter, r *http.Request, userID uint, accountID uint) {
+	id, _ := pathID(r)
+	l, ok := s.findLabel(accountID, id)
+	if !ok {
+		writeError(w, http.StatusNotFound, "label not found")
+		return
+	}
+
+	label := *l
+	label.Count = s.labelCount(l.ID)
+	writeJSON(w, http.StatusOK, label)
+}
+
+// handleCreateLabel creates a new label.
+func (s *Server) handleCreateLabel(w http.ResponseWriter, r *http.Request, userID uint, accountID uint) {
+	var req api.LabelCreateRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if req.Name == "" {
+		writeError(w, http.StatusBadRequest, "name is required")
+		return
+	}
+
+	l := &api.Label{ID: s.newID(), AccountID: accountID, Name: req.Name}
+	s.labels[l.ID] = l
+	s.logActivity(api.Activity{AccountID: accountID, UserID: userID, LabelID: l.ID, Action: "create", SubAction: "label", Message: "created label " + l.Name})
+
+	writeJSON(w, http.StatusCreated, l)
+}
+
+// handleUpdateLabel renames a label.
+func (s *Server) handleUpdateLabel(w http.ResponseWriter, r *http.Request, userID uint, accountID uint) {
+	id, _ := pathID(r)
+	l, ok := s.findLabel(accountID, id)
+	if !ok {
+		writeError(w, http.StatusNotFound, "label not found")
+		return
+	}
+
+	var req api.LabelUpdateRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if req.Name != "" {
+		l.Name = req.Name
+	}
+	s.logActivity(api.Activity{AccountID: accountID, UserID: userID, LabelID: l.ID, Action: "update", SubAction: "label", Message: "updated label " + l.Name})
+
+	writeJSON(w, http.StatusOK, l)
+}
+
+// handleDeleteLabel deletes a label and removes it from every ledger entry.
+func (s *Server) handleDeleteLabel(w http.ResponseWriter, r *http.Request, userID uint, accountID uint) {
+	id, _ := pathID(r)
+	l, ok := s.findLabel(accountID, id)
+	if !ok {
+		writeError(w, http.StatusNotFound, "label not found")
+		return
+	}
+
+	for _, entry := range s.ledgers {
+		kept := []api.Label{}
+		for _, lb := range entry.Labels {
+			if lb.ID != id {
+				kept = append(kept, lb)
+			}
+		}
+		entry.Labels = kept
+	}
+
+	delete(s.labels, id)
+	s.logActivity(api.Activity{AccountID: accountID, UserID: userID, LabelID: id, Action: "delete", SubAction: "label", Message: "deleted label " + l.Name})
+
+	w.WriteHeader(http.StatusNoContent)
+}
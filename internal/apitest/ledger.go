@@ -0,0 +1,291 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package apitest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+)
+
+// findLedger returns the ledger entry if it exists in the account.
+func (s *Server) findLedger(accountID uint, id uint) (*api.Ledger, bool) {
+	l, ok := s.ledgers[id]
+	if !ok || l.AccountID != accountID {
+		return nil, false
+	}
+
+	return l, true
+}
+
+// accountLedgers returns the account's ledger entries sorted by date then ID.
+func (s *Server) accountLedgers(accountID uint) []api.Ledger {
+	list := []api.Ledger{}
+	for _, l := range s.ledgers {
+		if l.AccountID == accountID {
+			list = append(list, *l)
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Date != list[j].Date {
+			return list[i].Date < list[j].Date
+		}
+		return list[i].ID < list[j].ID
+	})
+
+	return list
+}
+
+// resolveLedgerRefs swaps the contact, category and labels sent by the client
+// for the stored records, creating the contact by name when it has no ID.
+func (s *Server) resolveLedgerRefs(accountID uint, entry *api.Ledger) error {
+	if entry.Contact.ID == 0 && entry.Contact.Name != "" {
+		c := &api.Contact{ID: s.newID(), AccountID: accountID, Name: entry.Contact.Name}
+		s.contacts[c.ID] = c
+		entry.Contact = *c
+	} else {
+		c, ok := s.findContact(accountID, entry.Contact.ID)
+		if !ok {
+			return fmt.Errorf("contact %d not found", entry.Contact.ID)
+		}
+		entry.Contact = *c
+	}
+
+	cat, ok := s.findCategory(accountID, entry.Category.ID)
+	if !ok {
+		return fmt.Errorf("category %d not found", entry.Category.ID)
+	}
+	entry.Category = *cat
+
+	labels := []api.Label{}
+	for _, lb := range entry.Labels {
+		label, ok := s.findLabel(accountID, lb.ID)
+		if !ok {
+			return fmt.Errorf("label %d not found", lb.ID)
+		}
+		labels = append(labels, *label)
+	}
+	entry.Labels = labels
+
+	if _, err := time.Parse(time.RFC3339, entry.Date); err != nil {
+		return fmt.Errorf("invalid date %q", entry.Date)
+	}
+
+	return nil
+}
+
+// handleListLedgers returns a page of ledger entries ordered by date.
+func (s *Server) handleListLedgers(w http.ResponseWriter, r *http.Request, userID uint, accountID uint) {
+	list := s.accountLedgers(accountID)
+
+	if r.URL.Query().Get("sort") != "ASC" {
+		for i, j := 0, len(list)-1; i < j; i, j = i+1, j-1 {
+			list[i], list[j] = list[j], list[i]
+		}
+	}
+
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = 25
+	}
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil || page <= 0 {
+		page = 1
+	}
+
+	start := (page - 1) * limit
+	if start > len(list) {
+		start = len(list)
+	}
+	end := start + limit
+	if end > len(list) {
+		end = len(list)
+	}
+
+	writeJSON(w, http.StatusOK, list[start:end])
+}
+
+// handleGetLedger returns a single ledger entry.
+func (s *Server) handleGetLedger(w http.ResponseWriter, r *http.Request, userID uint, accountID uint) {
+	id, _ := pathID(r)
+	l, ok := s.findLedger(accountID, id)
+	if !ok {
+		writeError(w, http.StatusNotFound, "ledger entry not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, l)
+}
+
+// handleCreateLedger creates a ledger entry.
+func (s *Server) handleCreateLedger(w http.ResponseWriter, r *http.Request, userID uint, accountID uint) {
+	var req api.LedgerCreateRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if req.Amount == 0 {
+		writeError(w, http.StatusBadRequest, "amount is required")
+		return
+	}
+
+	entry := &api.Ledger{
+		AccountID: accountID,
+		AddedByID: userID,
+		Amount:    req.Amount,
+		Date:      req.Date,
+		Contact:   req.Contact,
+		Category:  req.Category,
+		Labels:    req.Labels,
+		Files:     []api.File{},
+		Note:      req.Note,
+	}
+	if err := s.resolveLedgerRefs(accountID, entry); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entry.ID = s.newID()
+	entry.CreatedAt = now()
+	entry.UpdatedAt = entry.CreatedAt
+	s.ledgers[entry.ID] = entry
+	s.logActivity(api.Activity{AccountID: accountID, UserID: userID, LedgerID: entry.ID, Action: "create", SubAction: "ledger", Amount: entry.Amount, Message: "created ledger entry for " + entry.Contact.Name})
+
+	writeJSON(w, http.StatusCreated, entry)
+}
+
+// handleUpdateLedger applies every field present in the JSON body, so keys
+// the client omits are left untouched and explicit zero values are stored.
+func (s *Server) handleUpdateLedger(w http.ResponseWriter, r *http.Request, userID uint, accountID uint) {
+	id, _ := pathID(r)
+	l, ok := s.findLedger(accountID, id)
+	if !ok {
+		writeError(w, http.StatusNotFound, "ledger entry not found")
+		return
+	}
+
+	var fields map[string]json.RawMessage
+	if !decodeJSON(w, r, &fields) {
+		return
+	}
+
+	updated := *l
+	targets := map[string]interface{}{
+		"amount":   &updated.Amount,
+		"date":     &updated.Date,
+		"contact":  &updated.Contact,
+		"category": &updated.Category,
+		"labels":   &updated.Labels,
+		"note":     &updated.Note,
+	}
+	for key, raw := range fields {
+		target, ok := targets[key]
+		if !ok {
+			continue
+		}
+		if err := json.Unmarshal(raw, target); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid value for "+key)
+			return
+		}
+	}
+
+	// An empty contact or category object means "unchanged", matching the API.
+	if updated.Contact.ID == 0 && updated.Contact.Name == "" {
+		updated.Contact = l.Contact
+	}
+	if updated.Category.ID == 0 {
+		updated.Category = l.Category
+	}
+
+	if err := s.resolveLedgerRefs(accountID, &updated); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	updated.UpdatedAt = now()
+	*l = updated
+	s.logActivity(api.Activity{AccountID: accountID, UserID: userID, LedgerID: l.ID, Action: "update", SubAction: "ledger", Amount: l.Amount, Message: "updated ledger entry for " + l.Contact.Name})
+
+	writeJSON(w, http.StatusOK, l)
+}
+
+// handleDeleteLedger deletes a ledger entry along with its attached files.
+func (s *Server) handleDeleteLedger(w http.ResponseWriter, r *http.Request, userID uint, accountID uint) {
+	id, _ := pathID(r)
+	l, ok := s.findLedger(accountID, id)
+	if !ok {
+		writeError(w, http.StatusNotFound, "ledger entry not found")
+		return
+	}
+
+	for fid, f := range s.files {
+		if f.LedgerID == id {
+			delete(s.files, fid)
+		}
+	}
+
+	delete(s.ledgers, id)
+	s.logActivity(api.Activity{AccountID: accountID, UserID: userID, LedgerID: id, Action: "delete", SubAction: "ledger", Amount: l.Amount, Message: "deleted ledger entry for " + l.Contact.Name})
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleLedgerSummary returns entry counts grouped by year, category and label.
+func (s *Server) handleLedgerSummary(w http.ResponseWriter, r *http.Request, userID uint, accountID uint) {
+	years := map[int]int{}
+	categories := map[uint]*api.LedgerSummaryItem{}
+	labels := map[uint]*api.LedgerSummaryItem{}
+
+	for _, l := range s.accountLedgers(accountID) {
+		if t, err := time.Parse(time.RFC3339, l.Date); err == nil {
+			years[t.Year()]++
+		}
+
+		if _, ok := categories[l.Category.ID]; !ok {
+			categories[l.Category.ID] = &api.LedgerSummaryItem{ID: l.Category.ID, Name: l.Category.Name}
+		}
+		categories[l.Category.ID].Count++
+
+		for _, lb := range l.Labels {
+			if _, ok := labels[lb.ID]; !ok {
+				labels[lb.ID] = &api.LedgerSummaryItem{ID: lb.ID, Name: lb.Name}
+			}
+			labels[lb.ID].Count++
+		}
+	}
+
+	summary := api.LedgerSummary{
+		Years:      []api.LedgerSummaryYear{},
+		Categories: summaryItems(categories),
+		Labels:     summaryItems(labels),
+	}
+	for year, count := range years {
+		summary.Years = append(summary.Years, api.LedgerSummaryYear{Year: year, Count: count})
+	}
+	sort.Slice(summary.Years, func(i, j int) bool { return summary.Years[i].Year > summary.Years[j].Year })
+
+	writeJSON(w, http.StatusOK, summary)
+}
+
+// handleLedgerPL returns the profit and loss totals across every entry.
+func (s *Server) handleLedgerPL(w http.ResponseWriter, r *http.Request, userID uint, accountID uint) {
+	writeJSON(w, http.StatusOK, pnl(s.accountLedgers(accountID), nil))
+}
+
+// summaryItems flattens a summary map into a slice sorted by name.
+func summaryItems(m map[uint]*api.LedgerSummaryItem) []api.LedgerSummaryItem {
+	items := []api.LedgerSummaryItem{}
+	for _, item := range m {
+		items = append(items, *item)
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Name < items[j].Name })
+
+	return items
+}
@@ -0,0 +1,138 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package apitest
+
+import (
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+)
+
+// breakdownKey returns the breakdown names a ledger entry contributes to.
+type breakdownKey func(l api.Ledger) []string
+
+// pnl totals income and expense for the given entries, optionally grouping
+// the signed amounts into a breakdown sorted by name.
+func pnl(ledgers []api.Ledger, key breakdownKey) api.PnlReport {
+	var report api.PnlReport
+	groups := map[string]float64{}
+
+	for _, l := range ledgers {
+		if l.Amount >= 0 {
+			report.Income += l.Amount
+		} else {
+			report.Expense += -l.Amount
+		}
+
+		if key != nil {
+			for _, name := range key(l) {
+				groups[name] += l.Amount
+			}
+		}
+	}
+
+	report.Income = round(report.Income)
+	report.Expense = round(report.Expense)
+	report.Profit = round(report.Income - report.Expense)
+
+	for name, amount := range groups {
+		report.Breakdown = append(report.Breakdown, api.PnlBreakdown{Name: name, Amount: round(amount)})
+	}
+	sort.Slice(report.Breakdown, func(i, j int) bool { return report.Breakdown[i].Name < report.Breakdown[j].Name })
+
+	return report
+}
+
+// round rounds an amount to cents to avoid float noise in totals.
+func round(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// ledgersInRange returns the account entries between the ?start and ?end
+// query parameters (inclusive, YYYY-MM-DD), keeping only those matching keep.
+func (s *Server) ledgersInRange(r *http.Request, accountID uint, keep func(l api.Ledger) bool) []api.Ledger {
+	start := r.URL.Query().Get("start")
+	end := r.URL.Query().Get("end")
+
+	list := []api.Ledger{}
+	for _, l := range s.accountLedgers(accountID) {
+		day := l.Date
+		if len(day) > 10 {
+			day = day[:10]
+		}
+		if start != "" && day < start {
+			continue
+		}
+		if end != "" && day > end {
+			continue
+		}
+		if keep != nil && !keep(l) {
+			continue
+		}
+		list = append(list, l)
+	}
+
+	return list
+}
+
+// byCategory groups entries by category name.
+func byCategory(l api.Ledger) []string {
+	return []string{l.Category.Name}
+}
+
+// byLabel groups entries by each of their label names.
+func byLabel(l api.Ledger) []string {
+	names := []string{}
+	for _, lb := range l.Labels {
+		names = append(names, lb.Name)
+	}
+
+	return names
+}
+
+// byContact groups entries by contact name.
+func byContact(l api.Ledger) []string {
+	return []string{l.Contact.Name}
+}
+
+// handlePnl returns the P&L totals for the requested range.
+func (s *Server) handlePnl(w http.ResponseWriter, r *http.Request, userID uint, accountID uint) {
+	writeJSON(w, http.StatusOK, pnl(s.ledgersInRange(r, accountID, nil), nil))
+}
+
+// handlePnlCurrent returns the P&L totals for the current calendar year.
+func (s *Server) handlePnlCurrent(w http.ResponseWriter, r *http.Request, userID uint, accountID uint) {
+	year := strconv.Itoa(time.Now().Year())
+	current := func(l api.Ledger) bool { return len(l.Date) >= 4 && l.Date[:4] == year }
+
+	writeJSON(w, http.StatusOK, pnl(s.ledgersInRange(r, accountID, current), nil))
+}
+
+// handlePnlByCategory returns the P&L broken down by category.
+func (s *Server) handlePnlByCategory(w http.ResponseWriter, r *http.Request, userID uint, accountID uint) {
+	writeJSON(w, http.StatusOK, pnl(s.ledgersInRange(r, accountID, nil), byCategory))
+}
+
+// handlePnlByLabel returns the P&L broken down by label.
+func (s *Server) handlePnlByLabel(w http.ResponseWriter, r *http.Request, userID uint, accountID uint) {
+	writeJSON(w, http.StatusOK, pnl(s.ledgersInRange(r, accountID, nil), byLabel))
+}
+
+// handleIncomeByContact returns income totals broken down by contact.
+func (s *Server) handleIncomeByContact(w http.ResponseWriter, r *http.Request, userID uint, accountID uint) {
+	income := func(l api.Ledger) bool { return l.Amount > 0 }
+
+	writeJSON(w, http.StatusOK, pnl(s.ledgersInRange(r, accountID, income), byContact))
+}
+
+// handleExpensesByContact returns expense totals broken down by contact.
+func (s *Server) handleExpensesByContact(w http.ResponseWriter, r *http.Request, userID uint, accountID uint) {
+	expense := func(l api.Ledger) bool { return l.Amount < 0 }
+
+	writeJSON(w, http.StatusOK, pnl(s.ledgersInRange(r, accountID, expense), byContact))
+}
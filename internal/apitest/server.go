@@ -0,0 +1,331 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+// Package apitest implements an in-memory, stateful fake of the Skyclerk API.
+// It is used by tests to exercise realistic flows and by the hidden
+// `skyclerk dev-server` command to run the CLI fully offline.
+package apitest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+)
+
+// DefaultToken is the access token accepted by a freshly seeded server.
+const DefaultToken = "dev-token"
+
+// DefaultEmail is the email of the seeded user.
+const DefaultEmail = "demo@skyclerk.com"
+
+// DefaultPassword is the password of the seeded user.
+const DefaultPassword = "demo"
+
+// DefaultClientID is the OAuth client ID accepted by the fake token endpoint.
+const DefaultClientID = "dev-client"
+
+// DefaultAccountID is the ID of the seeded account.
+const DefaultAccountID uint = 1
+
+// DefaultUserID is the ID of the seeded user.
+const DefaultUserID uint = 1
+
+// userRecord pairs a user with the password used by the token endpoint.
+type userRecord struct {
+	User     api.User
+	Password string
+}
+
+// storedFile holds an uploaded file's metadata along with its contents.
+type storedFile struct {
+	File     api.File
+	LedgerID uint
+	Data     []byte
+}
+
+// Server is an in-memory fake of the Skyclerk API. All state lives in maps
+// guarded by a single mutex, so it is safe for concurrent requests.
+type Server struct {
+	mu     sync.Mutex
+	mux    *http.ServeMux
+	nextID uint
+
+	tokens     map[string]uint
+	users      map[uint]*userRecord
+	accounts   map[uint]*api.Account
+	billing    map[uint]*api.Billing
+	ledgers    map[uint]*api.Ledger
+	categories map[uint]*api.Category
+	labels     map[uint]*api.Label
+	contacts   map[uint]*api.Contact
+	files      map[uint]*storedFile
+	invites    map[uint]*api.Invite
+	activities []api.Activity
+}
+
+// New creates a fake server seeded with the default fixtures.
+func New() *Server {
+	s := &Server{
+		mux:        http.NewServeMux(),
+		nextID:     1000,
+		tokens:     map[string]uint{},
+		users:      map[uint]*userRecord{},
+		accounts:   map[uint]*api.Account{},
+		billing:    map[uint]*api.Billing{},
+		ledgers:    map[uint]*api.Ledger{},
+		categories: map[uint]*api.Category{},
+		labels:     map[uint]*api.Label{},
+		contacts:   map[uint]*api.Contact{},
+		files:      map[uint]*storedFile{},
+		invites:    map[uint]*api.Invite{},
+	}
+
+	s.seed()
+	s.routes()
+
+	return s
+}
+
+// NewTestServer starts the fake on a local httptest server and returns both.
+// Callers are responsible for closing the returned httptest server.
+func NewTestServer() (*Server, *httptest.Server) {
+	s := New()
+	return s, httptest.NewServer(s)
+}
+
+// ServeHTTP dispatches a request to the registered fake endpoints.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// Ledgers returns a snapshot of every ledger entry sorted by ID (useful for assertions).
+func (s *Server) Ledgers() []api.Ledger {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list := make([]api.Ledger, 0, len(s.ledgers))
+	for _, l := range s.ledgers {
+		list = append(list, *l)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+
+	return list
+}
+
+// routes registers every fake endpoint on the server mux.
+func (s *Server) routes() {
+	s.mux.HandleFunc("POST /oauth/token", s.locked(s.handleToken))
+	s.mux.HandleFunc("GET /oauth/logout", s.locked(s.handleLogout))
+	s.mux.HandleFunc("GET /oauth/me", s.authed(s.handleAuthUser))
+	s.mux.HandleFunc("GET /fake-files/{id}/{name}", s.locked(s.handleFileContent))
+
+	s.account("GET /account", s.handleGetAccount)
+	s.account("PUT /account", s.handleUpdateAccount)
+	s.account("GET /account/billing", s.handleGetBilling)
+
+	s.account("GET /ledger", s.handleListLedgers)
+	s.account("POST /ledger", s.handleCreateLedger)
+	s.account("GET /ledger/{id}", s.handleGetLedger)
+	s.account("PUT /ledger/{id}", s.handleUpdateLedger)
+	s.account("DELETE /ledger/{id}", s.handleDeleteLedger)
+	s.account("GET /ledger-summary", s.handleLedgerSummary)
+	s.account("GET /ledger-pl-summary", s.handleLedgerPL)
+
+	s.account("GET /categories", s.handleListCategories)
+	s.account("POST /categories", s.handleCreateCategory)
+	s.account("GET /categories/{id}", s.handleGetCategory)
+	s.account("PUT /categories/{id}", s.handleUpdateCategory)
+	s.account("DELETE /categories/{id}", s.handleDeleteCategory)
+
+	s.account("GET /labels", s.handleListLabels)
+	s.account("POST /labels", s.handleCreateLabel)
+	s.account("GET /labels/{id}", s.handleGetLabel)
+	s.account("PUT /labels/{id}", s.handleUpdateLabel)
+	s.account("DELETE /labels/{id}", s.handleDeleteLabel)
+
+	s.account("GET /contacts", s.handleListContacts)
+	s.account("POST /contacts", s.handleCreateContact)
+	s.account("GET /contacts/{id}", s.handleGetContact)
+	s.account("PUT /contacts/{id}", s.handleUpdateContact)
+	s.account("DELETE /contacts/{id}", s.handleDeleteContact)
+
+	s.account("GET /files", s.handleListFiles)
+	s.account("POST /files", s.handleUploadFile)
+	s.account("GET /files/{id}", s.handleGetFile)
+	s.account("DELETE /files/{id}", s.handleDeleteFile)
+
+	s.account("GET /reports/pnl", s.handlePnl)
+	s.account("GET /reports/pnl/current", s.handlePnlCurrent)
+	s.account("GET /reports/pnl/category", s.handlePnlByCategory)
+	s.account("GET /reports/pnl/label", s.handlePnlByLabel)
+	s.account("GET /reports/income/by-contact", s.handleIncomeByContact)
+	s.account("GET /reports/expenses/by-contact", s.handleExpensesByContact)
+
+	s.account("GET /me", s.handleGetMe)
+	s.account("PUT /me", s.handleUpdateMe)
+	s.account("POST /me/change-password", s.handleChangePassword)
+
+	s.account("GET /users", s.handleListUsers)
+	s.account("DELETE /users/{id}", s.handleRemoveUser)
+	s.account("GET /users/invite", s.handleListInvites)
+	s.account("POST /users/invite", s.handleCreateInvite)
+	s.account("DELETE /user-invite/{id}", s.handleCancelInvite)
+
+	s.account("GET /activities", s.handleListActivities)
+}
+
+// accountHandler is a handler scoped to an authenticated user and a valid account.
+type accountHandler func(w http.ResponseWriter, r *http.Request, userID uint, accountID uint)
+
+// account registers an account-scoped handler under /api/v3/{account}.
+func (s *Server) account(pattern string, h accountHandler) {
+	method, path, _ := strings.Cut(pattern, " ")
+
+	s.mux.HandleFunc(method+" /api/v3/{account}"+path, s.authed(func(w http.ResponseWriter, r *http.Request, userID uint) {
+		accountID, err := strconv.ParseUint(r.PathValue("account"), 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid account id")
+			return
+		}
+
+		if _, ok := s.accounts[uint(accountID)]; !ok || !s.userInAccount(userID, uint(accountID)) {
+			writeError(w, http.StatusForbidden, "you do not have access to this account")
+			return
+		}
+
+		if r.Method != http.MethodGet {
+			h(w, r, userID, uint(accountID))
+			return
+		}
+
+		rec := httptest.NewRecorder()
+		h(rec, r, userID, uint(accountID))
+		writeWithETag(w, r, rec)
+	}))
+}
+
+// writeWithETag copies a recorded GET response, adding an ETag and answering
+// 304 Not Modified when it matches the request's If-None-Match header.
+func writeWithETag(w http.ResponseWriter, r *http.Request, rec *httptest.ResponseRecorder) {
+	for k, v := range rec.Header() {
+		w.Header()[k] = v
+	}
+
+	if rec.Code != http.StatusOK {
+		w.WriteHeader(rec.Code)
+		w.Write(rec.Body.Bytes())
+		return
+	}
+
+	sum := sha256.Sum256(rec.Body.Bytes())
+	etag := `"` + hex.EncodeToString(sum[:8]) + `"`
+	w.Header().Set("ETag", etag)
+
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(rec.Body.Bytes())
+}
+
+// authed wraps a handler so it only runs with a valid bearer token, holding the server lock.
+func (s *Server) authed(h func(w http.ResponseWriter, r *http.Request, userID uint)) http.HandlerFunc {
+	return s.locked(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		userID, ok := s.tokens[token]
+		if token == "" || !ok {
+			writeError(w, http.StatusUnauthorized, "invalid or missing access token")
+			return
+		}
+
+		h(w, r, userID)
+	})
+}
+
+// locked wraps a handler so it runs while holding the server lock.
+func (s *Server) locked(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		h(w, r)
+	}
+}
+
+// userInAccount reports whether the user belongs to the given account.
+func (s *Server) userInAccount(userID uint, accountID uint) bool {
+	rec, ok := s.users[userID]
+	if !ok {
+		return false
+	}
+
+	for _, a := range rec.User.Accounts {
+		if a.ID == accountID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// newID returns the next unused record ID.
+func (s *Server) newID() uint {
+	s.nextID++
+	return s.nextID
+}
+
+// logActivity appends an activity entry like the real API does on mutations.
+func (s *Server) logActivity(a api.Activity) {
+	a.ID = s.newID()
+	a.CreatedAt = now()
+	s.activities = append(s.activities, a)
+}
+
+// now returns the current UTC time formatted the way the API returns timestamps.
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
+
+// pathID parses the {id} wildcard from the request path.
+func pathID(r *http.Request) (uint, bool) {
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return uint(id), true
+}
+
+// decodeJSON decodes the request body into v, writing a 400 on failure.
+func decodeJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid JSON body: %v", err))
+		return false
+	}
+
+	return true
+}
+
+// writeJSON writes v as a JSON response with the given status code.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an API-style JSON error body.
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
@@ -0,0 +1,464 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package apitest
+
+import (
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+)
+
+// newTestClient starts a seeded fake and returns a client authenticated against it.
+func newTestClient(t *testing.T) (*Server, *api.Client) {
+	t.Helper()
+
+	s, ts := NewTestServer()
+	t.Cleanup(ts.Close)
+
+	return s, api.NewClient(ts.URL, DefaultToken, DefaultAccountID)
+}
+
+// TestLoginFlow verifies logging in, fetching the user, and logging out.
+func TestLoginFlow(t *testing.T) {
+	_, ts := NewTestServer()
+	defer ts.Close()
+
+	client := api.NewClient(ts.URL, "", 0)
+	resp, err := client.Login(DefaultEmail, DefaultPassword, DefaultClientID)
+	if err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+
+	client = api.NewClient(ts.URL, resp.AccessToken, 0)
+	user, err := client.GetAuthUser()
+	if err != nil {
+		t.Fatalf("GetAuthUser() error = %v", err)
+	}
+
+	if len(user.Accounts) != 1 || user.Accounts[0].ID != DefaultAccountID {
+		t.Errorf("Accounts = %+v, want the default account", user.Accounts)
+	}
+
+	if err := client.Logout(); err != nil {
+		t.Fatalf("Logout() error = %v", err)
+	}
+
+	if _, err := client.GetAuthUser(); err == nil {
+		t.Error("GetAuthUser() after logout expected error, got nil")
+	}
+}
+
+// TestLoginBadPassword verifies the token endpoint rejects bad credentials.
+func TestLoginBadPassword(t *testing.T) {
+	_, ts := NewTestServer()
+	defer ts.Close()
+
+	client := api.NewClient(ts.URL, "", 0)
+	if _, err := client.Login(DefaultEmail, "wrong", DefaultClientID); err == nil {
+		t.Fatal("Login() expected error, got nil")
+	}
+}
+
+// TestRejectsUnknownToken verifies account endpoints require a valid token.
+func TestRejectsUnknownToken(t *testing.T) {
+	_, ts := NewTestServer()
+	defer ts.Close()
+
+	client := api.NewClient(ts.URL, "bogus", DefaultAccountID)
+	_, err := client.GetLedgers(nil)
+	if err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("GetLedgers() error = %v, want 401", err)
+	}
+}
+
+// TestRejectsForeignAccount verifies users can not read accounts they don't belong to.
+func TestRejectsForeignAccount(t *testing.T) {
+	_, ts := NewTestServer()
+	defer ts.Close()
+
+	client := api.NewClient(ts.URL, DefaultToken, 999)
+	_, err := client.GetAccount()
+	if err == nil || !strings.Contains(err.Error(), "403") {
+		t.Errorf("GetAccount() error = %v, want 403", err)
+	}
+}
+
+// TestLedgerLifecycle verifies create, get, update, list and delete share state.
+func TestLedgerLifecycle(t *testing.T) {
+	s, client := newTestClient(t)
+	before := len(s.Ledgers())
+
+	created, err := client.CreateLedger(&api.LedgerCreateRequest{
+		Amount:   -12.34,
+		Date:     "2026-03-10T00:00:00Z",
+		Contact:  api.Contact{ID: 2},
+		Category: api.Category{ID: 4, Type: "1"},
+		Labels:   []api.Label{{ID: 1}},
+		Note:     "Team coffee",
+	})
+	if err != nil {
+		t.Fatalf("CreateLedger() error = %v", err)
+	}
+
+	if created.Contact.Name != "Starbucks" || created.Category.Name != "Meals" {
+		t.Errorf("created refs = %q/%q, want Starbucks/Meals", created.Contact.Name, created.Category.Name)
+	}
+
+	got, err := client.GetLedger(created.ID)
+	if err != nil {
+		t.Fatalf("GetLedger() error = %v", err)
+	}
+	if got.Note != "Team coffee" || len(got.Labels) != 1 {
+		t.Errorf("GetLedger() = %+v, want note and one label", got)
+	}
+
+	updated, err := client.UpdateLedger(created.ID, &api.LedgerUpdateRequest{Note: api.Ptr("Client coffee")})
+	if err != nil {
+		t.Fatalf("UpdateLedger() error = %v", err)
+	}
+	if updated.Note != "Client coffee" || updated.Amount != -12.34 {
+		t.Errorf("UpdateLedger() = %+v, want new note and unchanged amount", updated)
+	}
+
+	cleared, err := client.UpdateLedger(created.ID, &api.LedgerUpdateRequest{Amount: api.Ptr(0.0), Note: api.Ptr(""), Labels: &[]api.Label{}})
+	if err != nil {
+		t.Fatalf("UpdateLedger() error = %v", err)
+	}
+	if cleared.Note != "" || cleared.Amount != 0 || len(cleared.Labels) != 0 || cleared.Contact.Name != "Starbucks" {
+		t.Errorf("UpdateLedger() = %+v, want note, amount and labels cleared", cleared)
+	}
+
+	ledgers, err := client.GetLedgers(map[string]string{"limit": "1", "sort": "DESC"})
+	if err != nil {
+		t.Fatalf("GetLedgers() error = %v", err)
+	}
+	if len(ledgers) != 1 || ledgers[0].ID != created.ID {
+		t.Errorf("GetLedgers() = %+v, want newest entry first", ledgers)
+	}
+
+	if err := client.DeleteLedger(created.ID); err != nil {
+		t.Fatalf("DeleteLedger() error = %v", err)
+	}
+	if len(s.Ledgers()) != before {
+		t.Errorf("ledger count = %d, want %d", len(s.Ledgers()), before)
+	}
+	if _, err := client.GetLedger(created.ID); err == nil {
+		t.Error("GetLedger() after delete expected error, got nil")
+	}
+}
+
+// TestLedgerPagination verifies pages do not overlap.
+func TestLedgerPagination(t *testing.T) {
+	_, client := newTestClient(t)
+
+	page1, err := client.GetLedgers(map[string]string{"limit": "5", "page": "1"})
+	if err != nil {
+		t.Fatalf("GetLedgers() error = %v", err)
+	}
+	page2, err := client.GetLedgers(map[string]string{"limit": "5", "page": "2"})
+	if err != nil {
+		t.Fatalf("GetLedgers() error = %v", err)
+	}
+
+	if len(page1) != 5 || len(page2) != 3 {
+		t.Fatalf("page sizes = %d/%d, want 5/3", len(page1), len(page2))
+	}
+	if page1[4].ID == page2[0].ID {
+		t.Error("pages overlap")
+	}
+}
+
+// TestCategoryLifecycle verifies category CRUD and type normalization.
+func TestCategoryLifecycle(t *testing.T) {
+	_, client := newTestClient(t)
+
+	created, err := client.CreateCategory(&api.CategoryCreateRequest{Name: "Travel", Type: "1"})
+	if err != nil {
+		t.Fatalf("CreateCategory() error = %v", err)
+	}
+	if created.Type != "expense" {
+		t.Errorf("Type = %q, want expense", created.Type)
+	}
+
+	if _, err := client.UpdateCategory(created.ID, &api.CategoryUpdateRequest{Name: "Air Travel"}); err != nil {
+		t.Fatalf("UpdateCategory() error = %v", err)
+	}
+
+	got, err := client.GetCategory(created.ID)
+	if err != nil {
+		t.Fatalf("GetCategory() error = %v", err)
+	}
+	if got.Name != "Air Travel" || got.Type != "expense" {
+		t.Errorf("GetCategory() = %+v, want renamed expense category", got)
+	}
+
+	if err := client.DeleteCategory(created.ID); err != nil {
+		t.Fatalf("DeleteCategory() error = %v", err)
+	}
+
+	// Categories in use can not be deleted.
+	if err := client.DeleteCategory(3); err == nil {
+		t.Error("DeleteCategory() on used category expected error, got nil")
+	}
+}
+
+// TestLabelCounts verifies label counts reflect ledger usage.
+func TestLabelCounts(t *testing.T) {
+	_, client := newTestClient(t)
+
+	labels, err := client.GetLabels(nil)
+	if err != nil {
+		t.Fatalf("GetLabels() error = %v", err)
+	}
+
+	counts := map[string]int{}
+	for _, l := range labels {
+		counts[l.Name] = l.Count
+	}
+	if counts["client-x"] != 3 {
+		t.Errorf("client-x count = %d, want 3", counts["client-x"])
+	}
+}
+
+// TestContactSearchAndUpdate verifies searching and clearing contact fields.
+func TestContactSearchAndUpdate(t *testing.T) {
+	_, client := newTestClient(t)
+
+	contacts, err := client.GetContacts(map[string]string{"search": "acme"})
+	if err != nil {
+		t.Fatalf("GetContacts() error = %v", err)
+	}
+	if len(contacts) != 1 || contacts[0].Name != "Acme Corp" {
+		t.Fatalf("GetContacts() = %+v, want Acme Corp", contacts)
+	}
+
+	updated, err := client.UpdateContact(contacts[0].ID, &api.ContactUpdateRequest{Email: api.Ptr("ap@acme.com")})
+	if err != nil {
+		t.Fatalf("UpdateContact() error = %v", err)
+	}
+	if updated.Email != "ap@acme.com" || updated.Phone != "555-1234" {
+		t.Errorf("UpdateContact() = %+v, want new email and unchanged phone", updated)
+	}
+}
+
+// TestUploadAndDownloadFile verifies an upload is attached and its URL serves the content.
+func TestUploadAndDownloadFile(t *testing.T) {
+	_, client := newTestClient(t)
+
+	path := filepath.Join(t.TempDir(), "receipt.pdf")
+	os.WriteFile(path, []byte("%PDF-1.4 fake"), 0644)
+
+	file, err := client.UploadFile(path, "2")
+	if err != nil {
+		t.Fatalf("UploadFile() error = %v", err)
+	}
+	if file.Type != "application/pdf" || file.Size != 13 {
+		t.Errorf("UploadFile() = %+v, want pdf of 13 bytes", file)
+	}
+
+	ledger, err := client.GetLedger(2)
+	if err != nil {
+		t.Fatalf("GetLedger() error = %v", err)
+	}
+	if len(ledger.Files) != 1 {
+		t.Fatalf("Files = %d, want 1", len(ledger.Files))
+	}
+
+	resp, err := http.Get(ledger.Files[0].URL)
+	if err != nil {
+		t.Fatalf("GET file URL error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != "%PDF-1.4 fake" {
+		t.Errorf("downloaded body = %q", string(body))
+	}
+}
+
+// TestFileListDownloadDelete verifies files can be listed, downloaded with
+// checksum verification and deleted, which detaches them from their ledger entry.
+func TestFileListDownloadDelete(t *testing.T) {
+	_, client := newTestClient(t)
+
+	path := filepath.Join(t.TempDir(), "lunch.jpg")
+	os.WriteFile(path, []byte("jpeg bytes"), 0644)
+
+	uploaded, err := client.UploadFile(path, "2")
+	if err != nil {
+		t.Fatalf("UploadFile() error = %v", err)
+	}
+
+	files, err := client.ListFiles(nil)
+	if err != nil || len(files) != 1 || files[0].ID != uploaded.ID {
+		t.Fatalf("ListFiles() = %+v, %v", files, err)
+	}
+
+	var buf strings.Builder
+	dl, err := client.DownloadFile(&files[0], false, &buf)
+	if err != nil {
+		t.Fatalf("DownloadFile() error = %v", err)
+	}
+	if buf.String() != "jpeg bytes" || dl.Bytes != 10 {
+		t.Errorf("DownloadFile() = %+v, body %q", dl, buf.String())
+	}
+
+	if err := client.DeleteFile(uploaded.ID); err != nil {
+		t.Fatalf("DeleteFile() error = %v", err)
+	}
+	if _, err := client.GetFile(uploaded.ID); err == nil {
+		t.Error("GetFile() after delete should fail")
+	}
+
+	ledger, _ := client.GetLedger(2)
+	if len(ledger.Files) != 0 {
+		t.Errorf("ledger files = %d, want 0 after delete", len(ledger.Files))
+	}
+}
+
+// TestReports verifies P&L totals and breakdowns are computed from ledger state.
+func TestReports(t *testing.T) {
+	_, client := newTestClient(t)
+
+	report, err := client.GetPnlReport(map[string]string{"start": "2026-01-01", "end": "2026-01-31"})
+	if err != nil {
+		t.Fatalf("GetPnlReport() error = %v", err)
+	}
+	if report.Income != 5000 || report.Expense != 25.5 || report.Profit != 4974.5 {
+		t.Errorf("GetPnlReport() = %+v, want 5000/25.5/4974.5", report)
+	}
+
+	byContact, err := client.GetExpensesByContact(nil)
+	if err != nil {
+		t.Fatalf("GetExpensesByContact() error = %v", err)
+	}
+	for _, b := range byContact.Breakdown {
+		if b.Name == "Acme Corp" {
+			t.Error("expenses by contact should not include income-only contacts")
+		}
+	}
+}
+
+// TestReportRangesAgree verifies a range covering every entry reports the same
+// totals and breakdowns as no range, and that adjacent day ranges add up to it.
+func TestReportRangesAgree(t *testing.T) {
+	_, client := newTestClient(t)
+
+	all := map[string]string{"start": "2000-01-01", "end": "2100-12-31"}
+	reports := map[string]func(map[string]string) (*api.PnlReport, error){
+		"GetPnlReport":         client.GetPnlReport,
+		"GetPnlByCategory":     client.GetPnlByCategory,
+		"GetPnlByLabel":        client.GetPnlByLabel,
+		"GetIncomeByContact":   client.GetIncomeByContact,
+		"GetExpensesByContact": client.GetExpensesByContact,
+	}
+	for name, fetch := range reports {
+		unranged, err := fetch(nil)
+		if err != nil {
+			t.Fatalf("%s(nil) error = %v", name, err)
+		}
+		ranged, err := fetch(all)
+		if err != nil {
+			t.Fatalf("%s(range) error = %v", name, err)
+		}
+		if !reflect.DeepEqual(ranged, unranged) {
+			t.Errorf("%s(range) = %+v, want %+v", name, ranged, unranged)
+		}
+	}
+
+	total, err := client.GetPnlReport(nil)
+	if err != nil {
+		t.Fatalf("GetPnlReport() error = %v", err)
+	}
+	january, err := client.GetPnlReport(map[string]string{"start": "2000-01-01", "end": "2026-01-31"})
+	if err != nil {
+		t.Fatalf("GetPnlReport() error = %v", err)
+	}
+	rest, err := client.GetPnlReport(map[string]string{"start": "2026-02-01", "end": "2100-12-31"})
+	if err != nil {
+		t.Fatalf("GetPnlReport() error = %v", err)
+	}
+	if math.Abs(january.Income+rest.Income-total.Income) > 0.001 || math.Abs(january.Expense+rest.Expense-total.Expense) > 0.001 {
+		t.Errorf("split ranges = %+v + %+v, want totals %+v", january, rest, total)
+	}
+}
+
+// TestUsersAndInvites verifies invite creation and cancellation.
+func TestUsersAndInvites(t *testing.T) {
+	_, client := newTestClient(t)
+
+	users, err := client.GetUsers()
+	if err != nil || len(users) != 1 {
+		t.Fatalf("GetUsers() = %v, %v; want one user", users, err)
+	}
+
+	invite, err := client.CreateInvite(&api.InviteCreateRequest{Email: "new@example.com", FirstName: "New", LastName: "User"})
+	if err != nil {
+		t.Fatalf("CreateInvite() error = %v", err)
+	}
+
+	if err := client.CancelInvite(invite.ID); err != nil {
+		t.Fatalf("CancelInvite() error = %v", err)
+	}
+
+	invites, err := client.GetInvites()
+	if err != nil || len(invites) != 0 {
+		t.Errorf("GetInvites() = %v, %v; want none", invites, err)
+	}
+}
+
+// TestActivitiesRecorded verifies mutations show up in the activity feed.
+func TestActivitiesRecorded(t *testing.T) {
+	_, client := newTestClient(t)
+
+	if _, err := client.CreateLabel(&api.LabelCreateRequest{Name: "q4"}); err != nil {
+		t.Fatalf("CreateLabel() error = %v", err)
+	}
+
+	activities, err := client.GetActivities(map[string]string{"limit": "1"})
+	if err != nil {
+		t.Fatalf("GetActivities() error = %v", err)
+	}
+	if len(activities) != 1 || activities[0].SubAction != "label" {
+		t.Errorf("GetActivities() = %+v, want the label creation", activities)
+	}
+}
+
+// TestETagRevalidation verifies GET responses carry an ETag and honor If-None-Match.
+func TestETagRevalidation(t *testing.T) {
+	_, ts := NewTestServer()
+	defer ts.Close()
+
+	req, _ := http.NewRequest("GET", ts.URL+"/api/v3/1/categories", nil)
+	req.Header.Set("Authorization", "Bearer "+DefaultToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET error = %v", err)
+	}
+	resp.Body.Close()
+
+	etag := resp.Header.Get("ETag")
+	if etag == "" {
+		t.Fatal("ETag header missing")
+	}
+
+	req.Header.Set("If-None-Match", etag)
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET error = %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNotModified {
+		t.Errorf("status = %d, want 304", resp.StatusCode)
+	}
+}
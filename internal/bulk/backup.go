@@ -0,0 +1,152 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package bulk
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+	"github.com/cloudmanic/skyclerk-cli/internal/receipts"
+)
+
+// BackupVersion is the current backup file format.
+const BackupVersion = 1
+
+// Backup is a copy of ledger entries taken before they are deleted. Attached
+// files are deleted with their entries, so their contents are saved in a
+// folder next to the backup file (see FilesDir).
+type Backup struct {
+	Version   int          `json:"version"`
+	CreatedAt time.Time    `json:"created_at"`
+	APIURL    string       `json:"api_url"`
+	AccountID uint         `json:"account_id"`
+	Ledgers   []api.Ledger `json:"ledgers"`
+
+	// Restored maps original entry IDs to the IDs they were restored as, so
+	// restoring the same backup twice doesn't create duplicates.
+	Restored map[uint]uint `json:"restored,omitempty"`
+
+	// RestoredFiles maps original file IDs to the IDs they were re-uploaded
+	// as, so a repeated restore retries only the files that failed.
+	RestoredFiles map[uint]uint `json:"restored_files,omitempty"`
+
+	path string
+}
+
+// NewBackup returns a backup of ledgers to be written to path.
+func NewBackup(path string, apiURL string, accountID uint, ledgers []api.Ledger) *Backup {
+	return &Backup{
+		Version:   BackupVersion,
+		CreatedAt: time.Now().UTC(),
+		APIURL:    apiURL,
+		AccountID: accountID,
+		Ledgers:   ledgers,
+		path:      path,
+	}
+}
+
+// ReadBackup loads a backup file.
+func ReadBackup(path string) (*Backup, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read backup: %w", err)
+	}
+
+	var b Backup
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("unable to parse backup: %w", err)
+	}
+	if b.Version != BackupVersion {
+		return nil, fmt.Errorf("unsupported backup version %d", b.Version)
+	}
+
+	b.path = path
+	return &b, nil
+}
+
+// Path returns the backup file's location.
+func (b *Backup) Path() string {
+	return b.path
+}
+
+// FilesDir returns the folder holding the backup's attached files.
+func (b *Backup) FilesDir() string {
+	return strings.TrimSuffix(b.path, filepath.Ext(b.path)) + ".files"
+}
+
+// FileName returns an attached file's slash-separated path inside FilesDir.
+func (b *Backup) FileName(f api.File) string {
+	name := receipts.SanitizeName(f.Name)
+	if name == "" {
+		name = "file"
+	}
+
+	return strconv.FormatUint(uint64(f.ID), 10) + "/" + name
+}
+
+// FilePath returns where an attached file's contents are kept.
+func (b *Backup) FilePath(f api.File) string {
+	return filepath.Join(b.FilesDir(), filepath.FromSlash(b.FileName(f)))
+}
+
+// MarkRestored records that an entry was recreated and saves the backup.
+func (b *Backup) MarkRestored(oldID uint, newID uint) error {
+	if b.Restored == nil {
+		b.Restored = map[uint]uint{}
+	}
+	b.Restored[oldID] = newID
+
+	return b.Save()
+}
+
+// MarkFileRestored records that a file was re-uploaded and saves the backup.
+func (b *Backup) MarkFileRestored(oldID uint, newID uint) error {
+	if b.RestoredFiles == nil {
+		b.RestoredFiles = map[uint]uint{}
+	}
+	b.RestoredFiles[oldID] = newID
+
+	return b.Save()
+}
+
+// PendingFiles returns the entry's files that have not been re-uploaded.
+func (b *Backup) PendingFiles(l api.Ledger) []api.File {
+	var files []api.File
+	for _, f := range l.Files {
+		if b.RestoredFiles[f.ID] == 0 {
+			files = append(files, f)
+		}
+	}
+
+	return files
+}
+
+// Save writes the backup atomically, readable only by the current user.
+func (b *Backup) Save() error {
+	if err := os.MkdirAll(filepath.Dir(b.path), 0700); err != nil {
+		return fmt.Errorf("unable to create backup directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to marshal backup: %w", err)
+	}
+
+	tmp := b.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("unable to write backup: %w", err)
+	}
+
+	if err := os.Rename(tmp, b.path); err != nil {
+		return fmt.Errorf("unable to write backup: %w", err)
+	}
+
+	return nil
+}
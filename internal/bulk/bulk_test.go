@@ -0,0 +1,197 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package bulk
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+)
+
+// sample returns entries covering income, expenses, labels and contacts.
+func sample() []api.Ledger {
+	software := api.Category{ID: 5, Name: "Software"}
+	return []api.Ledger{
+		{ID: 1, Date: "2026-01-05T00:00:00Z", Amount: -21, Category: software, Contact: api.Contact{ID: 3, Name: "GitHub"}, Note: "Team plan"},
+		{ID: 2, Date: "2026-02-05T00:00:00Z", Amount: -49, Category: software, Contact: api.Contact{ID: 4, Name: "Figma"}, Labels: []api.Label{{ID: 7, Name: "Design"}}},
+		{ID: 3, Date: "2026-02-10T00:00:00Z", Amount: 900, Category: api.Category{ID: 1, Name: "Sales"}, Contact: api.Contact{ID: 9, Name: "Acme"}},
+	}
+}
+
+// TestFilterMatch verifies each criterion narrows the selection.
+func TestFilterMatch(t *testing.T) {
+	tests := []struct {
+		filter Filter
+		want   string
+	}{
+		{Filter{}, "1,2,3"},
+		{Filter{CategoryID: 5}, "1,2"},
+		{Filter{LabelID: 7}, "2"},
+		{Filter{Type: "income"}, "3"},
+		{Filter{Start: "2026-02-01", End: "2026-02-09"}, "2"},
+		{Filter{Search: "team"}, "1"},
+		{Filter{Search: "FIG", Type: "expense"}, "2"},
+	}
+
+	for _, tt := range tests {
+		var ids []string
+		for _, l := range tt.filter.Select(sample()) {
+			ids = append(ids, strconv.FormatUint(uint64(l.ID), 10))
+		}
+		if got := strings.Join(ids, ","); got != tt.want {
+			t.Errorf("%+v selected %s, want %s", tt.filter, got, tt.want)
+		}
+	}
+}
+
+// TestFilterLocation verifies date ranges are whole days in the filter's zone.
+func TestFilterLocation(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("time zone data unavailable:", err)
+	}
+
+	// 9:30pm on February 9 in New York.
+	late := api.Ledger{ID: 4, Date: "2026-02-10T02:30:00Z"}
+	if !(Filter{End: "2026-02-09", Location: ny}).Match(late) {
+		t.Error("entry from the evening of February 9 in New York is not on February 9")
+	}
+	if (Filter{End: "2026-02-09"}).Match(late) {
+		t.Error("entry from February 10 UTC matched a range ending February 9")
+	}
+	if !(Filter{Location: ny}).Empty() {
+		t.Error("a filter with only a location is not empty")
+	}
+}
+
+// TestChangePlan verifies requests carry only what changes and no-op entries are skipped.
+func TestChangePlan(t *testing.T) {
+	cloud := &api.Category{ID: 6, Name: "Cloud"}
+	design := api.Label{ID: 7, Name: "Design"}
+	tools := api.Label{ID: 8, Name: "Tools"}
+
+	c := Change{Category: cloud, AddLabels: []api.Label{tools}, RemoveLabels: []api.Label{design}, NoteAppend: "(split)"}
+	entries := sample()
+
+	u, ok := c.Plan(entries[1])
+	if !ok {
+		t.Fatal("Plan() reported no change")
+	}
+	if u.Request.Category.ID != 6 || len(*u.Request.Labels) != 1 || (*u.Request.Labels)[0].ID != 8 || *u.Request.Note != "(split)" {
+		t.Errorf("request = %+v", u.Request)
+	}
+	if u.Request.Contact != nil {
+		t.Errorf("contact should be left out of the request, got %+v", u.Request.Contact)
+	}
+	if len(u.Changes) != 4 {
+		t.Errorf("changes = %v, want 4", u.Changes)
+	}
+
+	u, _ = c.Plan(entries[0])
+	if *u.Request.Note != "Team plan (split)" {
+		t.Errorf("note = %q, want appended", *u.Request.Note)
+	}
+
+	already := entries[2]
+	already.Category = *cloud
+	already.Labels = []api.Label{tools}
+	if _, ok := (Change{Category: cloud, AddLabels: []api.Label{tools}}).Plan(already); ok {
+		t.Error("Plan() changed an entry that already matches")
+	}
+}
+
+// fakeUpdater fails entry 2 and records the requests it receives.
+type fakeUpdater struct {
+	mu   sync.Mutex
+	seen []uint
+}
+
+// UpdateLedger records the call and fails entry 2.
+func (f *fakeUpdater) UpdateLedger(id uint, req *api.LedgerUpdateRequest) (*api.Ledger, error) {
+	f.mu.Lock()
+	f.seen = append(f.seen, id)
+	f.mu.Unlock()
+
+	if id == 2 {
+		return nil, errors.New("boom")
+	}
+
+	return &api.Ledger{ID: id}, nil
+}
+
+// TestApply verifies failures are reported per entry without stopping the
+// rest, and that removing an entry's only label sends an empty list.
+func TestApply(t *testing.T) {
+	design := api.Label{ID: 7, Name: "Design"}
+	var updates []Update
+	for _, l := range sample() {
+		if u, ok := (Change{RemoveLabels: []api.Label{design}, NoteAppend: "x"}).Plan(l); ok {
+			updates = append(updates, u)
+		}
+	}
+
+	if labels := updates[1].Request.Labels; labels == nil || len(*labels) != 0 {
+		t.Errorf("entry 2 labels = %v, want an empty list", labels)
+	}
+
+	f := &fakeUpdater{}
+	outcomes := Apply(f, updates, 2, nil)
+
+	if len(outcomes) != 3 || outcomes[0].Err != nil || outcomes[2].Result == nil {
+		t.Fatalf("outcomes = %+v", outcomes)
+	}
+	if outcomes[1].Err == nil || outcomes[1].ID != 2 {
+		t.Errorf("entry 2 outcome = %+v, want the updater's error", outcomes[1])
+	}
+	if len(f.seen) != 3 {
+		t.Errorf("API called for %v, want every entry", f.seen)
+	}
+}
+
+// TestBackupRoundTrip verifies a backup reloads with its entries, file paths
+// and restore progress.
+func TestBackupRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ledger-delete.json")
+	ledgers := sample()
+	ledgers[0].Files = []api.File{{ID: 40, Name: "a/b:receipt.pdf"}, {ID: 41, Name: "invoice.pdf"}}
+
+	b := NewBackup(path, "http://localhost", 1, ledgers)
+	if err := b.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if err := b.MarkRestored(1, 101); err != nil {
+		t.Fatalf("MarkRestored() error = %v", err)
+	}
+	if err := b.MarkFileRestored(40, 140); err != nil {
+		t.Fatalf("MarkFileRestored() error = %v", err)
+	}
+
+	got, err := ReadBackup(path)
+	if err != nil {
+		t.Fatalf("ReadBackup() error = %v", err)
+	}
+	if len(got.Ledgers) != 3 || got.Ledgers[0].Files[0].ID != 40 || got.Restored[1] != 101 {
+		t.Errorf("ReadBackup() = %+v", got)
+	}
+	if pending := got.PendingFiles(got.Ledgers[0]); len(pending) != 1 || pending[0].ID != 41 {
+		t.Errorf("PendingFiles() = %+v, want only file 41", pending)
+	}
+
+	want := filepath.Join(filepath.Dir(path), "ledger-delete.files", "40", "a-b-receipt.pdf")
+	if p := got.FilePath(got.Ledgers[0].Files[0]); p != want {
+		t.Errorf("FilePath() = %s, want %s", p, want)
+	}
+
+	os.WriteFile(path, []byte(`{"version": 9}`), 0600)
+	if _, err := ReadBackup(path); err == nil {
+		t.Error("ReadBackup() accepted an unknown version")
+	}
+}
@@ -0,0 +1,102 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package bulk
+
+import (
+	"strings"
+	"time"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+	"github.com/cloudmanic/skyclerk-cli/internal/dates"
+)
+
+// Filter selects ledger entries. Zero-valued fields match everything.
+type Filter struct {
+	CategoryID uint
+	ContactID  uint
+	LabelID    uint
+	Start      string // YYYY-MM-DD, inclusive
+	End        string // YYYY-MM-DD, inclusive
+	Type       string // "income" or "expense"
+	Search     string // case-insensitive substring of the note or contact name
+
+	// Location is the time zone Start and End are days in. Nil means UTC.
+	Location *time.Location
+}
+
+// Empty reports whether the filter matches every entry.
+func (f Filter) Empty() bool {
+	f.Location = nil
+	return f == Filter{}
+}
+
+// Match reports whether an entry passes every set criterion.
+func (f Filter) Match(l api.Ledger) bool {
+	if f.CategoryID != 0 && l.Category.ID != f.CategoryID {
+		return false
+	}
+	if f.ContactID != 0 && l.Contact.ID != f.ContactID {
+		return false
+	}
+	if f.LabelID != 0 && !hasLabel(l, f.LabelID) {
+		return false
+	}
+
+	day := Day(l, f.Location)
+	if (f.Start != "" && day < f.Start) || (f.End != "" && day > f.End) {
+		return false
+	}
+
+	switch strings.ToLower(f.Type) {
+	case "income":
+		if l.Amount < 0 {
+			return false
+		}
+	case "expense":
+		if l.Amount >= 0 {
+			return false
+		}
+	}
+
+	if f.Search != "" {
+		search := strings.ToLower(f.Search)
+		if !strings.Contains(strings.ToLower(l.Note), search) && !strings.Contains(strings.ToLower(l.Contact.Name), search) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Select returns the entries matching the filter, keeping their order.
+func (f Filter) Select(ledgers []api.Ledger) []api.Ledger {
+	var list []api.Ledger
+	for _, l := range ledgers {
+		if f.Match(l) {
+			list = append(list, l)
+		}
+	}
+
+	return list
+}
+
+// Day returns the day an entry falls on in loc as YYYY-MM-DD. Nil means UTC.
+func Day(l api.Ledger, loc *time.Location) string {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	return dates.LocalDay(l.Date, loc)
+}
+
+// hasLabel reports whether an entry carries a label.
+func hasLabel(l api.Ledger, id uint) bool {
+	for _, lb := range l.Labels {
+		if lb.ID == id {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,120 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package bulk
+
+import (
+	"fmt"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+	"github.com/cloudmanic/skyclerk-cli/internal/pool"
+)
+
+// Updater applies a ledger update; *api.Client implements it.
+type Updater interface {
+	UpdateLedger(id uint, req *api.LedgerUpdateRequest) (*api.Ledger, error)
+}
+
+// Change is a set of edits applied to every selected entry.
+type Change struct {
+	Category     *api.Category
+	Contact      *api.Contact
+	AddLabels    []api.Label
+	RemoveLabels []api.Label
+	NoteAppend   string
+}
+
+// Update is the request for one entry and a description of what it changes.
+type Update struct {
+	Ledger  api.Ledger               `json:"-"`
+	ID      uint                     `json:"id"`
+	Request *api.LedgerUpdateRequest `json:"-"`
+	Changes []string                 `json:"changes"`
+}
+
+// Outcome is the result of applying one update.
+type Outcome struct {
+	Update
+	Result *api.Ledger
+	Err    error
+}
+
+// Empty reports whether the change does nothing.
+func (c Change) Empty() bool {
+	return c.Category == nil && c.Contact == nil && len(c.AddLabels) == 0 && len(c.RemoveLabels) == 0 && c.NoteAppend == ""
+}
+
+// Plan works out the update request for one entry. It reports false when the
+// entry already matches and nothing would change.
+func (c Change) Plan(l api.Ledger) (Update, bool) {
+	u := Update{Ledger: l, ID: l.ID, Request: &api.LedgerUpdateRequest{}}
+
+	if c.Category != nil && c.Category.ID != l.Category.ID {
+		u.Request.Category = c.Category
+		u.Changes = append(u.Changes, fmt.Sprintf("category %s -> %s", l.Category.Name, c.Category.Name))
+	}
+
+	if c.Contact != nil && c.Contact.ID != l.Contact.ID {
+		u.Request.Contact = c.Contact
+		u.Changes = append(u.Changes, fmt.Sprintf("contact %s -> %s", l.Contact.Name, c.Contact.Name))
+	}
+
+	labels, labelChanges := c.planLabels(l)
+	if len(labelChanges) > 0 {
+		u.Request.Labels = &labels
+		u.Changes = append(u.Changes, labelChanges...)
+	}
+
+	if c.NoteAppend != "" {
+		note := c.NoteAppend
+		if l.Note != "" {
+			note = l.Note + " " + c.NoteAppend
+		}
+		u.Request.Note = &note
+		u.Changes = append(u.Changes, fmt.Sprintf("note += %q", c.NoteAppend))
+	}
+
+	return u, len(u.Changes) > 0
+}
+
+// planLabels returns the entry's new label list and the label changes made.
+func (c Change) planLabels(l api.Ledger) ([]api.Label, []string) {
+	remove := map[uint]bool{}
+	for _, lb := range c.RemoveLabels {
+		remove[lb.ID] = true
+	}
+
+	labels := []api.Label{}
+	have := map[uint]bool{}
+	var changes []string
+	for _, lb := range l.Labels {
+		if remove[lb.ID] {
+			changes = append(changes, "-label "+lb.Name)
+			continue
+		}
+		labels = append(labels, lb)
+		have[lb.ID] = true
+	}
+
+	for _, lb := range c.AddLabels {
+		if have[lb.ID] {
+			continue
+		}
+		labels = append(labels, lb)
+		have[lb.ID] = true
+		changes = append(changes, "+label "+lb.Name)
+	}
+
+	return labels, changes
+}
+
+// Apply sends every update using at most parallel concurrent requests.
+// Failures do not stop the rest. onResult (if set) is called as each update
+// finishes; the returned outcomes are in update order.
+func Apply(u Updater, updates []Update, parallel int, onResult func(Outcome)) []Outcome {
+	return pool.Map(updates, parallel, func(up Update) Outcome {
+		o := Outcome{Update: up}
+		o.Result, o.Err = u.UpdateLedger(up.ID, up.Request)
+		return o
+	}, onResult)
+}
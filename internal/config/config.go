@@ -4,6 +4,8 @@
 package config
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -16,6 +18,24 @@ const ConfigDir = ".config/skyclerk"
 // ConfigFile is the name of the config file.
 const ConfigFile = "config.json"
 
+// CacheDir is the name of the reference data cache directory inside the config directory.
+const CacheDir = "cache"
+
+// UploadsDir is the name of the directory holding per-account upload manifests.
+const UploadsDir = "uploads"
+
+// BackupsDir is the name of the directory holding backups of deleted ledger entries.
+const BackupsDir = "backups"
+
+// JournalDir is the name of the directory holding per-account undo journals.
+const JournalDir = "journal"
+
+// SnapshotsDir is the name of the directory holding the object versions each account has fetched.
+const SnapshotsDir = "snapshots"
+
+// TemplatesDir is the name of the directory holding per-account ledger entry templates.
+const TemplatesDir = "templates"
+
 // Config holds the CLI configuration including auth credentials and defaults.
 type Config struct {
 	AccessToken      string `json:"access_token"`
@@ -23,6 +43,16 @@ type Config struct {
 	DefaultAccountID uint   `json:"default_account_id"`
 	ApiURL           string `json:"api_url"`
 	ClientID         string `json:"client_id"`
+	CacheTTL         string `json:"cache_ttl,omitempty"`
+	TimeZone         string `json:"time_zone,omitempty"`
+	Audit            *Audit `json:"audit,omitempty"`
+}
+
+// Audit holds the receipt policy checked by 'skyclerk ledger audit'.
+type Audit struct {
+	ReceiptThreshold  *float64 `json:"receipt_threshold,omitempty"`
+	RequireCategories []string `json:"require_categories,omitempty"`
+	ExemptLabels      []string `json:"exempt_labels,omitempty"`
 }
 
 // DefaultApiURL is the default Skyclerk API URL.
@@ -38,6 +68,75 @@ func GetConfigDir() (string, error) {
 	return filepath.Join(home, ConfigDir), nil
 }
 
+// GetCacheDir returns the full path to the reference data cache directory.
+func GetCacheDir() (string, error) {
+	dir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, CacheDir), nil
+}
+
+// GetUploadsDir returns the full path to the upload manifest directory.
+func GetUploadsDir() (string, error) {
+	dir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, UploadsDir), nil
+}
+
+// GetBackupsDir returns the full path to the deleted ledger entry backup directory.
+func GetBackupsDir() (string, error) {
+	dir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, BackupsDir), nil
+}
+
+// GetJournalDir returns the full path to the undo journal directory.
+func GetJournalDir() (string, error) {
+	dir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, JournalDir), nil
+}
+
+// GetSnapshotsDir returns the full path to the fetched object snapshot directory.
+func GetSnapshotsDir() (string, error) {
+	dir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, SnapshotsDir), nil
+}
+
+// GetTemplatesDir returns the full path to the ledger entry template directory.
+func GetTemplatesDir() (string, error) {
+	dir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, TemplatesDir), nil
+}
+
+// AccountPath returns the file for an API URL and account inside one of the
+// per-account directories, such as the upload manifest, journal, snapshot or
+// template directory. The URL is hashed so test and production servers sharing
+// an account ID don't share a file.
+func AccountPath(dir string, baseURL string, accountID uint) string {
+	sum := sha256.Sum256([]byte(baseURL))
+	return filepath.Join(dir, fmt.Sprintf("account-%d-%s.json", accountID, hex.EncodeToString(sum[:4])))
+}
+
 // GetConfigPath returns the full path to the config file.
 func GetConfigPath() (string, error) {
 	dir, err := GetConfigDir()
@@ -6,6 +6,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -164,3 +165,17 @@ func TestMaskString(t *testing.T) {
 		}
 	}
 }
+
+// TestAccountPath verifies each API URL and account gets its own file.
+func TestAccountPath(t *testing.T) {
+	a := AccountPath("/tmp/journal", "https://app.skyclerk.com", 1)
+	if filepath.Dir(a) != "/tmp/journal" || !strings.HasPrefix(filepath.Base(a), "account-1-") || filepath.Ext(a) != ".json" {
+		t.Errorf("AccountPath() = %s", a)
+	}
+	if a == AccountPath("/tmp/journal", "http://127.0.0.1:7071", 1) {
+		t.Error("AccountPath() is the same for two API URLs")
+	}
+	if a == AccountPath("/tmp/journal", "https://app.skyclerk.com", 2) {
+		t.Error("AccountPath() is the same for two accounts")
+	}
+}
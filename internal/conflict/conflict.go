@@ -0,0 +1,164 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package conflict
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ignoredFields are fields the server changes on its own. They don't count as
+// edits when hashing or comparing objects.
+var ignoredFields = map[string]bool{
+	"account_id":  true,
+	"added_by_id": true,
+	"count":       true,
+	"created_at":  true,
+	"updated_at":  true,
+	"files":       true,
+}
+
+// Field is one field that differs between two versions of an object.
+type Field struct {
+	Name string
+	Was  string
+	Now  string
+}
+
+// Normalize decodes an object's JSON form without the ignored fields, at every
+// level. Empty arrays become nil so a missing list equals an empty one.
+func Normalize(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var out interface{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+
+	return strip(out), nil
+}
+
+// Equal reports whether two objects match once ignored fields are removed.
+func Equal(a interface{}, b interface{}) bool {
+	na, errA := Normalize(a)
+	nb, errB := Normalize(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(na, nb)
+}
+
+// Hash returns a short hash of an object's content that changes whenever a
+// user-editable field does.
+func Hash(v interface{}) string {
+	n, err := Normalize(v)
+	if err != nil {
+		return ""
+	}
+
+	// Maps marshal with sorted keys, so equal content always hashes the same.
+	data, _ := json.Marshal(n)
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:6])
+}
+
+// Diff lists the fields that differ between two versions of an object.
+// Nested objects are flattened into dotted names such as "contact.name".
+func Diff(was interface{}, now interface{}) []Field {
+	a, b := map[string]string{}, map[string]string{}
+	if n, err := Normalize(was); err == nil {
+		flatten("", n, a)
+	}
+	if n, err := Normalize(now); err == nil {
+		flatten("", n, b)
+	}
+
+	names := map[string]bool{}
+	for k := range a {
+		names[k] = true
+	}
+	for k := range b {
+		names[k] = true
+	}
+
+	var fields []Field
+	for name := range names {
+		if a[name] != b[name] {
+			fields = append(fields, Field{Name: name, Was: a[name], Now: b[name]})
+		}
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Name < fields[j].Name })
+
+	return fields
+}
+
+// strip removes ignored fields from decoded JSON.
+func strip(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, val := range t {
+			if ignoredFields[k] {
+				delete(t, k)
+				continue
+			}
+			t[k] = strip(val)
+		}
+	case []interface{}:
+		for i := range t {
+			t[i] = strip(t[i])
+		}
+		if len(t) == 0 {
+			return nil
+		}
+	}
+
+	return v
+}
+
+// flatten writes each leaf of decoded JSON to out under its dotted name.
+// Lists are shown as the names of their items when they have them.
+func flatten(prefix string, v interface{}, out map[string]string) {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, val := range t {
+			name := k
+			if prefix != "" {
+				name = prefix + "." + k
+			}
+			flatten(name, val, out)
+		}
+	case []interface{}:
+		parts := make([]string, 0, len(t))
+		for _, item := range t {
+			if m, ok := item.(map[string]interface{}); ok && m["name"] != nil {
+				parts = append(parts, fmt.Sprint(m["name"]))
+				continue
+			}
+			if str, ok := item.(string); ok {
+				parts = append(parts, str)
+				continue
+			}
+			data, _ := json.Marshal(item)
+			parts = append(parts, string(data))
+		}
+		out[prefix] = strings.Join(parts, ", ")
+	case nil:
+		out[prefix] = ""
+	case string:
+		out[prefix] = t
+	default:
+		data, _ := json.Marshal(t)
+		out[prefix] = string(data)
+	}
+}
@@ -0,0 +1,102 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package conflict
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+)
+
+// TestHash verifies the hash ignores server-maintained fields but not edits.
+func TestHash(t *testing.T) {
+	base := api.Ledger{
+		ID: 1, Amount: -21, Note: "GitHub", UpdatedAt: "2026-10-01T10:00:00Z",
+		Labels: []api.Label{{ID: 2, Name: "Software", Count: 3}},
+	}
+
+	touched := base
+	touched.UpdatedAt = "2026-10-18T10:00:00Z"
+	touched.Labels = []api.Label{{ID: 2, Name: "Software", Count: 9}}
+	touched.Files = []api.File{{ID: 5}}
+	if Hash(base) != Hash(touched) {
+		t.Error("Hash() changed for server-maintained fields")
+	}
+
+	edited := base
+	edited.Note = "GitHub Team"
+	if Hash(base) == Hash(edited) {
+		t.Error("Hash() did not change when the note was edited")
+	}
+
+	// A snapshot read back from JSON hashes the same as the object.
+	data, _ := json.Marshal(base)
+	if Hash(json.RawMessage(data)) != Hash(base) {
+		t.Error("Hash() differs between an object and its JSON")
+	}
+}
+
+// TestDiff verifies changed fields are listed by dotted name with label names.
+func TestDiff(t *testing.T) {
+	was := api.Ledger{
+		ID: 1, Amount: -21, Note: "GitHub",
+		Contact: api.Contact{ID: 3, Name: "GitHub"},
+		Labels:  []api.Label{{ID: 2, Name: "Software"}},
+	}
+	now := was
+	now.Amount = -25
+	now.Note = ""
+	now.Contact.Name = "GitHub Inc"
+	now.Labels = []api.Label{{ID: 2, Name: "Software"}, {ID: 4, Name: "Tax"}}
+
+	want := []Field{
+		{Name: "amount", Was: "-21", Now: "-25"},
+		{Name: "contact.name", Was: "GitHub", Now: "GitHub Inc"},
+		{Name: "labels", Was: "Software", Now: "Software, Tax"},
+		{Name: "note", Was: "GitHub", Now: ""},
+	}
+	if got := Diff(was, now); !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff() = %+v, want %+v", got, want)
+	}
+
+	if got := Diff(was, was); got != nil {
+		t.Errorf("Diff() of equal objects = %+v, want none", got)
+	}
+}
+
+// TestSnapshots verifies snapshots survive a reload and Refresh only updates
+// objects that were already remembered.
+func TestSnapshots(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshots.json")
+	s, err := LoadSnapshots(path)
+	if err != nil {
+		t.Fatalf("LoadSnapshots() error = %v", err)
+	}
+
+	contact := api.Contact{ID: 4, Name: "Acme"}
+	if err := s.Put("contact", 4, contact); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	s.Refresh("contact", 5, api.Contact{ID: 5, Name: "Other"})
+
+	contact.Name = "Acme Corp"
+	s.Refresh("contact", 4, contact)
+
+	s, _ = LoadSnapshots(path)
+	snap, ok := s.Get("contact", 4)
+	if !ok || snap.Hash != Hash(contact) {
+		t.Errorf("Get(4) = %+v, want the refreshed version", snap)
+	}
+	if _, ok := s.Get("contact", 5); ok {
+		t.Error("Refresh() remembered an object that was never fetched")
+	}
+
+	s.Forget("contact", 4)
+	if _, ok := s.Get("contact", 4); ok {
+		t.Error("Forget() kept the snapshot")
+	}
+}
@@ -0,0 +1,136 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package conflict
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// MaxSnapshots is how many objects a snapshot file keeps; the oldest are dropped first.
+const MaxSnapshots = 1000
+
+// Snapshot is the version of an object a user last fetched or saved.
+type Snapshot struct {
+	Time time.Time       `json:"time"`
+	Hash string          `json:"hash"`
+	Data json.RawMessage `json:"data"`
+}
+
+// Snapshots is the local per-account record of the object versions a user
+// has seen, used to notice when someone else changed them since.
+type Snapshots struct {
+	path    string
+	Objects map[string]Snapshot `json:"objects"`
+}
+
+// LoadSnapshots reads a snapshot file, returning an empty one if it does not exist.
+func LoadSnapshots(path string) (*Snapshots, error) {
+	s := &Snapshots{path: path, Objects: map[string]Snapshot{}}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, fmt.Errorf("unable to read snapshots: %w", err)
+	}
+
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("unable to parse snapshots: %w", err)
+	}
+	if s.Objects == nil {
+		s.Objects = map[string]Snapshot{}
+	}
+
+	return s, nil
+}
+
+// Get returns the snapshot of an object, if one was taken.
+func (s *Snapshots) Get(kind string, id uint) (Snapshot, bool) {
+	snap, ok := s.Objects[key(kind, id)]
+	return snap, ok
+}
+
+// Put records an object's current version and saves the file.
+func (s *Snapshots) Put(kind string, id uint, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("unable to marshal snapshot: %w", err)
+	}
+
+	s.Objects[key(kind, id)] = Snapshot{Time: time.Now().UTC(), Hash: Hash(v), Data: data}
+	s.trim()
+
+	return s.save()
+}
+
+// Refresh updates an object's snapshot only if one was already taken, so
+// changes made by the user's own commands don't later look like conflicts.
+func (s *Snapshots) Refresh(kind string, id uint, v interface{}) error {
+	if _, ok := s.Get(kind, id); !ok {
+		return nil
+	}
+
+	return s.Put(kind, id, v)
+}
+
+// Forget drops an object's snapshot and saves the file.
+func (s *Snapshots) Forget(kind string, id uint) error {
+	if _, ok := s.Get(kind, id); !ok {
+		return nil
+	}
+	delete(s.Objects, key(kind, id))
+
+	return s.save()
+}
+
+// key returns the map key for an object, such as "ledger/12".
+func key(kind string, id uint) string {
+	return fmt.Sprintf("%s/%d", kind, id)
+}
+
+// trim drops the oldest snapshots beyond MaxSnapshots.
+func (s *Snapshots) trim() {
+	if len(s.Objects) <= MaxSnapshots {
+		return
+	}
+
+	keys := make([]string, 0, len(s.Objects))
+	for k := range s.Objects {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return s.Objects[keys[i]].Time.Before(s.Objects[keys[j]].Time) })
+
+	for _, k := range keys[:len(keys)-MaxSnapshots] {
+		delete(s.Objects, k)
+	}
+}
+
+// save writes the snapshots atomically, readable only by the current user.
+func (s *Snapshots) save() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return fmt.Errorf("unable to create snapshot directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to marshal snapshots: %w", err)
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("unable to write snapshots: %w", err)
+	}
+
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("unable to write snapshots: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,393 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package dates
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Layout is the API's day format.
+const Layout = "2006-01-02"
+
+// Day orders for numeric dates such as 05/03/2026.
+const (
+	MonthDayYear = "MDY"
+	DayMonthYear = "DMY"
+	YearMonthDay = "YMD"
+)
+
+// Parser turns dates typed by a user into calendar days. Besides YYYY-MM-DD it
+// understands today, yesterday, tomorrow, last/next <weekday>, this/last/next
+// week, month or year, offsets such as -3d or +2w, months such as 2026-03 or
+// "mar 2026", years, "mar 5" with an optional year, and numeric dates such as
+// 05/03/2026 read in the account locale's order.
+type Parser struct {
+	// Now is the moment "today" and relative dates are counted from.
+	Now time.Time
+
+	// Locale returns the account locale, such as "en-US". It is only called
+	// for numeric dates whose meaning depends on it and may be nil.
+	Locale func() string
+}
+
+var (
+	// isoDay matches 2026-03-05.
+	isoDay = regexp.MustCompile(`^(\d{4})-(\d{1,2})-(\d{1,2})$`)
+
+	// isoMonth matches 2026-03.
+	isoMonth = regexp.MustCompile(`^(\d{4})-(\d{1,2})$`)
+
+	// year matches 2026.
+	year = regexp.MustCompile(`^\d{4}$`)
+
+	// offset matches -3d, +2w, -1m or -1y.
+	offset = regexp.MustCompile(`^([+-]\d+)\s*([dwmy])$`)
+
+	// numeric matches 05/03/2026, 5.3.26, 05-03-2026 or 05/03.
+	numeric = regexp.MustCompile(`^(\d{1,4})[/.-](\d{1,2})(?:[/.-](\d{1,4}))?$`)
+)
+
+// months maps month names and their three-letter abbreviations to months.
+var months = map[string]time.Month{}
+
+// weekdays maps weekday names and their three-letter abbreviations to weekdays.
+var weekdays = map[string]time.Weekday{}
+
+func init() {
+	for m := time.January; m <= time.December; m++ {
+		name := strings.ToLower(m.String())
+		months[name] = m
+		months[name[:3]] = m
+	}
+	months["sept"] = time.September
+
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		name := strings.ToLower(d.String())
+		weekdays[name] = d
+		weekdays[name[:3]] = d
+	}
+}
+
+// Day returns the single day s refers to as YYYY-MM-DD. Periods such as a
+// month are an error.
+func (p Parser) Day(s string) (string, error) {
+	start, end, err := p.parse(s)
+	if err != nil {
+		return "", err
+	}
+	if !start.Equal(end) {
+		return "", fmt.Errorf("%q is a period from %s to %s, not a single day", s, start.Format(Layout), end.Format(Layout))
+	}
+
+	return start.Format(Layout), nil
+}
+
+// Start returns the first day of the period s refers to, for a range's start.
+func (p Parser) Start(s string) (string, error) {
+	start, _, err := p.parse(s)
+	if err != nil {
+		return "", err
+	}
+
+	return start.Format(Layout), nil
+}
+
+// End returns the last day of the period s refers to, for a range's end.
+func (p Parser) End(s string) (string, error) {
+	_, end, err := p.parse(s)
+	if err != nil {
+		return "", err
+	}
+
+	return end.Format(Layout), nil
+}
+
+// parse returns the first and last day s refers to, equal for a single day.
+func (p Parser) parse(input string) (time.Time, time.Time, error) {
+	s := strings.ToLower(strings.Join(strings.Fields(input), " "))
+	if s == "" {
+		return time.Time{}, time.Time{}, fmt.Errorf("empty date")
+	}
+
+	today := p.today()
+
+	switch s {
+	case "today":
+		return today, today, nil
+	case "yesterday":
+		return single(today.AddDate(0, 0, -1), nil)
+	case "tomorrow":
+		return single(today.AddDate(0, 0, 1), nil)
+	}
+
+	if m := isoDay.FindStringSubmatch(s); m != nil {
+		return single(civil(atoi(m[1]), atoi(m[2]), atoi(m[3]), input))
+	}
+	if m := isoMonth.FindStringSubmatch(s); m != nil {
+		return month(atoi(m[1]), atoi(m[2]), input)
+	}
+	if year.MatchString(s) {
+		y := atoi(s)
+		return date(y, 1, 1), date(y, 12, 31), nil
+	}
+	if t, err := time.Parse(time.RFC3339, strings.TrimSpace(input)); err == nil {
+		return single(date(t.Year(), int(t.Month()), t.Day()), nil)
+	}
+	if m := offset.FindStringSubmatch(s); m != nil {
+		n := atoi(m[1])
+		switch m[2] {
+		case "d":
+			return single(today.AddDate(0, 0, n), nil)
+		case "w":
+			return single(today.AddDate(0, 0, 7*n), nil)
+		case "m":
+			return single(addMonths(today, n), nil)
+		default:
+			return single(addMonths(today, 12*n), nil)
+		}
+	}
+	if m := numeric.FindStringSubmatch(s); m != nil {
+		return single(p.numeric(m, input))
+	}
+
+	words := strings.Fields(strings.ReplaceAll(s, ",", " "))
+	if start, end, ok := relative(words, today); ok {
+		return start, end, nil
+	}
+	if _, ok := weekdays[s]; ok {
+		return time.Time{}, time.Time{}, fmt.Errorf("%q is ambiguous: use \"last %s\" or \"next %s\"", input, s, s)
+	}
+
+	return named(words, today, input)
+}
+
+// today returns the current day at midnight UTC.
+func (p Parser) today() time.Time {
+	now := p.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	return date(now.Year(), int(now.Month()), now.Day())
+}
+
+// numeric reads a date such as 05/03/2026 in the locale's order. Dates that
+// read differently in other orders are an error when the locale doesn't say.
+func (p Parser) numeric(m []string, input string) (time.Time, error) {
+	a, b := atoi(m[1]), atoi(m[2])
+	y, hasYear := p.today().Year(), m[3] != ""
+
+	// A four-digit first part can only be a year.
+	if len(m[1]) == 4 {
+		if !hasYear {
+			return time.Time{}, fmt.Errorf("%q is not a date: use YYYY-MM-DD", input)
+		}
+		return civil(a, b, atoi(m[3]), input)
+	}
+	if hasYear {
+		y = fullYear(m[3])
+	}
+
+	locale := ""
+	if p.Locale != nil {
+		locale = p.Locale()
+	}
+
+	switch Order(locale) {
+	case MonthDayYear:
+		return civil(y, a, b, input)
+	case DayMonthYear:
+		return civil(y, b, a, input)
+	case YearMonthDay:
+		if hasYear {
+			return civil(fullYear(m[1]), b, atoi(m[3]), input)
+		}
+		return civil(y, a, b, input)
+	}
+
+	// Without a known order only dates that read one way are accepted.
+	switch {
+	case a > 12 && b > 12:
+		return time.Time{}, fmt.Errorf("%q is not a valid date", input)
+	case a > 12:
+		return civil(y, b, a, input)
+	case b > 12:
+		return civil(y, a, b, input)
+	case a == b:
+		return civil(y, a, b, input)
+	}
+
+	mdy, dmy := date(y, a, b), date(y, b, a)
+	return time.Time{}, fmt.Errorf("%q is ambiguous: it could be %s or %s; use YYYY-MM-DD or set the account locale", input, mdy.Format(Layout), dmy.Format(Layout))
+}
+
+// Order returns the day order numeric dates use in a locale such as "en-US"
+// or "de_DE", or "" when it isn't known.
+func Order(locale string) string {
+	parts := strings.FieldsFunc(strings.ToLower(locale), func(r rune) bool { return r == '-' || r == '_' })
+	if len(parts) == 0 {
+		return ""
+	}
+
+	lang, region := parts[0], ""
+	if len(parts) > 1 {
+		region = parts[len(parts)-1]
+	}
+
+	switch region {
+	case "us", "ph", "pr", "gu", "vi", "as", "mp", "um":
+		return MonthDayYear
+	case "ca":
+		// Canada uses all three orders.
+		return ""
+	}
+
+	switch lang {
+	case "ja", "zh", "ko", "hu", "lt", "mn":
+		return YearMonthDay
+	case "en":
+		if region == "" {
+			return ""
+		}
+	}
+
+	return DayMonthYear
+}
+
+// relative handles "last friday", "next monday" and "this/last/next week,
+// month or year".
+func relative(words []string, today time.Time) (time.Time, time.Time, bool) {
+	if len(words) != 2 {
+		return time.Time{}, time.Time{}, false
+	}
+
+	step := map[string]int{"last": -1, "this": 0, "next": 1}
+	n, ok := step[words[0]]
+	if !ok {
+		return time.Time{}, time.Time{}, false
+	}
+
+	if wd, ok := weekdays[words[1]]; ok && n != 0 {
+		diff := int(wd - today.Weekday())
+		if n < 0 {
+			// The most recent such day before today.
+			if diff >= 0 {
+				diff -= 7
+			}
+		} else if diff <= 0 {
+			diff += 7
+		}
+		day := today.AddDate(0, 0, diff)
+		return day, day, true
+	}
+
+	switch words[1] {
+	case "week":
+		// Weeks start on Monday.
+		monday := today.AddDate(0, 0, -((int(today.Weekday())+6)%7)+7*n)
+		return monday, monday.AddDate(0, 0, 6), true
+	case "month":
+		first := addMonths(date(today.Year(), int(today.Month()), 1), n)
+		return first, first.AddDate(0, 1, -1), true
+	case "year":
+		y := today.Year() + n
+		return date(y, 1, 1), date(y, 12, 31), true
+	}
+
+	return time.Time{}, time.Time{}, false
+}
+
+// named handles dates with a month name: "mar 5", "5 march 2026",
+// "mar 5, 2026", "march 2026" and "march".
+func named(words []string, today time.Time, input string) (time.Time, time.Time, error) {
+	var m time.Month
+	var day, y int
+	for _, w := range words {
+		if mm, ok := months[strings.TrimSuffix(w, ".")]; ok && m == 0 {
+			m = mm
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimRight(w, "stndrh"))
+		switch {
+		case err != nil:
+			return time.Time{}, time.Time{}, fmt.Errorf("%q is not a date", input)
+		case len(w) == 4 && y == 0:
+			y = n
+		case day == 0 && n >= 1 && n <= 31:
+			day = n
+		default:
+			return time.Time{}, time.Time{}, fmt.Errorf("%q is not a date", input)
+		}
+	}
+	if m == 0 {
+		return time.Time{}, time.Time{}, fmt.Errorf("%q is not a date", input)
+	}
+	if y == 0 {
+		y = today.Year()
+	}
+	if day == 0 {
+		return month(y, int(m), input)
+	}
+
+	return single(civil(y, int(m), day, input))
+}
+
+// month returns the first and last day of a month.
+func month(y int, m int, input string) (time.Time, time.Time, error) {
+	if m < 1 || m > 12 {
+		return time.Time{}, time.Time{}, fmt.Errorf("%q has no month %d", input, m)
+	}
+	first := date(y, m, 1)
+
+	return first, first.AddDate(0, 1, -1), nil
+}
+
+// civil returns a day, rejecting ones that don't exist such as February 30.
+func civil(y int, m int, d int, input string) (time.Time, error) {
+	t := date(y, m, d)
+	if m < 1 || m > 12 || t.Day() != d {
+		return time.Time{}, fmt.Errorf("%q is not a valid date", input)
+	}
+
+	return t, nil
+}
+
+// single returns a day as a one-day period.
+func single(t time.Time, err error) (time.Time, time.Time, error) {
+	return t, t, err
+}
+
+// date returns midnight UTC on a day.
+func date(y int, m int, d int) time.Time {
+	return time.Date(y, time.Month(m), d, 0, 0, 0, 0, time.UTC)
+}
+
+// addMonths moves a day by whole months, keeping it within the target month
+// so Jan 31 plus one month is Feb 28 rather than March.
+func addMonths(t time.Time, n int) time.Time {
+	first := date(t.Year(), int(t.Month()), 1).AddDate(0, n, 0)
+	last := first.AddDate(0, 1, -1).Day()
+
+	return date(first.Year(), int(first.Month()), min(t.Day(), last))
+}
+
+// fullYear expands a two-digit year to 20xx.
+func fullYear(s string) int {
+	y := atoi(s)
+	if len(s) <= 2 {
+		y += 2000
+	}
+
+	return y
+}
+
+// atoi converts digits, with an optional sign, to an int.
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
@@ -0,0 +1,123 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package dates
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// now is a Sunday used as "today" by the tests.
+var now = time.Date(2026, 10, 18, 15, 4, 5, 0, time.UTC)
+
+// TestRanges verifies the first and last day of each supported form.
+func TestRanges(t *testing.T) {
+	p := Parser{Now: now, Locale: func() string { return "en-US" }}
+
+	tests := []struct {
+		in, start, end string
+	}{
+		{"2026-03-05", "2026-03-05", "2026-03-05"},
+		{"today", "2026-10-18", "2026-10-18"},
+		{"Yesterday", "2026-10-17", "2026-10-17"},
+		{"tomorrow", "2026-10-19", "2026-10-19"},
+		{"-3d", "2026-10-15", "2026-10-15"},
+		{"+2w", "2026-11-01", "2026-11-01"},
+		{"-1m", "2026-09-18", "2026-09-18"},
+		{"-1y", "2025-10-18", "2025-10-18"},
+		{"last friday", "2026-10-16", "2026-10-16"},
+		{"last sunday", "2026-10-11", "2026-10-11"},
+		{"next mon", "2026-10-19", "2026-10-19"},
+		{"this week", "2026-10-12", "2026-10-18"},
+		{"last month", "2026-09-01", "2026-09-30"},
+		{"next year", "2027-01-01", "2027-12-31"},
+		{"2026-02", "2026-02-01", "2026-02-28"},
+		{"2025", "2025-01-01", "2025-12-31"},
+		{"mar 5", "2026-03-05", "2026-03-05"},
+		{"5 March 2025", "2025-03-05", "2025-03-05"},
+		{"Mar 5th, 2025", "2025-03-05", "2025-03-05"},
+		{"march 2025", "2025-03-01", "2025-03-31"},
+		{"05/03/2026", "2026-05-03", "2026-05-03"},
+		{"5/3/26", "2026-05-03", "2026-05-03"},
+		{"12/25", "2026-12-25", "2026-12-25"},
+		{"2026/03/05", "2026-03-05", "2026-03-05"},
+		{"2026-03-05T10:00:00Z", "2026-03-05", "2026-03-05"},
+	}
+
+	for _, tt := range tests {
+		start, err := p.Start(tt.in)
+		if err != nil {
+			t.Errorf("Start(%q) error = %v", tt.in, err)
+			continue
+		}
+		end, _ := p.End(tt.in)
+		if start != tt.start || end != tt.end {
+			t.Errorf("%q = %s..%s, want %s..%s", tt.in, start, end, tt.start, tt.end)
+		}
+	}
+}
+
+// TestLocales verifies numeric dates follow the locale and are refused when
+// the locale leaves them ambiguous.
+func TestLocales(t *testing.T) {
+	tests := []struct {
+		locale, in, want, err string
+	}{
+		{"en-GB", "05/03/2026", "2026-03-05", ""},
+		{"de_DE", "5.3.2026", "2026-03-05", ""},
+		{"ja-JP", "26/03/05", "2026-03-05", ""},
+		{"en-US", "13/03/2026", "", "not a valid date"},
+		{"", "05/03/2026", "", "could be 2026-05-03 or 2026-03-05"},
+		{"en-CA", "05/03/2026", "", "ambiguous"},
+		{"", "25/03/2026", "2026-03-25", ""},
+		{"", "03/25/2026", "2026-03-25", ""},
+	}
+
+	for _, tt := range tests {
+		locale := tt.locale
+		p := Parser{Now: now, Locale: func() string { return locale }}
+
+		got, err := p.Day(tt.in)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("%s %q error = %v, want %q", tt.locale, tt.in, err, tt.err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("%s %q = %q, %v, want %q", tt.locale, tt.in, got, err, tt.want)
+		}
+	}
+}
+
+// TestErrors verifies bad and ambiguous input is refused with a clear reason.
+func TestErrors(t *testing.T) {
+	p := Parser{Now: now}
+
+	tests := map[string]string{
+		"friday":     `use "last friday" or "next friday"`,
+		"2026-02-30": "not a valid date",
+		"2026-13":    "no month 13",
+		"soon":       "not a date",
+		"":           "empty date",
+	}
+	for in, want := range tests {
+		if _, err := p.Start(in); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Start(%q) error = %v, want %q", in, err, want)
+		}
+	}
+
+	if _, err := p.Day("last month"); err == nil || !strings.Contains(err.Error(), "not a single day") {
+		t.Errorf("Day(last month) error = %v", err)
+	}
+
+	// The locale is only looked up for dates that need it.
+	called := false
+	p.Locale = func() string { called = true; return "" }
+	p.Day("2026-03-05")
+	if called {
+		t.Error("Locale was called for an ISO date")
+	}
+}
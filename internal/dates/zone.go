@@ -0,0 +1,75 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package dates
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TimeLayout is how timestamps such as CreatedAt are shown in tables.
+const TimeLayout = "2006-01-02 15:04"
+
+// LoadLocation returns the time zone named by an IANA name such as
+// "America/New_York". An empty name or "local" is the system's zone.
+func LoadLocation(name string) (*time.Location, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || strings.EqualFold(name, "local") {
+		return time.Local, nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q: use a name such as America/New_York", name)
+	}
+
+	return loc, nil
+}
+
+// Instant returns the moment a YYYY-MM-DD day starts in loc, in the RFC 3339
+// form the API stores. Values that already carry a time are returned as is.
+func Instant(day string, loc *time.Location) string {
+	t, err := time.ParseInLocation(Layout, day, loc)
+	if err != nil {
+		return day
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
+
+// LocalDay returns the day an API timestamp falls on in loc as YYYY-MM-DD.
+// Midnight UTC is how days were sent before the zone was taken into account,
+// so such timestamps are read as that calendar day wherever the user is.
+func LocalDay(value string, loc *time.Location) string {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		if len(value) > 10 {
+			return value[:10]
+		}
+		return value
+	}
+	if isMidnightUTC(t) {
+		return t.Format(Layout)
+	}
+
+	return t.In(loc).Format(Layout)
+}
+
+// LocalTime returns an API timestamp as a time of day in loc, or the value
+// unchanged when it isn't a timestamp.
+func LocalTime(value string, loc *time.Location) string {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return value
+	}
+
+	return t.In(loc).Format(TimeLayout)
+}
+
+// isMidnightUTC reports whether t is exactly midnight with a zero UTC offset.
+func isMidnightUTC(t time.Time) bool {
+	_, offset := t.Zone()
+	return offset == 0 && t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0
+}
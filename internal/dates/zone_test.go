@@ -0,0 +1,60 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package dates
+
+import (
+	"testing"
+	"time"
+)
+
+// TestZones verifies days become instants in the zone and back again.
+func TestZones(t *testing.T) {
+	ny, err := LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("LoadLocation() error = %v", err)
+	}
+	berlin, err := LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("LoadLocation() error = %v", err)
+	}
+
+	if got := Instant("2026-03-05", ny); got != "2026-03-05T05:00:00Z" {
+		t.Errorf("Instant(New York) = %q", got)
+	}
+	if got := Instant("2026-03-05", berlin); got != "2026-03-04T23:00:00Z" {
+		t.Errorf("Instant(Berlin) = %q", got)
+	}
+	if got := Instant("2026-03-05T12:00:00Z", ny); got != "2026-03-05T12:00:00Z" {
+		t.Errorf("Instant() changed a timestamp to %q", got)
+	}
+
+	tests := []struct {
+		in   string
+		loc  *time.Location
+		want string
+	}{
+		// 9pm in New York is already the next day in UTC.
+		{"2026-03-06T02:00:00Z", ny, "2026-03-05"},
+		{"2026-03-04T23:00:00Z", berlin, "2026-03-05"},
+		{"2026-03-05T05:00:00Z", ny, "2026-03-05"},
+		// Days sent as midnight UTC keep their calendar day.
+		{"2026-03-05T00:00:00Z", ny, "2026-03-05"},
+		{"2026-03-05", ny, "2026-03-05"},
+	}
+	for _, tt := range tests {
+		if got := LocalDay(tt.in, tt.loc); got != tt.want {
+			t.Errorf("LocalDay(%q, %s) = %q, want %q", tt.in, tt.loc, got, tt.want)
+		}
+	}
+
+	if got := LocalTime("2026-03-06T02:30:00Z", ny); got != "2026-03-05 21:30" {
+		t.Errorf("LocalTime() = %q", got)
+	}
+	if _, err := LoadLocation("Mars/Olympus"); err == nil {
+		t.Error("LoadLocation() accepted an unknown zone")
+	}
+	if loc, err := LoadLocation(""); err != nil || loc != time.Local {
+		t.Errorf("LoadLocation(\"\") = %v, %v, want Local", loc, err)
+	}
+}
@@ -0,0 +1,77 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package edit
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+)
+
+// Contact is the editable form of a contact.
+type Contact struct {
+	Name          string `yaml:"name" json:"name"`
+	FirstName     string `yaml:"first_name" json:"first_name"`
+	LastName      string `yaml:"last_name" json:"last_name"`
+	Email         string `yaml:"email" json:"email"`
+	Phone         string `yaml:"phone" json:"phone"`
+	Address       string `yaml:"address" json:"address"`
+	City          string `yaml:"city" json:"city"`
+	State         string `yaml:"state" json:"state"`
+	Zip           string `yaml:"zip" json:"zip"`
+	Country       string `yaml:"country" json:"country"`
+	Website       string `yaml:"website" json:"website"`
+	AccountNumber string `yaml:"account_number" json:"account_number"`
+}
+
+// NewContact returns the editable form of a contact.
+func NewContact(c api.Contact) Contact {
+	return Contact{
+		Name: c.Name, FirstName: c.FirstName, LastName: c.LastName, Email: c.Email,
+		Phone: c.Phone, Address: c.Address, City: c.City, State: c.State, Zip: c.Zip,
+		Country: c.Country, Website: c.Website, AccountNumber: c.AccountNumber,
+	}
+}
+
+// Marshal renders the document as annotated YAML for contact id.
+func (d Contact) Marshal(id uint) ([]byte, error) {
+	header := fmt.Sprintf("Editing contact %d. Save and close the editor to apply the changes;\nempty the file to cancel. Clear a field with \"\".", id)
+	return Marshal(d, header, map[string]string{"name": "required"})
+}
+
+// Validate checks an edited document.
+func (d Contact) Validate() error {
+	if strings.TrimSpace(d.Name) == "" {
+		return errors.New("name: a contact needs a name")
+	}
+
+	return nil
+}
+
+// Request returns an update that sets only the fields changed from was.
+func (d Contact) Request(was Contact) *api.ContactUpdateRequest {
+	req := &api.ContactUpdateRequest{}
+	set := func(dst **string, now string, before string) {
+		if now != before {
+			*dst = api.Ptr(now)
+		}
+	}
+
+	set(&req.Name, d.Name, was.Name)
+	set(&req.FirstName, d.FirstName, was.FirstName)
+	set(&req.LastName, d.LastName, was.LastName)
+	set(&req.Email, d.Email, was.Email)
+	set(&req.Phone, d.Phone, was.Phone)
+	set(&req.Address, d.Address, was.Address)
+	set(&req.City, d.City, was.City)
+	set(&req.State, d.State, was.State)
+	set(&req.Zip, d.Zip, was.Zip)
+	set(&req.Country, d.Country, was.Country)
+	set(&req.Website, d.Website, was.Website)
+	set(&req.AccountNumber, d.AccountNumber, was.AccountNumber)
+
+	return req
+}
@@ -0,0 +1,91 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package edit
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrEmpty is returned when the edited document was emptied, which cancels the edit.
+var ErrEmpty = errors.New("the document is empty")
+
+// Command returns the editor to run: $VISUAL, then $EDITOR, then vi.
+func Command() string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if v := strings.TrimSpace(os.Getenv(env)); v != "" {
+			return v
+		}
+	}
+
+	return "vi"
+}
+
+// Open runs the editor on a file and waits for it to exit. The editor command
+// goes through the shell so settings such as "code --wait" work.
+func Open(path string) error {
+	cmd := exec.Command("sh", "-c", Command()+` "$1"`, "sh", path)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("editor %q failed: %w", Command(), err)
+	}
+
+	return nil
+}
+
+// Marshal renders a document as YAML with a header comment and a trailing
+// comment on each field named in notes. Lists are written inline.
+func Marshal(v interface{}, header string, notes map[string]string) ([]byte, error) {
+	var node yaml.Node
+	if err := node.Encode(v); err != nil {
+		return nil, fmt.Errorf("unable to render document: %w", err)
+	}
+
+	node.HeadComment = header
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if value.Kind == yaml.SequenceNode {
+			value.Style = yaml.FlowStyle
+		}
+		if note, ok := notes[key.Value]; ok {
+			value.LineComment = note
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return nil, fmt.Errorf("unable to render document: %w", err)
+	}
+	enc.Close()
+
+	return buf.Bytes(), nil
+}
+
+// Unmarshal parses an edited document, rejecting unknown fields so a typo
+// in a field name isn't silently ignored.
+func Unmarshal(data []byte, v interface{}) error {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+
+	if err := dec.Decode(v); err != nil {
+		if err == io.EOF {
+			return ErrEmpty
+		}
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package edit
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+)
+
+// testLedger is an expense entry used by the ledger document tests.
+var testLedger = api.Ledger{
+	ID: 6, Amount: -86.4, Date: "2026-02-18T00:00:00Z", Note: "Client lunch",
+	Contact:  api.Contact{ID: 3, Name: "Starbucks"},
+	Category: api.Category{ID: 4, Name: "Meals", Type: "expense"},
+	Labels:   []api.Label{{ID: 1, Name: "client-x"}},
+}
+
+// TestLedgerRoundTrip verifies a rendered document parses back unchanged and
+// carries its annotations.
+func TestLedgerRoundTrip(t *testing.T) {
+	d := NewLedger(testLedger)
+	data, err := d.Marshal(6)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	for _, want := range []string{"# Editing ledger entry 6.", `date: "2026-02-18" # YYYY-MM-DD`, "labels: [client-x]"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("document is missing %q:\n%s", want, data)
+		}
+	}
+
+	var got Ledger
+	if err := Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, d) {
+		t.Errorf("Unmarshal() = %+v, want %+v", got, d)
+	}
+}
+
+// TestUnmarshalErrors verifies emptied documents cancel and unknown fields are rejected.
+func TestUnmarshalErrors(t *testing.T) {
+	var d Ledger
+	if err := Unmarshal([]byte("# only a comment\n"), &d); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Unmarshal(empty) error = %v, want ErrEmpty", err)
+	}
+	if err := Unmarshal([]byte("amount: 5\nammount: 6\n"), &d); err == nil {
+		t.Error("Unmarshal() accepted an unknown field")
+	}
+}
+
+// TestLedgerResolve verifies names are resolved and every problem is reported.
+func TestLedgerResolve(t *testing.T) {
+	contacts := []api.Contact{{ID: 3, Name: "Starbucks"}, {ID: 9, Name: "Starbucks Reserve"}}
+	categories := []api.Category{{ID: 4, Name: "Meals", Type: "expense"}, {ID: 5, Name: "Sales", Type: "income"}}
+	labels := []api.Label{{ID: 1, Name: "client-x"}, {ID: 2, Name: "travel"}}
+
+	d := Ledger{Amount: 120, Date: "2026-03-01", Contact: "starbucks reserve", Category: "sales", Labels: []string{"Travel", "travel"}}
+	l, err := d.Resolve(contacts, categories, labels)
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if l.Contact.ID != 9 || l.Category.ID != 5 || len(l.Labels) != 1 || l.Labels[0].ID != 2 {
+		t.Errorf("Resolve() = %+v", l)
+	}
+
+	req := LedgerRequest(testLedger, l)
+	if req.Amount == nil || req.Date == nil || req.Contact == nil || req.Category == nil || req.Labels == nil || req.Note == nil {
+		t.Errorf("LedgerRequest() = %+v, want every field set", req)
+	}
+	if req := LedgerRequest(testLedger, testLedger); !reflect.DeepEqual(req, &api.LedgerUpdateRequest{}) {
+		t.Errorf("LedgerRequest() of an unchanged entry = %+v, want empty", req)
+	}
+
+	bad := Ledger{Amount: 10, Date: "March 1", Contact: "Nobody", Category: "Meals", Labels: []string{"nope"}}
+	_, err = bad.Resolve(contacts, categories, labels)
+	if err == nil {
+		t.Fatal("Resolve() accepted an invalid document")
+	}
+	for _, want := range []string{"date:", "contact:", "expense category", "labels:"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Resolve() error %q is missing %q", err, want)
+		}
+	}
+}
+
+// TestContactRequest verifies only changed fields are sent, including cleared ones.
+func TestContactRequest(t *testing.T) {
+	was := NewContact(api.Contact{Name: "Acme", Phone: "555-1234", City: "Seattle"})
+	d := was
+	d.Phone = ""
+	d.City = "Portland"
+
+	req := d.Request(was)
+	want := &api.ContactUpdateRequest{Phone: api.Ptr(""), City: api.Ptr("Portland")}
+	if !reflect.DeepEqual(req, want) {
+		t.Errorf("Request() = %+v, want %+v", req, want)
+	}
+
+	d.Name = " "
+	if d.Validate() == nil {
+		t.Error("Validate() accepted a contact without a name")
+	}
+}
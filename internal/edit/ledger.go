@@ -0,0 +1,181 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package edit
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+)
+
+// ledgerNotes are the comments shown next to each field of a ledger document.
+var ledgerNotes = map[string]string{
+	"amount":   "negative for expenses, positive for income",
+	"date":     "YYYY-MM-DD",
+	"contact":  "contact name",
+	"category": "category name",
+	"labels":   "label names, e.g. [travel, client-x]",
+}
+
+// Ledger is the editable form of a ledger entry, referring to the contact,
+// category and labels by name.
+type Ledger struct {
+	Amount   float64  `yaml:"amount" json:"amount"`
+	Date     string   `yaml:"date" json:"date"`
+	Contact  string   `yaml:"contact" json:"contact"`
+	Category string   `yaml:"category" json:"category"`
+	Labels   []string `yaml:"labels" json:"labels"`
+	Note     string   `yaml:"note" json:"note"`
+}
+
+// NewLedger returns the editable form of a ledger entry.
+func NewLedger(l api.Ledger) Ledger {
+	d := Ledger{
+		Amount:   l.Amount,
+		Date:     l.Date,
+		Contact:  l.Contact.Name,
+		Category: l.Category.Name,
+		Labels:   []string{},
+		Note:     l.Note,
+	}
+	if len(d.Date) > 10 {
+		d.Date = d.Date[:10]
+	}
+	for _, label := range l.Labels {
+		d.Labels = append(d.Labels, label.Name)
+	}
+
+	return d
+}
+
+// Marshal renders the document as annotated YAML for entry id.
+func (d Ledger) Marshal(id uint) ([]byte, error) {
+	header := fmt.Sprintf("Editing ledger entry %d. Save and close the editor to apply the changes;\nempty the file to cancel.", id)
+	return Marshal(d, header, ledgerNotes)
+}
+
+// Resolve checks an edited document and looks up the names it uses. contacts
+// are the candidates for the contact name. All problems are reported together.
+func (d Ledger) Resolve(contacts []api.Contact, categories []api.Category, labels []api.Label) (api.Ledger, error) {
+	l := api.Ledger{Amount: d.Amount, Date: strings.TrimSpace(d.Date), Note: d.Note, Labels: []api.Label{}}
+	var errs []error
+
+	if _, err := time.Parse("2006-01-02", l.Date); err != nil {
+		errs = append(errs, fmt.Errorf("date: %q is not a date, use YYYY-MM-DD", d.Date))
+	}
+
+	if c, ok := findContact(contacts, d.Contact); ok {
+		l.Contact = c
+	} else {
+		errs = append(errs, fmt.Errorf("contact: no contact named %q", d.Contact))
+	}
+
+	if c, ok := findCategory(categories, d.Category); ok {
+		l.Category = c
+		switch categoryType(c.Type) {
+		case "expense":
+			if d.Amount > 0 {
+				errs = append(errs, fmt.Errorf("amount: %v is positive but %q is an expense category", d.Amount, c.Name))
+			}
+		case "income":
+			if d.Amount < 0 {
+				errs = append(errs, fmt.Errorf("amount: %v is negative but %q is an income category", d.Amount, c.Name))
+			}
+		}
+	} else {
+		errs = append(errs, fmt.Errorf("category: no category named %q", d.Category))
+	}
+
+	seen := map[uint]bool{}
+	for _, name := range d.Labels {
+		label, ok := findLabel(labels, name)
+		if !ok {
+			errs = append(errs, fmt.Errorf("labels: no label named %q", name))
+			continue
+		}
+		if !seen[label.ID] {
+			seen[label.ID] = true
+			l.Labels = append(l.Labels, label)
+		}
+	}
+
+	return l, errors.Join(errs...)
+}
+
+// LedgerRequest returns an update that sets only the fields of l that differ
+// from the entry as it was.
+func LedgerRequest(was api.Ledger, l api.Ledger) *api.LedgerUpdateRequest {
+	before, after := NewLedger(was), NewLedger(l)
+	req := &api.LedgerUpdateRequest{}
+
+	if after.Amount != before.Amount {
+		req.Amount = api.Ptr(l.Amount)
+	}
+	if after.Date != before.Date {
+		req.Date = api.Ptr(after.Date)
+	}
+	if l.Contact.ID != was.Contact.ID {
+		req.Contact = &l.Contact
+	}
+	if l.Category.ID != was.Category.ID {
+		req.Category = &l.Category
+	}
+	if strings.Join(after.Labels, "\n") != strings.Join(before.Labels, "\n") {
+		req.Labels = &l.Labels
+	}
+	if after.Note != before.Note {
+		req.Note = api.Ptr(l.Note)
+	}
+
+	return req
+}
+
+// categoryType returns "expense" or "income" for a category type as either
+// the API reads or writes it.
+func categoryType(t string) string {
+	switch strings.ToLower(t) {
+	case "1", "expense":
+		return "expense"
+	case "2", "income":
+		return "income"
+	}
+
+	return t
+}
+
+// findContact returns the contact with a name, ignoring case.
+func findContact(contacts []api.Contact, name string) (api.Contact, bool) {
+	for _, c := range contacts {
+		if strings.EqualFold(c.Name, strings.TrimSpace(name)) {
+			return c, true
+		}
+	}
+
+	return api.Contact{}, false
+}
+
+// findCategory returns the category with a name, ignoring case.
+func findCategory(categories []api.Category, name string) (api.Category, bool) {
+	for _, c := range categories {
+		if strings.EqualFold(c.Name, strings.TrimSpace(name)) {
+			return c, true
+		}
+	}
+
+	return api.Category{}, false
+}
+
+// findLabel returns the label with a name, ignoring case.
+func findLabel(labels []api.Label, name string) (api.Label, bool) {
+	for _, l := range labels {
+		if strings.EqualFold(l.Name, strings.TrimSpace(name)) {
+			return l, true
+		}
+	}
+
+	return api.Label{}, false
+}
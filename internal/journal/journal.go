@@ -0,0 +1,209 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package journal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"time"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/conflict"
+)
+
+// Kinds of object recorded in the journal.
+const (
+	KindLedger   = "ledger"
+	KindContact  = "contact"
+	KindCategory = "category"
+	KindLabel    = "label"
+)
+
+// Actions recorded in the journal.
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+// plurals names each kind when an operation changed several objects.
+var plurals = map[string]string{
+	KindLedger:   "ledger entries",
+	KindContact:  "contacts",
+	KindCategory: "categories",
+	KindLabel:    "labels",
+}
+
+// MaxOps is how many operations a journal keeps; the oldest are dropped first.
+const MaxOps = 500
+
+// Change is one object created, updated or deleted by an operation, with
+// snapshots of the object before and after.
+type Change struct {
+	Kind   string          `json:"kind"`
+	Action string          `json:"action"`
+	ID     uint            `json:"id"`
+	Before json.RawMessage `json:"before,omitempty"`
+	After  json.RawMessage `json:"after,omitempty"`
+}
+
+// Op is one mutating command and every change it made.
+type Op struct {
+	ID       uint      `json:"id"`
+	Time     time.Time `json:"time"`
+	Command  string    `json:"command"`
+	Changes  []Change  `json:"changes"`
+	Backup   string    `json:"backup,omitempty"`
+	UndoOf   uint      `json:"undo_of,omitempty"`
+	UndoneBy uint      `json:"undone_by,omitempty"`
+}
+
+// Journal is the local per-account list of recent mutating operations.
+type Journal struct {
+	path   string
+	NextID uint `json:"next_id"`
+	Ops    []Op `json:"ops"`
+}
+
+// Load reads a journal, returning an empty one if the file does not exist.
+func Load(path string) (*Journal, error) {
+	j := &Journal{path: path, NextID: 1}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return j, nil
+		}
+		return nil, fmt.Errorf("unable to read journal: %w", err)
+	}
+
+	if err := json.Unmarshal(data, j); err != nil {
+		return nil, fmt.Errorf("unable to parse journal: %w", err)
+	}
+
+	return j, nil
+}
+
+// NewChange builds a change from before and after snapshots; either may be nil.
+func NewChange(kind string, action string, id uint, before interface{}, after interface{}) Change {
+	c := Change{Kind: kind, Action: action, ID: id}
+	if before != nil && !reflect.ValueOf(before).IsZero() {
+		c.Before, _ = json.Marshal(before)
+	}
+	if after != nil && !reflect.ValueOf(after).IsZero() {
+		c.After, _ = json.Marshal(after)
+	}
+
+	return c
+}
+
+// Describe returns a short description such as "update ledger 12".
+func (c Change) Describe() string {
+	return fmt.Sprintf("%s %s %d", c.Action, c.Kind, c.ID)
+}
+
+// Summary describes an operation's changes, grouping several of the same kind.
+func (op Op) Summary() string {
+	switch len(op.Changes) {
+	case 0:
+		return "no changes"
+	case 1:
+		return op.Changes[0].Describe()
+	}
+
+	first := op.Changes[0]
+	for _, c := range op.Changes[1:] {
+		if c.Kind != first.Kind || c.Action != first.Action {
+			return fmt.Sprintf("%d changes", len(op.Changes))
+		}
+	}
+
+	return fmt.Sprintf("%s %d %s", first.Action, len(op.Changes), plurals[first.Kind])
+}
+
+// Record assigns the next ID to an operation, appends it and saves the journal.
+func (j *Journal) Record(op Op) (Op, error) {
+	if j.NextID == 0 {
+		j.NextID = 1
+	}
+
+	op.ID = j.NextID
+	j.NextID++
+	if op.Time.IsZero() {
+		op.Time = time.Now().UTC()
+	}
+
+	j.Ops = append(j.Ops, op)
+	if len(j.Ops) > MaxOps {
+		j.Ops = j.Ops[len(j.Ops)-MaxOps:]
+	}
+
+	return op, j.save()
+}
+
+// Find returns the operation with an ID.
+func (j *Journal) Find(id uint) (Op, bool) {
+	for _, op := range j.Ops {
+		if op.ID == id {
+			return op, true
+		}
+	}
+
+	return Op{}, false
+}
+
+// Latest returns the most recent operation that can be undone: one that has
+// not been undone and is not itself an undo.
+func (j *Journal) Latest() (Op, bool) {
+	for i := len(j.Ops) - 1; i >= 0; i-- {
+		if op := j.Ops[i]; op.UndoneBy == 0 && op.UndoOf == 0 {
+			return op, true
+		}
+	}
+
+	return Op{}, false
+}
+
+// MarkUndone records that an operation was reversed by another and saves the journal.
+func (j *Journal) MarkUndone(id uint, by uint) error {
+	for i := range j.Ops {
+		if j.Ops[i].ID == id {
+			j.Ops[i].UndoneBy = by
+			return j.save()
+		}
+	}
+
+	return fmt.Errorf("operation %d not found", id)
+}
+
+// Modified reports whether an object's current state differs from a snapshot,
+// ignoring fields the server maintains itself.
+func Modified(current interface{}, snapshot json.RawMessage) bool {
+	return !conflict.Equal(current, snapshot)
+}
+
+// save writes the journal atomically, readable only by the current user.
+func (j *Journal) save() error {
+	if err := os.MkdirAll(filepath.Dir(j.path), 0700); err != nil {
+		return fmt.Errorf("unable to create journal directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to marshal journal: %w", err)
+	}
+
+	tmp := j.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("unable to write journal: %w", err)
+	}
+
+	if err := os.Rename(tmp, j.path); err != nil {
+		return fmt.Errorf("unable to write journal: %w", err)
+	}
+
+	return nil
+}
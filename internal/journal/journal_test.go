@@ -0,0 +1,86 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package journal
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+)
+
+// TestRecordAndUndo verifies operations get increasing IDs, survive a reload
+// and drop out of Latest once undone.
+func TestRecordAndUndo(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.json")
+	j, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	before := api.Contact{ID: 4, Name: "Acme"}
+	after := api.Contact{ID: 4, Name: "Acme Corp"}
+	first, _ := j.Record(Op{Command: "skyclerk contacts update 4", Changes: []Change{NewChange(KindContact, ActionUpdate, 4, before, after)}})
+	second, err := j.Record(Op{Command: "skyclerk labels create", Changes: []Change{NewChange(KindLabel, ActionCreate, 9, nil, &api.Label{ID: 9})}})
+	if err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if first.ID != 1 || second.ID != 2 || second.Changes[0].Before != nil {
+		t.Fatalf("recorded %+v and %+v", first, second)
+	}
+
+	undo, _ := j.Record(Op{Command: "skyclerk undo", UndoOf: 2})
+	if err := j.MarkUndone(2, undo.ID); err != nil {
+		t.Fatalf("MarkUndone() error = %v", err)
+	}
+
+	j, _ = Load(path)
+	if latest, ok := j.Latest(); !ok || latest.ID != 1 {
+		t.Errorf("Latest() = %+v, want operation 1", latest)
+	}
+	if op, ok := j.Find(2); !ok || op.UndoneBy != 3 {
+		t.Errorf("Find(2) = %+v", op)
+	}
+	if j.NextID != 4 {
+		t.Errorf("NextID = %d, want 4", j.NextID)
+	}
+}
+
+// TestModified verifies server-maintained fields don't count as modifications.
+func TestModified(t *testing.T) {
+	snap := NewChange(KindLedger, ActionUpdate, 1, nil, api.Ledger{
+		ID: 1, Amount: -21, Note: "GitHub",
+		Labels: []api.Label{{ID: 2, Name: "Software", Count: 3}},
+	}).After
+
+	current := api.Ledger{
+		ID: 1, Amount: -21, Note: "GitHub", UpdatedAt: "2026-10-18T10:00:00Z",
+		Labels: []api.Label{{ID: 2, Name: "Software", Count: 7}},
+		Files:  []api.File{{ID: 5}},
+	}
+	if Modified(current, snap) {
+		t.Error("Modified() = true for an entry that only changed server fields")
+	}
+
+	current.Note = "GitHub Team"
+	if !Modified(current, snap) {
+		t.Error("Modified() = false after the note changed")
+	}
+}
+
+// TestSummary verifies operations on several objects are grouped.
+func TestSummary(t *testing.T) {
+	op := Op{Changes: []Change{
+		{Kind: KindLedger, Action: ActionDelete, ID: 1},
+		{Kind: KindLedger, Action: ActionDelete, ID: 2},
+	}}
+	if got := op.Summary(); got != "delete 2 ledger entries" {
+		t.Errorf("Summary() = %q", got)
+	}
+
+	op.Changes = op.Changes[:1]
+	if got := op.Summary(); got != "delete ledger 1" {
+		t.Errorf("Summary() = %q", got)
+	}
+}
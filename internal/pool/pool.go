@@ -0,0 +1,48 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+// Package pool runs independent calls, such as uploads or updates, with a
+// bounded number of concurrent workers.
+package pool
+
+import "sync"
+
+// Map calls fn for every item using at most workers concurrent goroutines.
+// onResult (if set) is called as each call finishes, never concurrently, so
+// it can print progress or update shared state; the returned results are in
+// item order.
+func Map[T, R any](items []T, workers int, fn func(T) R, onResult func(R)) []R {
+	if workers < 1 {
+		workers = 1
+	}
+
+	results := make([]R, len(items))
+	indexes := make(chan int)
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = fn(items[i])
+
+				if onResult != nil {
+					mu.Lock()
+					onResult(results[i])
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	for i := range items {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
@@ -0,0 +1,39 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package pool
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestMap verifies results keep item order, every result is reported and no
+// more than the given number of calls run at once.
+func TestMap(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5, 6, 7, 8}
+
+	var running, peak atomic.Int32
+	reported := 0
+	results := Map(items, 3, func(n int) int {
+		if now := running.Add(1); now > peak.Load() {
+			peak.Store(now)
+		}
+		time.Sleep(time.Millisecond)
+		running.Add(-1)
+		return n * n
+	}, func(int) { reported++ })
+
+	for i, n := range items {
+		if results[i] != n*n {
+			t.Errorf("results[%d] = %d, want %d", i, results[i], n*n)
+		}
+	}
+	if reported != len(items) {
+		t.Errorf("onResult called %d times, want %d", reported, len(items))
+	}
+	if peak.Load() > 3 {
+		t.Errorf("%d calls ran at once, want at most 3", peak.Load())
+	}
+}
@@ -0,0 +1,293 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package quickadd
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+	"github.com/cloudmanic/skyclerk-cli/internal/dates"
+)
+
+// maxDateWords is the most words a date phrase such as "mar 5 2026" spans.
+const maxDateWords = 4
+
+// amountPattern matches an amount such as -4.50, +1250 or $12.
+var amountPattern = regexp.MustCompile(`^([+-]?)\$?(\d+(?:\.\d+)?|\.\d+)$`)
+
+// notePattern matches the "note:" marker that starts the trailing note.
+var notePattern = regexp.MustCompile(`(?i)(^|\s)note:`)
+
+// Entry is a ledger entry written on one line, such as
+// "-4.50 Starbucks Meals #client-x yesterday note: team sync", before its
+// names are looked up.
+type Entry struct {
+	Amount float64
+	Signed bool     // the amount was written with a + or - sign
+	Date   string   // YYYY-MM-DD, today when no date was given
+	Words  []string // words naming the contact, category and untagged labels
+	Labels []string // names given as #label
+	Note   string
+}
+
+// Parse splits a line into its amount, #labels, date phrase, trailing note
+// and the remaining words. Dates are read with p, in any form it accepts.
+func Parse(line string, p dates.Parser) (Entry, error) {
+	var e Entry
+
+	if loc := notePattern.FindStringIndex(line); loc != nil {
+		e.Note = strings.TrimSpace(line[loc[1]:])
+		line = line[:loc[0]]
+	}
+
+	var rest []string
+	hasAmount := false
+	for _, word := range strings.Fields(line) {
+		if m := amountPattern.FindStringSubmatch(word); m != nil && !hasAmount {
+			amount, err := strconv.ParseFloat(m[2], 64)
+			if err != nil {
+				return Entry{}, fmt.Errorf("%q is not an amount", word)
+			}
+			if m[1] == "-" {
+				amount = -amount
+			}
+			e.Amount, e.Signed, hasAmount = amount, m[1] != "", true
+			continue
+		}
+		if strings.HasPrefix(word, "#") {
+			name := strings.TrimPrefix(word, "#")
+			if name == "" {
+				return Entry{}, errors.New("# must be followed by a label name")
+			}
+			e.Labels = append(e.Labels, name)
+			continue
+		}
+		rest = append(rest, word)
+	}
+	if !hasAmount {
+		return Entry{}, errors.New("no amount: start with one such as -4.50")
+	}
+	if e.Amount == 0 {
+		return Entry{}, errors.New("the amount can't be zero")
+	}
+
+	words, date, err := findDate(rest, p)
+	if err != nil {
+		return Entry{}, err
+	}
+	e.Words, e.Date = words, date
+
+	return e, nil
+}
+
+// findDate removes the longest date phrase from words, preferring the
+// leftmost, and returns the remaining words and the day. Without a phrase the
+// day is today.
+func findDate(words []string, p dates.Parser) ([]string, string, error) {
+	for i := range words {
+		for n := min(maxDateWords, len(words)-i); n > 0; n-- {
+			day, err := p.Day(strings.Join(words[i:i+n], " "))
+			if err != nil {
+				continue
+			}
+			rest := append(append([]string{}, words[:i]...), words[i+n:]...)
+			return rest, day, nil
+		}
+	}
+
+	day, err := p.Day("today")
+	return words, day, err
+}
+
+// match is one way of reading the words as a contact, a category and labels.
+type match struct {
+	contact  api.Contact
+	category api.Category
+	labels   []api.Label
+}
+
+// Resolve looks up the entry's names and returns the ledger entry it
+// describes. The words must split into a contact name, a category name and
+// any number of label names. An unsigned amount takes the category's sign.
+func (e Entry) Resolve(contacts []api.Contact, categories []api.Category, labels []api.Label) (api.Ledger, error) {
+	l := api.Ledger{Amount: e.Amount, Date: e.Date, Note: e.Note, Labels: []api.Label{}}
+	var errs []error
+
+	seen := map[uint]bool{}
+	for _, name := range e.Labels {
+		label, ok := findLabel(labels, name)
+		if !ok {
+			errs = append(errs, fmt.Errorf("no label named %q", name))
+			continue
+		}
+		if !seen[label.ID] {
+			seen[label.ID] = true
+			l.Labels = append(l.Labels, label)
+		}
+	}
+
+	m, err := e.split(contacts, categories, labels)
+	if err != nil {
+		return api.Ledger{}, errors.Join(append(errs, err)...)
+	}
+	l.Contact, l.Category = m.contact, m.category
+	for _, label := range m.labels {
+		if !seen[label.ID] {
+			seen[label.ID] = true
+			l.Labels = append(l.Labels, label)
+		}
+	}
+
+	switch categoryType(m.category.Type) {
+	case "expense":
+		if !e.Signed {
+			l.Amount = -l.Amount
+		} else if l.Amount > 0 {
+			errs = append(errs, fmt.Errorf("%v is positive but %q is an expense category", e.Amount, m.category.Name))
+		}
+	case "income":
+		if l.Amount < 0 {
+			errs = append(errs, fmt.Errorf("%v is negative but %q is an income category", e.Amount, m.category.Name))
+		}
+	}
+
+	return l, errors.Join(errs...)
+}
+
+// split finds the readings of the words that use the fewest labels, and fails
+// unless exactly one contact and category pair remains.
+func (e Entry) split(contacts []api.Contact, categories []api.Category, labels []api.Label) (match, error) {
+	words := e.Words
+	if len(words) < 2 {
+		return match{}, fmt.Errorf("name a contact and a category (got %q)", strings.Join(words, " "))
+	}
+
+	var best []match
+	bestLabels := len(words) + 1
+	for a := 0; a < len(words); a++ {
+		for b := a + 1; b <= len(words); b++ {
+			contact, ok := findContact(contacts, strings.Join(words[a:b], " "))
+			if !ok {
+				continue
+			}
+			for c := 0; c < len(words); c++ {
+				for d := c + 1; d <= len(words); d++ {
+					if c < b && a < d {
+						continue
+					}
+					category, ok := findCategory(categories, strings.Join(words[c:d], " "))
+					if !ok {
+						continue
+					}
+					extra, ok := leftoverLabels(words, labels, a, b, c, d)
+					if !ok || len(extra) > bestLabels {
+						continue
+					}
+					if len(extra) < bestLabels {
+						best, bestLabels = nil, len(extra)
+					}
+					best = append(best, match{contact: contact, category: category, labels: extra})
+				}
+			}
+		}
+	}
+
+	switch {
+	case len(best) == 0:
+		return match{}, fmt.Errorf("no contact and category match %q", strings.Join(words, " "))
+	case len(best) > 1 && !sameMatch(best):
+		var options []string
+		for _, m := range best {
+			options = append(options, fmt.Sprintf("%s / %s", m.contact.Name, m.category.Name))
+		}
+		return match{}, fmt.Errorf("%q is ambiguous: it could be %s", strings.Join(words, " "), strings.Join(options, " or "))
+	}
+
+	return best[0], nil
+}
+
+// leftoverLabels returns the labels named by the words outside the contact
+// span [a,b) and category span [c,d), failing if any of them isn't a label.
+func leftoverLabels(words []string, labels []api.Label, a, b, c, d int) ([]api.Label, bool) {
+	var out []api.Label
+	for i, word := range words {
+		if (i >= a && i < b) || (i >= c && i < d) {
+			continue
+		}
+		label, ok := findLabel(labels, word)
+		if !ok {
+			return nil, false
+		}
+		out = append(out, label)
+	}
+
+	return out, true
+}
+
+// sameMatch reports whether every reading names the same contact and category.
+func sameMatch(matches []match) bool {
+	for _, m := range matches[1:] {
+		if m.contact.ID != matches[0].contact.ID || m.category.ID != matches[0].category.ID {
+			return false
+		}
+	}
+
+	return true
+}
+
+// categoryType returns "expense" or "income" for a category type as either
+// the API reads or writes it.
+func categoryType(t string) string {
+	switch strings.ToLower(t) {
+	case "1", "expense":
+		return "expense"
+	case "2", "income":
+		return "income"
+	}
+
+	return t
+}
+
+// sameName reports whether a name matches typed words, ignoring case and
+// repeated spaces.
+func sameName(name string, words string) bool {
+	return strings.EqualFold(strings.Join(strings.Fields(name), " "), words)
+}
+
+// findContact returns the contact with a name, ignoring case.
+func findContact(contacts []api.Contact, name string) (api.Contact, bool) {
+	for _, c := range contacts {
+		if sameName(c.Name, name) {
+			return c, true
+		}
+	}
+
+	return api.Contact{}, false
+}
+
+// findCategory returns the category with a name, ignoring case.
+func findCategory(categories []api.Category, name string) (api.Category, bool) {
+	for _, c := range categories {
+		if sameName(c.Name, name) {
+			return c, true
+		}
+	}
+
+	return api.Category{}, false
+}
+
+// findLabel returns the label with a name, ignoring case.
+func findLabel(labels []api.Label, name string) (api.Label, bool) {
+	for _, l := range labels {
+		if sameName(l.Name, name) {
+			return l, true
+		}
+	}
+
+	return api.Label{}, false
+}
@@ -0,0 +1,104 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package quickadd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+	"github.com/cloudmanic/skyclerk-cli/internal/dates"
+)
+
+// parser reads dates relative to Sunday 2026-10-18 in the en-US order.
+var parser = dates.Parser{
+	Now:    time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC),
+	Locale: func() string { return "en-US" },
+}
+
+var (
+	contacts   = []api.Contact{{ID: 1, Name: "Starbucks"}, {ID: 2, Name: "Acme Corp"}, {ID: 3, Name: "Acme"}}
+	categories = []api.Category{{ID: 4, Name: "Meals", Type: "expense"}, {ID: 5, Name: "Sales", Type: "income"}, {ID: 6, Name: "Office Supplies", Type: "expense"}}
+	labels     = []api.Label{{ID: 7, Name: "client-x"}, {ID: 8, Name: "travel"}}
+)
+
+// TestParse verifies each part of a line is picked out.
+func TestParse(t *testing.T) {
+	tests := []struct {
+		line string
+		want Entry
+	}{
+		{"-4.50 Starbucks Meals #client-x yesterday note: team sync", Entry{Amount: -4.5, Signed: true, Date: "2026-10-17", Words: []string{"Starbucks", "Meals"}, Labels: []string{"client-x"}, Note: "team sync"}},
+		{"12 Acme Corp Office Supplies last friday", Entry{Amount: 12, Date: "2026-10-16", Words: []string{"Acme", "Corp", "Office", "Supplies"}}},
+		{"Starbucks $3 Meals mar 5 2026", Entry{Amount: 3, Date: "2026-03-05", Words: []string{"Starbucks", "Meals"}}},
+		{"+1250 Acme Sales NOTE: license: pro", Entry{Amount: 1250, Signed: true, Date: "2026-10-18", Words: []string{"Acme", "Sales"}, Note: "license: pro"}},
+	}
+
+	for _, tt := range tests {
+		got, err := Parse(tt.line, parser)
+		if err != nil {
+			t.Errorf("Parse(%q) error = %v", tt.line, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Parse(%q) = %+v, want %+v", tt.line, got, tt.want)
+		}
+	}
+
+	for _, line := range []string{"Starbucks Meals", "0 Starbucks Meals", "-4 Starbucks Meals #"} {
+		if _, err := Parse(line, parser); err == nil {
+			t.Errorf("Parse(%q) accepted an invalid line", line)
+		}
+	}
+}
+
+// TestResolve verifies names are matched, signs follow the category and
+// unclear lines are refused.
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		line     string
+		contact  uint
+		category uint
+		amount   float64
+		labels   int
+	}{
+		{"-4.50 Starbucks Meals #client-x", 1, 4, -4.5, 1},
+		{"4.50 starbucks meals travel", 1, 4, -4.5, 1},
+		{"12 Acme Corp Office Supplies", 2, 6, -12, 0},
+		{"1250 Sales Acme", 3, 5, 1250, 0},
+	}
+	for _, tt := range tests {
+		e, err := Parse(tt.line, parser)
+		if err != nil {
+			t.Fatalf("Parse(%q) error = %v", tt.line, err)
+		}
+		l, err := e.Resolve(contacts, categories, labels)
+		if err != nil {
+			t.Errorf("Resolve(%q) error = %v", tt.line, err)
+			continue
+		}
+		if l.Contact.ID != tt.contact || l.Category.ID != tt.category || l.Amount != tt.amount || len(l.Labels) != tt.labels {
+			t.Errorf("Resolve(%q) = %+v", tt.line, l)
+		}
+	}
+
+	errs := map[string]string{
+		"-4.50 Starbucks Mealz":    "no contact and category",
+		"-4.50 Starbucks Meals #x": "no label named",
+		"+4.50 Starbucks Meals":    "expense category",
+		"-5 Acme Sales":            "income category",
+		"-5 Starbucks":             "name a contact and a category",
+	}
+	for line, want := range errs {
+		e, err := Parse(line, parser)
+		if err == nil {
+			_, err = e.Resolve(contacts, categories, labels)
+		}
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%q: error = %v, want %q", line, err, want)
+		}
+	}
+}
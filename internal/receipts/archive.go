@@ -0,0 +1,314 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package receipts
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+	"github.com/cloudmanic/skyclerk-cli/internal/pool"
+)
+
+// ArchiveIndexName is the index CSV written at the root of an archive.
+const ArchiveIndexName = "index.csv"
+
+// Downloader streams a file's contents; *api.Client implements it.
+type Downloader interface {
+	DownloadFile(file *api.File, thumb bool, w io.Writer) (*api.Download, error)
+}
+
+// ArchiveItem is one attached file and where it goes in the archive.
+type ArchiveItem struct {
+	Ledger api.Ledger
+	File   api.File
+	Path   string
+
+	// KnownSHA256 is the file's content hash from the upload manifest, if
+	// known. It decides whether a copy left by an earlier run is complete.
+	KnownSHA256 string
+}
+
+// ArchiveResult is the outcome of fetching one archive item.
+type ArchiveResult struct {
+	ArchiveItem
+	SHA256  string
+	Bytes   int64
+	Resumed bool
+	Err     error
+}
+
+// PlanArchive lays out every file attached to the entries as
+// year/category/date_contact_amount_filename, in date order. Names that
+// would collide get the file ID appended.
+func PlanArchive(ledgers []api.Ledger) []ArchiveItem {
+	sorted := append([]api.Ledger(nil), ledgers...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Date != sorted[j].Date {
+			return sorted[i].Date < sorted[j].Date
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	var items []ArchiveItem
+	used := map[string]bool{}
+	for _, l := range sorted {
+		date := l.Date
+		if len(date) > 10 {
+			date = date[:10]
+		}
+		year := "unknown"
+		if len(date) >= 4 {
+			year = date[:4]
+		}
+
+		category := SanitizeName(l.Category.Name)
+		if category == "" {
+			category = "Uncategorized"
+		}
+
+		files := append([]api.File(nil), l.Files...)
+		sort.Slice(files, func(i, j int) bool { return files[i].ID < files[j].ID })
+
+		for _, f := range files {
+			name := SanitizeName(f.Name)
+			if name == "" {
+				name = fmt.Sprintf("file-%d", f.ID)
+			}
+
+			base := strings.Join([]string{date, SanitizeName(l.Contact.Name), fmt.Sprintf("%.2f", math.Abs(l.Amount)), name}, "_")
+			p := path.Join(year, category, base)
+			if used[strings.ToLower(p)] {
+				ext := path.Ext(base)
+				p = path.Join(year, category, fmt.Sprintf("%s-%d%s", strings.TrimSuffix(base, ext), f.ID, ext))
+			}
+			used[strings.ToLower(p)] = true
+
+			items = append(items, ArchiveItem{Ledger: l, File: f, Path: p})
+		}
+	}
+
+	return items
+}
+
+// SanitizeName makes s safe as a single path component on any OS.
+func SanitizeName(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r < 32 || strings.ContainsRune(`/\:*?"<>|`, r):
+			b.WriteRune('-')
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	return strings.Trim(strings.TrimSpace(b.String()), ".")
+}
+
+// FetchArchive downloads every item into dir using at most parallel workers.
+// Items already present and verified by complete are kept, so an interrupted
+// run resumes where it stopped. Downloads go to a .part file first and only
+// take their final name once complete and verified. onResult (if set) is
+// called as each item finishes; the returned results are in item order.
+func FetchArchive(d Downloader, dir string, items []ArchiveItem, parallel int, onResult func(ArchiveResult)) []ArchiveResult {
+	return pool.Map(items, parallel, func(item ArchiveItem) ArchiveResult {
+		return fetchItem(d, dir, item)
+	}, onResult)
+}
+
+// fetchItem downloads one item unless a complete copy is already on disk.
+func fetchItem(d Downloader, dir string, item ArchiveItem) ArchiveResult {
+	res := ArchiveResult{ArchiveItem: item}
+	dest := filepath.Join(dir, filepath.FromSlash(item.Path))
+
+	if hash, size, ok := complete(dest, item); ok {
+		res.SHA256, res.Bytes, res.Resumed = hash, size, true
+		return res
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		res.Err = fmt.Errorf("unable to create directory: %w", err)
+		return res
+	}
+
+	part := dest + ".part"
+	out, err := os.Create(part)
+	if err != nil {
+		res.Err = fmt.Errorf("unable to create file: %w", err)
+		return res
+	}
+
+	file := item.File
+	dl, err := d.DownloadFile(&file, false, out)
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(part)
+		res.Err = err
+		return res
+	}
+
+	if err := os.Rename(part, dest); err != nil {
+		os.Remove(part)
+		res.Err = fmt.Errorf("unable to save file: %w", err)
+		return res
+	}
+
+	res.SHA256 = dl.SHA256
+	res.Bytes = dl.Bytes
+	return res
+}
+
+// complete reports whether dest already holds the item's content, returning
+// its hash and size. The known hash must match when there is one; otherwise
+// the size must match the API's, and a file of unknown size is fetched again.
+func complete(dest string, item ArchiveItem) (string, int64, bool) {
+	info, err := os.Stat(dest)
+	if err != nil || (item.File.Size != 0 && info.Size() != item.File.Size) {
+		return "", 0, false
+	}
+	if item.KnownSHA256 == "" && item.File.Size == 0 {
+		return "", 0, false
+	}
+
+	hash, err := HashFile(dest)
+	if err != nil || (item.KnownSHA256 != "" && hash != item.KnownSHA256) {
+		return "", 0, false
+	}
+
+	return hash, info.Size(), true
+}
+
+// WriteArchiveIndex writes a CSV row per fetched file linking it to its ledger entry.
+func WriteArchiveIndex(w io.Writer, results []ArchiveResult) error {
+	out := csv.NewWriter(w)
+	out.Write([]string{"path", "ledger_id", "date", "contact", "category", "amount", "note", "file_id", "file_name", "size", "sha256"})
+
+	for _, r := range results {
+		if r.Err != nil {
+			continue
+		}
+
+		date := r.Ledger.Date
+		if len(date) > 10 {
+			date = date[:10]
+		}
+
+		out.Write([]string{
+			r.Path,
+			strconv.FormatUint(uint64(r.Ledger.ID), 10),
+			date,
+			r.Ledger.Contact.Name,
+			r.Ledger.Category.Name,
+			strconv.FormatFloat(r.Ledger.Amount, 'f', 2, 64),
+			r.Ledger.Note,
+			strconv.FormatUint(uint64(r.File.ID), 10),
+			r.File.Name,
+			strconv.FormatInt(r.Bytes, 10),
+			r.SHA256,
+		})
+	}
+
+	out.Flush()
+	return out.Error()
+}
+
+// ZipArchive writes the fetched files in dir, plus the index, to a zip file.
+// The zip is written to a temporary name and renamed once complete.
+func ZipArchive(dir string, zipPath string, results []ArchiveResult) error {
+	tmp := zipPath + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("unable to create zip: %w", err)
+	}
+
+	err = writeZip(f, dir, results)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+
+	if err := os.Rename(tmp, zipPath); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("unable to save zip: %w", err)
+	}
+
+	return nil
+}
+
+// writeZip streams the index and every fetched file into w.
+func writeZip(w io.Writer, dir string, results []ArchiveResult) error {
+	zw := zip.NewWriter(w)
+
+	index, err := zw.CreateHeader(&zip.FileHeader{Name: ArchiveIndexName, Method: zip.Deflate, Modified: time.Now()})
+	if err != nil {
+		return fmt.Errorf("unable to write zip: %w", err)
+	}
+	if err := WriteArchiveIndex(index, results); err != nil {
+		return fmt.Errorf("unable to write index: %w", err)
+	}
+
+	for _, r := range results {
+		if r.Err != nil {
+			continue
+		}
+
+		if err := addZipFile(zw, filepath.Join(dir, filepath.FromSlash(r.Path)), r.Path); err != nil {
+			return err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("unable to write zip: %w", err)
+	}
+
+	return nil
+}
+
+// addZipFile copies one file into the zip under name.
+func addZipFile(zw *zip.Writer, src string, name string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("unable to read %s: %w", src, err)
+	}
+	defer in.Close()
+
+	info, err := in.Stat()
+	if err != nil {
+		return fmt.Errorf("unable to read %s: %w", src, err)
+	}
+
+	header, err := zip.FileInfoHeader(info)
+	if err != nil {
+		return fmt.Errorf("unable to add %s to zip: %w", name, err)
+	}
+	header.Name = name
+	header.Method = zip.Deflate
+
+	out, err := zw.CreateHeader(header)
+	if err != nil {
+		return fmt.Errorf("unable to add %s to zip: %w", name, err)
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		return fmt.Errorf("unable to add %s to zip: %w", name, err)
+	}
+
+	return nil
+}
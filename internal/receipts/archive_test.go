@@ -0,0 +1,162 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package receipts
+
+import (
+	"archive/zip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+)
+
+// fakeDownloader serves each file's name as its content and fails names containing "bad".
+type fakeDownloader struct {
+	mu    sync.Mutex
+	calls []uint
+}
+
+// DownloadFile writes the file name to w.
+func (f *fakeDownloader) DownloadFile(file *api.File, thumb bool, w io.Writer) (*api.Download, error) {
+	f.mu.Lock()
+	f.calls = append(f.calls, file.ID)
+	f.mu.Unlock()
+
+	if strings.Contains(file.Name, "bad") {
+		return nil, errors.New("checksum mismatch")
+	}
+
+	n, _ := io.WriteString(w, file.Name)
+	return &api.Download{Bytes: int64(n), SHA256: HashBytes([]byte(file.Name))}, nil
+}
+
+// archiveLedgers returns two entries: one with colliding file names, one with unsafe names.
+func archiveLedgers() []api.Ledger {
+	return []api.Ledger{
+		{
+			ID: 2, Date: "2026-03-01T00:00:00Z", Amount: -12.5,
+			Contact:  api.Contact{Name: "AT&T / Wireless"},
+			Category: api.Category{Name: "Phone"},
+			Files:    []api.File{{ID: 21, Name: "bill.pdf", Size: 8}},
+		},
+		{
+			ID: 1, Date: "2026-02-18T00:00:00Z", Amount: -86.4,
+			Contact:  api.Contact{Name: "Starbucks"},
+			Category: api.Category{Name: "Meals"},
+			Files:    []api.File{{ID: 12, Name: "r.jpg", Size: 5}, {ID: 11, Name: "r.jpg", Size: 5}},
+		},
+	}
+}
+
+// TestPlanArchive verifies the year/category layout, name sanitizing and collision suffixes.
+func TestPlanArchive(t *testing.T) {
+	items := PlanArchive(archiveLedgers())
+
+	var paths []string
+	for _, it := range items {
+		paths = append(paths, it.Path)
+	}
+
+	want := []string{
+		"2026/Meals/2026-02-18_Starbucks_86.40_r.jpg",
+		"2026/Meals/2026-02-18_Starbucks_86.40_r-12.jpg",
+		"2026/Phone/2026-03-01_AT&T - Wireless_12.50_bill.pdf",
+	}
+	if strings.Join(paths, "\n") != strings.Join(want, "\n") {
+		t.Errorf("PlanArchive() paths =\n%s\nwant\n%s", strings.Join(paths, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+// TestFetchArchiveResumes verifies complete files are not downloaded again,
+// copies that can't be verified are, failures are reported without stopping
+// the rest and no .part files remain.
+func TestFetchArchiveResumes(t *testing.T) {
+	dir := t.TempDir()
+	ledgers := archiveLedgers()
+	ledgers[0].Files = append(ledgers[0].Files, api.File{ID: 22, Name: "bad.pdf", Size: 7}, api.File{ID: 23, Name: "fax.tif"}, api.File{ID: 24, Name: "scan.png", Size: 8})
+	items := PlanArchive(ledgers)
+	items[5].KnownSHA256 = HashBytes([]byte("scan.png"))
+
+	// A finished file from an earlier run, and a half-written one.
+	done := filepath.Join(dir, filepath.FromSlash(items[0].Path))
+	os.MkdirAll(filepath.Dir(done), 0755)
+	os.WriteFile(done, []byte("r.jpg"), 0644)
+	os.WriteFile(filepath.Join(dir, filepath.FromSlash(items[1].Path)), []byte("r"), 0644)
+
+	// Copies of unknown size, or of the right size but the wrong content.
+	fax := filepath.Join(dir, filepath.FromSlash(items[4].Path))
+	os.MkdirAll(filepath.Dir(fax), 0755)
+	os.WriteFile(fax, []byte("fax"), 0644)
+	os.WriteFile(filepath.Join(dir, filepath.FromSlash(items[5].Path)), []byte("scan.pnx"), 0644)
+
+	d := &fakeDownloader{}
+	results := FetchArchive(d, dir, items, 3, nil)
+
+	sort.Slice(d.calls, func(i, j int) bool { return d.calls[i] < d.calls[j] })
+	if len(d.calls) != 5 || d.calls[0] != 12 {
+		t.Errorf("downloaded %v, want 12, 21, 22, 23 and 24 but not 11", d.calls)
+	}
+
+	if !results[0].Resumed || results[0].SHA256 != HashBytes([]byte("r.jpg")) {
+		t.Errorf("results[0] = %+v, want resumed with hash", results[0])
+	}
+	if results[3].Err == nil || results[2].Err != nil {
+		t.Errorf("errors = %v, %v; want only bad.pdf to fail", results[2].Err, results[3].Err)
+	}
+
+	if data, _ := os.ReadFile(filepath.Join(dir, filepath.FromSlash(items[1].Path))); string(data) != "r.jpg" {
+		t.Errorf("partial file was not replaced: %q", data)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, filepath.FromSlash(items[5].Path))); string(data) != "scan.png" {
+		t.Errorf("file with the wrong hash was not replaced: %q", data)
+	}
+
+	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if strings.HasSuffix(path, ".part") {
+			t.Errorf("leftover %s", path)
+		}
+		return nil
+	})
+}
+
+// TestZipArchive verifies the zip holds the index and every fetched file.
+func TestZipArchive(t *testing.T) {
+	dir := t.TempDir()
+	items := PlanArchive(archiveLedgers())
+	results := FetchArchive(&fakeDownloader{}, dir, items, 2, nil)
+
+	zipPath := filepath.Join(t.TempDir(), "receipts.zip")
+	if err := ZipArchive(dir, zipPath, results); err != nil {
+		t.Fatalf("ZipArchive() error = %v", err)
+	}
+
+	zr, err := zip.OpenReader(zipPath)
+	if err != nil {
+		t.Fatalf("OpenReader() error = %v", err)
+	}
+	defer zr.Close()
+
+	names := map[string]*zip.File{}
+	for _, f := range zr.File {
+		names[f.Name] = f
+	}
+	if len(names) != 4 || names[ArchiveIndexName] == nil || names[items[2].Path] == nil {
+		t.Fatalf("zip entries = %v", names)
+	}
+
+	rc, _ := names[ArchiveIndexName].Open()
+	index, _ := io.ReadAll(rc)
+	rc.Close()
+
+	lines := strings.Split(strings.TrimSpace(string(index)), "\n")
+	if len(lines) != 4 || !strings.HasPrefix(lines[1], items[0].Path+",1,2026-02-18,Starbucks,Meals,-86.40,,11,r.jpg,5,") {
+		t.Errorf("index =\n%s", index)
+	}
+}
@@ -0,0 +1,136 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package receipts
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+)
+
+// DefaultReceiptThreshold is the expense amount above which a receipt is required.
+const DefaultReceiptThreshold = 75
+
+// Policy decides which ledger entries must have a receipt attached.
+type Policy struct {
+	// Threshold requires a receipt for expenses larger than this; 0 disables it.
+	Threshold float64
+
+	// RequireCategories are category names or IDs whose entries always need a receipt.
+	RequireCategories []string
+
+	// ExemptLabels are label names or IDs that excuse an entry from every rule.
+	ExemptLabels []string
+}
+
+// Violation is a ledger entry missing a required receipt.
+type Violation struct {
+	Ledger api.Ledger `json:"ledger"`
+	Reason string     `json:"reason"`
+}
+
+// ViolationGroup is the violations for one contact in one month.
+type ViolationGroup struct {
+	Month      string      `json:"month"`
+	Contact    string      `json:"contact"`
+	Total      float64     `json:"total"`
+	Violations []Violation `json:"violations"`
+}
+
+// Check returns why an entry needs a receipt it doesn't have, or "" when it passes.
+func (p Policy) Check(l api.Ledger) string {
+	if len(l.Files) > 0 {
+		return ""
+	}
+
+	for _, label := range l.Labels {
+		if matchesRef(p.ExemptLabels, label.ID, label.Name) {
+			return ""
+		}
+	}
+
+	if matchesRef(p.RequireCategories, l.Category.ID, l.Category.Name) {
+		return fmt.Sprintf("category %s requires a receipt", l.Category.Name)
+	}
+
+	if p.Threshold > 0 && l.Amount < 0 && math.Abs(l.Amount) > p.Threshold {
+		return fmt.Sprintf("expense over %s", strconv.FormatFloat(p.Threshold, 'f', -1, 64))
+	}
+
+	return ""
+}
+
+// matchesRef reports whether a name or ID appears in refs (names ignore case).
+func matchesRef(refs []string, id uint, name string) bool {
+	for _, ref := range refs {
+		ref = strings.TrimSpace(ref)
+		if strings.EqualFold(ref, name) || ref == strconv.FormatUint(uint64(id), 10) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Audit checks every entry against the policy.
+func Audit(ledgers []api.Ledger, p Policy) []Violation {
+	var violations []Violation
+	for _, l := range ledgers {
+		if reason := p.Check(l); reason != "" {
+			violations = append(violations, Violation{Ledger: l, Reason: reason})
+		}
+	}
+
+	return violations
+}
+
+// GroupViolations groups violations by month (oldest first) and then by
+// contact name, with entries in date order inside each group.
+func GroupViolations(violations []Violation) []ViolationGroup {
+	byKey := map[[2]string]*ViolationGroup{}
+	var groups []*ViolationGroup
+
+	for _, v := range violations {
+		month := ""
+		if len(v.Ledger.Date) >= 7 {
+			month = v.Ledger.Date[:7]
+		}
+
+		key := [2]string{month, v.Ledger.Contact.Name}
+		g, ok := byKey[key]
+		if !ok {
+			g = &ViolationGroup{Month: month, Contact: v.Ledger.Contact.Name}
+			byKey[key] = g
+			groups = append(groups, g)
+		}
+		g.Violations = append(g.Violations, v)
+		g.Total += v.Ledger.Amount
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Month != groups[j].Month {
+			return groups[i].Month < groups[j].Month
+		}
+		return strings.ToLower(groups[i].Contact) < strings.ToLower(groups[j].Contact)
+	})
+
+	out := make([]ViolationGroup, len(groups))
+	for i, g := range groups {
+		sort.SliceStable(g.Violations, func(a, b int) bool {
+			va, vb := g.Violations[a].Ledger, g.Violations[b].Ledger
+			if va.Date != vb.Date {
+				return va.Date < vb.Date
+			}
+			return va.ID < vb.ID
+		})
+		g.Total = math.Round(g.Total*100) / 100
+		out[i] = *g
+	}
+
+	return out
+}
@@ -0,0 +1,67 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package receipts
+
+import (
+	"testing"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+)
+
+// TestPolicyCheck verifies the threshold, required categories, exempt labels
+// and attached files each decide the outcome.
+func TestPolicyCheck(t *testing.T) {
+	p := Policy{Threshold: 75, RequireCategories: []string{"travel"}, ExemptLabels: []string{"9"}}
+
+	meals := api.Category{ID: 1, Name: "Meals"}
+	travel := api.Category{ID: 2, Name: "Travel"}
+
+	tests := []struct {
+		name   string
+		ledger api.Ledger
+		fails  bool
+	}{
+		{"small expense", api.Ledger{Amount: -75, Category: meals}, false},
+		{"large expense", api.Ledger{Amount: -75.01, Category: meals}, true},
+		{"large income", api.Ledger{Amount: 5000, Category: meals}, false},
+		{"required category by name", api.Ledger{Amount: -5, Category: travel}, true},
+		{"receipt attached", api.Ledger{Amount: -500, Category: travel, Files: []api.File{{ID: 1}}}, false},
+		{"exempt label by ID", api.Ledger{Amount: -500, Category: travel, Labels: []api.Label{{ID: 9, Name: "Per diem"}}}, false},
+	}
+
+	for _, tt := range tests {
+		if reason := p.Check(tt.ledger); (reason != "") != tt.fails {
+			t.Errorf("%s: Check() = %q, want failure %v", tt.name, reason, tt.fails)
+		}
+	}
+}
+
+// TestGroupViolations verifies groups are ordered by month then contact and totalled.
+func TestGroupViolations(t *testing.T) {
+	entry := func(id uint, date string, contact string, amount float64) api.Ledger {
+		return api.Ledger{ID: id, Date: date + "T00:00:00Z", Amount: amount, Contact: api.Contact{Name: contact}}
+	}
+
+	violations := Audit([]api.Ledger{
+		entry(1, "2026-03-10", "Acme", -100),
+		entry(2, "2026-02-20", "Staples", -80.10),
+		entry(3, "2026-02-05", "Staples", -90.20),
+		entry(4, "2026-02-11", "acme", -200),
+	}, Policy{Threshold: 75})
+
+	groups := GroupViolations(violations)
+	if len(groups) != 3 {
+		t.Fatalf("GroupViolations() = %d groups, want 3", len(groups))
+	}
+
+	if groups[0].Month != "2026-02" || groups[0].Contact != "acme" {
+		t.Errorf("first group = %s %s, want 2026-02 acme", groups[0].Month, groups[0].Contact)
+	}
+	if g := groups[1]; g.Contact != "Staples" || g.Total != -170.30 || g.Violations[0].Ledger.ID != 3 {
+		t.Errorf("second group = %+v, want Staples totalling -170.30 starting with entry 3", g)
+	}
+	if groups[2].Month != "2026-03" {
+		t.Errorf("last group month = %s, want 2026-03", groups[2].Month)
+	}
+}
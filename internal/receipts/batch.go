@@ -0,0 +1,183 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+// Package receipts holds the receipt-handling logic behind the files commands:
+// planning and running batch uploads, sidecar and manifest conventions, and
+// related helpers that are independent of the CLI layer.
+package receipts
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+	"github.com/cloudmanic/skyclerk-cli/internal/pool"
+)
+
+// SidecarExt is the extension of a sidecar file holding the ledger ID for the
+// file it sits next to, e.g. receipt.jpg.ledger.
+const SidecarExt = ".ledger"
+
+// Job is one file to upload along with its optional ledger association.
+type Job struct {
+	Path     string `json:"path"`
+	LedgerID string `json:"ledger_id,omitempty"`
+}
+
+// Result is the outcome of uploading one job.
+type Result struct {
+	Job
+	File *api.File `json:"file,omitempty"`
+	Err  error     `json:"-"`
+}
+
+// Uploader uploads a single file; *api.Client satisfies it.
+type Uploader interface {
+	UploadFile(filePath string, ledgerID string, opts ...api.UploadOption) (*api.File, error)
+}
+
+// ExpandPaths expands glob patterns and the regular files directly inside dir
+// (if set) into a sorted, de-duplicated list. Hidden files and sidecars are
+// skipped. A pattern that matches nothing is an error so typos are not silent.
+func ExpandPaths(patterns []string, dir string) ([]string, error) {
+	seen := map[string]bool{}
+	var paths []string
+
+	add := func(p string) {
+		info, err := os.Stat(p)
+		if err != nil || !info.Mode().IsRegular() || skipFile(p) {
+			return
+		}
+		if !seen[p] {
+			seen[p] = true
+			paths = append(paths, p)
+		}
+	}
+
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no files match %q", pattern)
+		}
+		for _, m := range matches {
+			add(m)
+		}
+	}
+
+	if dir != "" {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read directory: %w", err)
+		}
+		for _, e := range entries {
+			add(filepath.Join(dir, e.Name()))
+		}
+	}
+
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// skipFile reports whether a path is a hidden file or a sidecar.
+func skipFile(path string) bool {
+	base := filepath.Base(path)
+	return strings.HasPrefix(base, ".") || strings.HasSuffix(base, SidecarExt)
+}
+
+// ReadSidecar returns the ledger ID stored in path's sidecar file, or "" if there is none.
+func ReadSidecar(path string) (string, error) {
+	data, err := os.ReadFile(path + SidecarExt)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("unable to read sidecar: %w", err)
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
+
+// LoadManifest reads a CSV manifest of file,ledger_id rows. A header row whose
+// second column is "ledger_id" is skipped. Keys are the file column as written.
+func LoadManifest(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open manifest: %w", err)
+	}
+	defer f.Close()
+
+	return ParseManifest(f)
+}
+
+// ParseManifest parses CSV manifest rows from r.
+func ParseManifest(r io.Reader) (map[string]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse manifest: %w", err)
+	}
+
+	manifest := map[string]string{}
+	for i, row := range rows {
+		if len(row) < 2 {
+			return nil, fmt.Errorf("manifest line %d: expected file,ledger_id", i+1)
+		}
+		if i == 0 && strings.EqualFold(strings.TrimSpace(row[1]), "ledger_id") {
+			continue
+		}
+		manifest[strings.TrimSpace(row[0])] = strings.TrimSpace(row[1])
+	}
+
+	return manifest, nil
+}
+
+// Plan builds upload jobs, resolving each file's ledger ID from (in order of
+// precedence) its sidecar, the manifest (by path as given, then base name),
+// and finally defaultLedgerID.
+func Plan(paths []string, manifest map[string]string, defaultLedgerID string) ([]Job, error) {
+	jobs := make([]Job, 0, len(paths))
+
+	for _, p := range paths {
+		ledgerID, err := ReadSidecar(p)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", p, err)
+		}
+
+		if ledgerID == "" {
+			if id, ok := manifest[p]; ok {
+				ledgerID = id
+			} else if id, ok := manifest[filepath.Base(p)]; ok {
+				ledgerID = id
+			}
+		}
+
+		if ledgerID == "" {
+			ledgerID = defaultLedgerID
+		}
+
+		jobs = append(jobs, Job{Path: p, LedgerID: ledgerID})
+	}
+
+	return jobs, nil
+}
+
+// UploadAll uploads every job using at most parallel concurrent workers.
+// Failures do not stop the batch. onResult (if set) is called as each upload
+// finishes; the returned results are in job order.
+func UploadAll(u Uploader, jobs []Job, parallel int, onResult func(Result)) []Result {
+	return pool.Map(jobs, parallel, func(j Job) Result {
+		file, err := u.UploadFile(j.Path, j.LedgerID)
+		return Result{Job: j, File: file, Err: err}
+	}, onResult)
+}
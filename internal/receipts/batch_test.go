@@ -0,0 +1,159 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package receipts
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+)
+
+// writeFiles creates files with the given contents under dir.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+	}
+}
+
+// fakeUploader records uploads and fails for paths containing "bad".
+type fakeUploader struct {
+	mu       sync.Mutex
+	uploaded map[string]string
+	active   int32
+	peak     int32
+}
+
+// UploadFile implements Uploader.
+func (f *fakeUploader) UploadFile(filePath string, ledgerID string, opts ...api.UploadOption) (*api.File, error) {
+	n := atomic.AddInt32(&f.active, 1)
+	defer atomic.AddInt32(&f.active, -1)
+
+	for {
+		peak := atomic.LoadInt32(&f.peak)
+		if n <= peak || atomic.CompareAndSwapInt32(&f.peak, peak, n) {
+			break
+		}
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	if strings.Contains(filePath, "bad") {
+		return nil, errors.New("upload rejected")
+	}
+
+	f.mu.Lock()
+	f.uploaded[filePath] = ledgerID
+	f.mu.Unlock()
+
+	return &api.File{ID: 1, Name: filepath.Base(filePath)}, nil
+}
+
+// TestExpandPaths verifies globs and --dir are merged, de-duplicated and filtered.
+func TestExpandPaths(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"a.jpg":        "a",
+		"b.pdf":        "b",
+		"a.jpg.ledger": "7",
+		".DS_Store":    "x",
+	})
+
+	paths, err := ExpandPaths([]string{filepath.Join(dir, "*.jpg")}, dir)
+	if err != nil {
+		t.Fatalf("ExpandPaths() error = %v", err)
+	}
+
+	want := []string{filepath.Join(dir, "a.jpg"), filepath.Join(dir, "b.pdf")}
+	if strings.Join(paths, ",") != strings.Join(want, ",") {
+		t.Errorf("paths = %v, want %v", paths, want)
+	}
+
+	if _, err := ExpandPaths([]string{filepath.Join(dir, "*.png")}, ""); err == nil {
+		t.Error("expected error for pattern matching nothing")
+	}
+}
+
+// TestParseManifest verifies header skipping and row parsing.
+func TestParseManifest(t *testing.T) {
+	manifest, err := ParseManifest(strings.NewReader("file,ledger_id\na.jpg, 12\nscans/b.pdf,13\n"))
+	if err != nil {
+		t.Fatalf("ParseManifest() error = %v", err)
+	}
+
+	if len(manifest) != 2 || manifest["a.jpg"] != "12" || manifest["scans/b.pdf"] != "13" {
+		t.Errorf("manifest = %v", manifest)
+	}
+
+	if _, err := ParseManifest(strings.NewReader("only-one-column\n")); err == nil {
+		t.Error("expected error for row without ledger_id")
+	}
+}
+
+// TestPlanPrecedence verifies sidecars win over the manifest, which wins over the default.
+func TestPlanPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"a.jpg":        "a",
+		"a.jpg.ledger": " 7\n",
+		"b.jpg":        "b",
+		"c.jpg":        "c",
+	})
+
+	paths := []string{filepath.Join(dir, "a.jpg"), filepath.Join(dir, "b.jpg"), filepath.Join(dir, "c.jpg")}
+	manifest := map[string]string{"a.jpg": "99", "b.jpg": "8"}
+
+	jobs, err := Plan(paths, manifest, "5")
+	if err != nil {
+		t.Fatalf("Plan() error = %v", err)
+	}
+
+	got := []string{jobs[0].LedgerID, jobs[1].LedgerID, jobs[2].LedgerID}
+	if strings.Join(got, ",") != "7,8,5" {
+		t.Errorf("ledger IDs = %v, want [7 8 5]", got)
+	}
+}
+
+// TestUploadAllContinuesOnError verifies failures are reported without stopping
+// the batch and concurrency stays within the limit.
+func TestUploadAllContinuesOnError(t *testing.T) {
+	jobs := []Job{
+		{Path: "one.jpg", LedgerID: "1"},
+		{Path: "bad.jpg"},
+		{Path: "three.jpg"},
+		{Path: "four.jpg"},
+		{Path: "five.jpg"},
+	}
+
+	u := &fakeUploader{uploaded: map[string]string{}}
+	calls := 0
+	results := UploadAll(u, jobs, 2, func(Result) { calls++ })
+
+	if calls != len(jobs) || len(results) != len(jobs) {
+		t.Fatalf("calls = %d, results = %d, want %d", calls, len(results), len(jobs))
+	}
+
+	for i, r := range results {
+		if r.Path != jobs[i].Path {
+			t.Errorf("results[%d].Path = %q, want %q", i, r.Path, jobs[i].Path)
+		}
+	}
+
+	if results[1].Err == nil || len(u.uploaded) != 4 || u.uploaded["one.jpg"] != "1" {
+		t.Errorf("bad err = %v, uploaded = %v", results[1].Err, u.uploaded)
+	}
+
+	if u.peak > 2 {
+		t.Errorf("peak concurrency = %d, want <= 2", u.peak)
+	}
+}
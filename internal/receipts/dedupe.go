@@ -0,0 +1,72 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package receipts
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+)
+
+// DuplicateGroup is a set of remote files with identical content. Files are
+// ordered oldest first, so the first is the one to keep.
+type DuplicateGroup struct {
+	SHA256 string     `json:"sha256,omitempty"`
+	Size   int64      `json:"size"`
+	Files  []api.File `json:"files"`
+}
+
+// Redundant returns the files in the group other than the one to keep.
+func (g DuplicateGroup) Redundant() []api.File {
+	return g.Files[1:]
+}
+
+// FindDuplicates groups files with the same size and, when hash is set, the
+// same content hash. Only files sharing a size are hashed, so a hash function
+// that downloads content is called as rarely as possible. Files that cannot be
+// hashed are left out and reported in the returned errors.
+func FindDuplicates(files []api.File, hash func(api.File) (string, error)) ([]DuplicateGroup, []error) {
+	bySize := map[int64][]api.File{}
+	for _, f := range files {
+		bySize[f.Size] = append(bySize[f.Size], f)
+	}
+
+	var groups []DuplicateGroup
+	var errs []error
+
+	for size, candidates := range bySize {
+		if len(candidates) < 2 {
+			continue
+		}
+
+		if hash == nil {
+			groups = append(groups, DuplicateGroup{Size: size, Files: candidates})
+			continue
+		}
+
+		byHash := map[string][]api.File{}
+		for _, f := range candidates {
+			h, err := hash(f)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("file %d: %w", f.ID, err))
+				continue
+			}
+			byHash[h] = append(byHash[h], f)
+		}
+
+		for h, same := range byHash {
+			if len(same) > 1 {
+				groups = append(groups, DuplicateGroup{SHA256: h, Size: size, Files: same})
+			}
+		}
+	}
+
+	for _, g := range groups {
+		sort.Slice(g.Files, func(i, j int) bool { return g.Files[i].ID < g.Files[j].ID })
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Files[0].ID < groups[j].Files[0].ID })
+
+	return groups, errs
+}
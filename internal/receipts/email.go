@@ -0,0 +1,349 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package receipts
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxEmailDepth bounds MIME nesting so malformed messages cannot recurse forever.
+const maxEmailDepth = 10
+
+// Attachment is a receipt file found in an email.
+type Attachment struct {
+	Name        string `json:"name"`
+	ContentType string `json:"content_type"`
+	Data        []byte `json:"-"`
+}
+
+// Email is the receipt-relevant content of a parsed MIME message.
+type Email struct {
+	From        string       `json:"from"`
+	FromName    string       `json:"from_name,omitempty"`
+	FromAddress string       `json:"from_address,omitempty"`
+	Subject     string       `json:"subject"`
+	Date        time.Time    `json:"date"`
+	Text        string       `json:"-"`
+	HTML        string       `json:"-"`
+	Attachments []Attachment `json:"attachments"`
+}
+
+// ParseEmail reads an RFC 5322 message, decoding its MIME structure into text
+// and HTML bodies and PDF/image attachments. Inline images referenced from the
+// HTML body (logos and the like) are not treated as attachments.
+func ParseEmail(r io.Reader) (*Email, error) {
+	msg, err := mail.ReadMessage(r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse email: %w", err)
+	}
+
+	dec := new(mime.WordDecoder)
+	e := &Email{}
+
+	e.From, _ = dec.DecodeHeader(msg.Header.Get("From"))
+	if addr, err := mail.ParseAddress(msg.Header.Get("From")); err == nil {
+		e.FromName = addr.Name
+		e.FromAddress = addr.Address
+	}
+
+	e.Subject, _ = dec.DecodeHeader(msg.Header.Get("Subject"))
+	if date, err := msg.Header.Date(); err == nil {
+		e.Date = date
+	}
+
+	header := textproto.MIMEHeader(msg.Header)
+	if err := e.walk(header, msg.Body, 0); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// walk decodes one MIME entity, recursing into multipart containers.
+func (e *Email) walk(header textproto.MIMEHeader, body io.Reader, depth int) error {
+	if depth > maxEmailDepth {
+		return fmt.Errorf("unable to parse email: MIME nesting too deep")
+	}
+
+	contentType := header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "text/plain"
+	}
+
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType, params = "application/octet-stream", map[string]string{}
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("unable to parse email: %w", err)
+			}
+
+			if err := e.walk(part.Header, part, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+
+	data, err := io.ReadAll(decodeTransfer(header.Get("Content-Transfer-Encoding"), body))
+	if err != nil {
+		return fmt.Errorf("unable to decode email part: %w", err)
+	}
+
+	disposition, dparams, _ := mime.ParseMediaType(header.Get("Content-Disposition"))
+	name := dparams["filename"]
+	if name == "" {
+		name = params["name"]
+	}
+	if decoded, err := new(mime.WordDecoder).DecodeHeader(name); err == nil {
+		name = decoded
+	}
+
+	switch {
+	case isReceiptType(mediaType, name):
+		// Images embedded in the HTML body are decoration, not receipts.
+		if disposition != "attachment" && header.Get("Content-ID") != "" && strings.HasPrefix(mediaType, "image/") {
+			return nil
+		}
+		if name == "" {
+			name = fmt.Sprintf("attachment-%d%s", len(e.Attachments)+1, extensionFor(mediaType))
+		}
+		e.Attachments = append(e.Attachments, Attachment{Name: filepath.Base(name), ContentType: mediaType, Data: data})
+	case disposition == "attachment":
+		// Other attachments (calendar invites, archives, ...) are ignored.
+	case mediaType == "text/plain" && e.Text == "":
+		e.Text = toUTF8(data, params["charset"])
+	case mediaType == "text/html" && e.HTML == "":
+		e.HTML = toUTF8(data, params["charset"])
+	}
+
+	return nil
+}
+
+// decodeTransfer undoes a Content-Transfer-Encoding. Multipart parts have
+// quoted-printable removed by the standard library already.
+func decodeTransfer(encoding string, r io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, r)
+	case "quoted-printable":
+		return quotedprintable.NewReader(r)
+	default:
+		return r
+	}
+}
+
+// isReceiptType reports whether a part is a PDF or image worth uploading.
+func isReceiptType(mediaType string, name string) bool {
+	if mediaType == "application/pdf" || strings.HasPrefix(mediaType, "image/") {
+		return true
+	}
+
+	// Some mailers send everything as application/octet-stream.
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".pdf", ".jpg", ".jpeg", ".png", ".gif", ".heic", ".webp", ".tif", ".tiff":
+		return true
+	}
+
+	return false
+}
+
+// extensionFor returns a file extension for a receipt media type.
+func extensionFor(mediaType string) string {
+	switch mediaType {
+	case "application/pdf":
+		return ".pdf"
+	case "image/jpeg":
+		return ".jpg"
+	case "image/png":
+		return ".png"
+	}
+
+	if exts, _ := mime.ExtensionsByType(mediaType); len(exts) > 0 {
+		return exts[0]
+	}
+
+	return ""
+}
+
+// toUTF8 converts text in common single-byte charsets to UTF-8.
+func toUTF8(data []byte, charset string) string {
+	switch strings.ToLower(charset) {
+	case "iso-8859-1", "latin1", "windows-1252", "us-ascii", "ascii":
+		if utf8.Valid(data) {
+			return string(data)
+		}
+		runes := make([]rune, len(data))
+		for i, b := range data {
+			runes[i] = rune(b)
+		}
+		return string(runes)
+	}
+
+	return string(data)
+}
+
+// SenderName returns a contact name for the sender: the display name when
+// present, otherwise the capitalized domain name (billing@acme.com -> Acme).
+func (e *Email) SenderName() string {
+	if e.FromName != "" {
+		return e.FromName
+	}
+
+	at := strings.LastIndex(e.FromAddress, "@")
+	if at < 0 {
+		return e.FromAddress
+	}
+
+	parts := strings.Split(e.FromAddress[at+1:], ".")
+	domain := parts[0]
+	if len(parts) >= 2 {
+		domain = parts[len(parts)-2]
+	}
+	if domain == "" {
+		return e.FromAddress
+	}
+
+	return strings.ToUpper(domain[:1]) + domain[1:]
+}
+
+// BodyText returns the plain text body, falling back to the HTML body with tags removed.
+func (e *Email) BodyText() string {
+	if strings.TrimSpace(e.Text) != "" {
+		return e.Text
+	}
+
+	return HTMLToText(e.HTML)
+}
+
+// RenderHTML returns a standalone HTML document for the message body, used as
+// the receipt when an email has no attachments.
+func (e *Email) RenderHTML() []byte {
+	var b bytes.Buffer
+	b.WriteString("<!DOCTYPE html>\n<html><head><meta charset=\"utf-8\"><title>")
+	b.WriteString(html.EscapeString(e.Subject))
+	b.WriteString("</title></head><body>\n<p><strong>From:</strong> ")
+	b.WriteString(html.EscapeString(e.From))
+	b.WriteString("<br><strong>Date:</strong> ")
+	b.WriteString(html.EscapeString(e.Date.Format(time.RFC1123Z)))
+	b.WriteString("<br><strong>Subject:</strong> ")
+	b.WriteString(html.EscapeString(e.Subject))
+	b.WriteString("</p>\n<hr>\n")
+
+	if strings.TrimSpace(e.HTML) != "" {
+		b.WriteString(e.HTML)
+	} else {
+		b.WriteString("<pre>")
+		b.WriteString(html.EscapeString(e.Text))
+		b.WriteString("</pre>")
+	}
+
+	b.WriteString("\n</body></html>\n")
+	return b.Bytes()
+}
+
+// RenderName returns a file name for the rendered HTML body.
+func (e *Email) RenderName() string {
+	slug := strings.Trim(nonSlug.ReplaceAllString(strings.ToLower(e.Subject), "-"), "-")
+	if len(slug) > 60 {
+		slug = strings.Trim(slug[:60], "-")
+	}
+	if slug == "" {
+		slug = "email"
+	}
+
+	if !e.Date.IsZero() {
+		return e.Date.Format("2006-01-02") + "-" + slug + ".html"
+	}
+
+	return slug + ".html"
+}
+
+// nonSlug matches runs of characters not allowed in rendered file names.
+var nonSlug = regexp.MustCompile(`[^a-z0-9]+`)
+
+// htmlBlock and htmlTag strip markup when reading totals from HTML bodies.
+var (
+	htmlBlock = regexp.MustCompile(`(?is)<(style|script|head)[^>]*>.*?</(style|script|head)>`)
+	htmlBreak = regexp.MustCompile(`(?i)<(br|/p|/div|/tr|/li|/h[1-6])[^>]*>`)
+	htmlTag   = regexp.MustCompile(`(?s)<[^>]+>`)
+)
+
+// HTMLToText reduces HTML to text, keeping line breaks at block boundaries.
+func HTMLToText(s string) string {
+	s = htmlBlock.ReplaceAllString(s, "")
+	s = htmlBreak.ReplaceAllString(s, "\n")
+	s = htmlTag.ReplaceAllString(s, " ")
+
+	return html.UnescapeString(s)
+}
+
+// totalPattern finds amounts following total-like labels. "Subtotal" does not
+// match because "total" must start a word.
+var totalPattern = regexp.MustCompile(`(?i)\b(grand total|total due|amount due|balance due|amount paid|total paid|order total|total charged|total)\b[^0-9\n]{0,25}?([0-9]{1,3}(?:,[0-9]{3})+(?:\.[0-9]{1,2})?|[0-9]+(?:\.[0-9]{1,2})?)`)
+
+// totalRank orders total labels from most to least specific.
+var totalRank = map[string]int{
+	"grand total":   0,
+	"amount paid":   1,
+	"total paid":    1,
+	"total charged": 1,
+	"amount due":    2,
+	"balance due":   2,
+	"total due":     2,
+	"order total":   2,
+	"total":         3,
+}
+
+// TotalCandidate is an amount found next to a total-like label.
+type TotalCandidate struct {
+	Label  string  `json:"label"`
+	Amount float64 `json:"amount"`
+}
+
+// FindTotals returns the amounts labelled as totals in text, best first: the
+// most specific label wins and, among equal labels, the larger amount.
+func FindTotals(text string) []TotalCandidate {
+	var found []TotalCandidate
+	for _, m := range totalPattern.FindAllStringSubmatch(text, -1) {
+		amount, err := strconv.ParseFloat(strings.ReplaceAll(m[2], ",", ""), 64)
+		if err != nil || amount == 0 {
+			continue
+		}
+		found = append(found, TotalCandidate{Label: strings.ToLower(m[1]), Amount: amount})
+	}
+
+	sort.SliceStable(found, func(i, j int) bool {
+		ri, rj := totalRank[found[i].Label], totalRank[found[j].Label]
+		if ri != rj {
+			return ri < rj
+		}
+		return found[i].Amount > found[j].Amount
+	})
+
+	return found
+}
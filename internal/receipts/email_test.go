@@ -0,0 +1,113 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package receipts
+
+import (
+	"strings"
+	"testing"
+)
+
+// receiptEmail is a typical vendor receipt: text and HTML bodies, an inline
+// logo and a base64 PDF attachment with an encoded file name.
+const receiptEmail = "From: Acme Billing <billing@acme.com>\r\n" +
+	"To: books@example.com\r\n" +
+	"Subject: =?UTF-8?Q?Your_receipt_=E2=80=94_order_1042?=\r\n" +
+	"Date: Tue, 03 Mar 2026 14:05:00 -0500\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-Type: multipart/mixed; boundary=\"outer\"\r\n" +
+	"\r\n" +
+	"--outer\r\n" +
+	"Content-Type: multipart/related; boundary=\"rel\"\r\n" +
+	"\r\n" +
+	"--rel\r\n" +
+	"Content-Type: multipart/alternative; boundary=\"alt\"\r\n" +
+	"\r\n" +
+	"--alt\r\n" +
+	"Content-Type: text/plain; charset=utf-8\r\n" +
+	"Content-Transfer-Encoding: quoted-printable\r\n" +
+	"\r\n" +
+	"Subtotal: $40.00\r\n" +
+	"Tax: $3.20\r\n" +
+	"Total: $43.20\r\n" +
+	"--alt\r\n" +
+	"Content-Type: text/html; charset=utf-8\r\n" +
+	"\r\n" +
+	"<html><body><img src=\"cid:logo\"><p>Total: $43.20</p></body></html>\r\n" +
+	"--alt--\r\n" +
+	"--rel\r\n" +
+	"Content-Type: image/png\r\n" +
+	"Content-ID: <logo>\r\n" +
+	"Content-Transfer-Encoding: base64\r\n" +
+	"\r\n" +
+	"iVBORw0KGgo=\r\n" +
+	"--rel--\r\n" +
+	"--outer\r\n" +
+	"Content-Type: application/pdf; name=\"receipt-1042.pdf\"\r\n" +
+	"Content-Disposition: attachment; filename=\"receipt-1042.pdf\"\r\n" +
+	"Content-Transfer-Encoding: base64\r\n" +
+	"\r\n" +
+	"JVBERi0xLjQgZmFrZQ==\r\n" +
+	"--outer--\r\n"
+
+// TestParseEmail verifies headers, bodies and attachments are decoded and inline logos skipped.
+func TestParseEmail(t *testing.T) {
+	e, err := ParseEmail(strings.NewReader(receiptEmail))
+	if err != nil {
+		t.Fatalf("ParseEmail() error = %v", err)
+	}
+
+	if e.Subject != "Your receipt — order 1042" {
+		t.Errorf("Subject = %q", e.Subject)
+	}
+	if e.FromAddress != "billing@acme.com" || e.SenderName() != "Acme Billing" {
+		t.Errorf("From = %q <%q>, sender %q", e.FromName, e.FromAddress, e.SenderName())
+	}
+	if e.Date.Format("2006-01-02") != "2026-03-03" {
+		t.Errorf("Date = %v", e.Date)
+	}
+
+	if len(e.Attachments) != 1 {
+		t.Fatalf("Attachments = %+v, want only the PDF", e.Attachments)
+	}
+	if a := e.Attachments[0]; a.Name != "receipt-1042.pdf" || string(a.Data) != "%PDF-1.4 fake" {
+		t.Errorf("attachment = %s %q", a.Name, a.Data)
+	}
+
+	totals := FindTotals(e.BodyText())
+	if len(totals) == 0 || totals[0].Amount != 43.20 {
+		t.Errorf("FindTotals() = %+v, want 43.20 first", totals)
+	}
+}
+
+// TestEmailWithoutAttachments verifies HTML-only receipts render to a named file
+// and totals are read from the HTML.
+func TestEmailWithoutAttachments(t *testing.T) {
+	raw := "From: no-reply@mail.github.com\r\n" +
+		"Subject: Payment receipt\r\n" +
+		"Date: Sun, 01 Feb 2026 09:00:00 +0000\r\n" +
+		"Content-Type: text/html; charset=utf-8\r\n" +
+		"\r\n" +
+		"<style>p{color:red}</style><table><tr><td>Amount paid</td><td>$1,204.50</td></tr>" +
+		"<tr><td>Total</td><td>$1,204.50</td></tr></table>"
+
+	e, err := ParseEmail(strings.NewReader(raw))
+	if err != nil {
+		t.Fatalf("ParseEmail() error = %v", err)
+	}
+
+	if e.SenderName() != "Github" {
+		t.Errorf("SenderName() = %q, want Github", e.SenderName())
+	}
+	if e.RenderName() != "2026-02-01-payment-receipt.html" {
+		t.Errorf("RenderName() = %q", e.RenderName())
+	}
+	if !strings.Contains(string(e.RenderHTML()), "Amount paid") {
+		t.Error("RenderHTML() is missing the body")
+	}
+
+	totals := FindTotals(e.BodyText())
+	if len(totals) == 0 || totals[0].Label != "amount paid" || totals[0].Amount != 1204.50 {
+		t.Errorf("FindTotals() = %+v", totals)
+	}
+}
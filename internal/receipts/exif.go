@@ -0,0 +1,100 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package receipts
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// EXIF tags read from JPEG files.
+const (
+	exifTagOrientation = 0x0112
+	exifTagGPSInfo     = 0x8825
+)
+
+// exifInfo is the subset of JPEG EXIF metadata that affects optimization.
+type exifInfo struct {
+	Orientation int
+	HasGPS      bool
+	Present     bool
+}
+
+// readEXIF scans a JPEG's segments for an EXIF APP1 block and reads the
+// orientation and whether GPS data is present. Malformed data yields the
+// zero value rather than an error; metadata is advisory.
+func readEXIF(data []byte) exifInfo {
+	info := exifInfo{Orientation: 1}
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return info
+	}
+
+	for pos := 2; pos+4 <= len(data); {
+		if data[pos] != 0xFF {
+			return info
+		}
+
+		marker := data[pos+1]
+		// Start of scan: image data follows, no more metadata.
+		if marker == 0xDA || marker == 0xD9 {
+			return info
+		}
+
+		length := int(binary.BigEndian.Uint16(data[pos+2:]))
+		end := pos + 2 + length
+		if length < 2 || end > len(data) {
+			return info
+		}
+
+		segment := data[pos+4 : end]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			info.Present = true
+			parseTIFF(segment[6:], &info)
+			return info
+		}
+
+		pos = end
+	}
+
+	return info
+}
+
+// parseTIFF reads IFD0 of an EXIF TIFF structure.
+func parseTIFF(tiff []byte, info *exifInfo) {
+	if len(tiff) < 8 {
+		return
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd+2 > len(tiff) {
+		return
+	}
+
+	count := int(order.Uint16(tiff[ifd:]))
+	for i := 0; i < count; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return
+		}
+
+		switch order.Uint16(tiff[entry:]) {
+		case exifTagOrientation:
+			if v := int(order.Uint16(tiff[entry+8:])); v >= 1 && v <= 8 {
+				info.Orientation = v
+			}
+		case exifTagGPSInfo:
+			info.HasGPS = true
+		}
+	}
+}
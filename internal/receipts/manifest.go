@@ -0,0 +1,369 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package receipts
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+)
+
+// UploadRecord describes one file known to exist in an account, keyed by the
+// content hash of its local source.
+type UploadRecord struct {
+	SHA256       string    `json:"sha256"`
+	RemoteSHA256 string    `json:"remote_sha256,omitempty"` // hash of the bytes sent, when they differ from the source (e.g. optimized)
+	Size         int64     `json:"size"`
+	FileID       uint      `json:"file_id"`
+	Name         string    `json:"name"`
+	Source       string    `json:"source,omitempty"`
+	UploadedAt   time.Time `json:"uploaded_at"`
+}
+
+// Relink is a copy of an unattached file uploaded onto a ledger entry by
+// files match whose original could not be deleted yet.
+type Relink struct {
+	LedgerID uint         `json:"ledger_id"`
+	Record   UploadRecord `json:"record"`
+}
+
+// UploadManifest is the local per-account record of uploaded file hashes used
+// to catch the same receipt being uploaded twice. Files holds one record per
+// source hash; Remote holds the content hash of every known remote file.
+type UploadManifest struct {
+	path   string
+	mu     sync.Mutex
+	Files  map[string]UploadRecord `json:"files"`
+	Remote map[uint]string         `json:"remote,omitempty"`
+
+	// Imports maps the hash of an imported email to the ledger entry made for it.
+	Imports map[string]uint `json:"imports,omitempty"`
+
+	// Relinks maps original file IDs to the copies that replaced them while
+	// the originals still wait to be deleted.
+	Relinks map[uint]Relink `json:"relinks,omitempty"`
+}
+
+// LoadUploadManifest reads a manifest, returning an empty one if the file does not exist.
+func LoadUploadManifest(path string) (*UploadManifest, error) {
+	m := &UploadManifest{path: path, Files: map[string]UploadRecord{}, Remote: map[uint]string{}, Imports: map[string]uint{}, Relinks: map[uint]Relink{}}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return m, nil
+		}
+		return nil, fmt.Errorf("unable to read upload manifest: %w", err)
+	}
+
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("unable to parse upload manifest: %w", err)
+	}
+	if m.Files == nil {
+		m.Files = map[string]UploadRecord{}
+	}
+	if m.Remote == nil {
+		m.Remote = map[uint]string{}
+	}
+	if m.Imports == nil {
+		m.Imports = map[string]uint{}
+	}
+	if m.Relinks == nil {
+		m.Relinks = map[uint]Relink{}
+	}
+
+	// Manifests written before Remote existed only know the files in Files.
+	for _, rec := range m.Files {
+		if _, ok := m.Remote[rec.FileID]; !ok && rec.FileID != 0 && rec.RemoteSHA256 == "" {
+			m.Remote[rec.FileID] = rec.SHA256
+		}
+	}
+
+	return m, nil
+}
+
+// Lookup returns the record for a content hash.
+func (m *UploadManifest) Lookup(hash string) (UploadRecord, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	rec, ok := m.Files[hash]
+	return rec, ok
+}
+
+// HashFor returns the hash of a remote file's content, as stored in Skyclerk.
+func (m *UploadManifest) HashFor(fileID uint) (string, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	hash, ok := m.Remote[fileID]
+	return hash, ok
+}
+
+// RecordFor returns the upload record for a remote file ID.
+func (m *UploadManifest) RecordFor(fileID uint) (UploadRecord, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, rec := range m.Files {
+		if rec.FileID == fileID {
+			return rec, true
+		}
+	}
+
+	return UploadRecord{}, false
+}
+
+// Record stores a record and saves the manifest. Every remote file's content
+// hash is kept, but when several share a source hash the record of the oldest
+// (lowest ID) is kept, matching what dedupe keeps.
+func (m *UploadManifest) Record(rec UploadRecord) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if rec.RemoteSHA256 == rec.SHA256 {
+		rec.RemoteSHA256 = ""
+	}
+	if rec.FileID != 0 {
+		m.Remote[rec.FileID] = rec.SHA256
+		if rec.RemoteSHA256 != "" {
+			m.Remote[rec.FileID] = rec.RemoteSHA256
+		}
+	}
+
+	if existing, ok := m.Files[rec.SHA256]; !ok || existing.FileID == 0 || existing.FileID >= rec.FileID {
+		m.Files[rec.SHA256] = rec
+	}
+
+	return m.save()
+}
+
+// ImportedLedger returns the ledger entry created for an imported email.
+func (m *UploadManifest) ImportedLedger(hash string) (uint, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	id, ok := m.Imports[hash]
+	return id, ok
+}
+
+// RecordImport stores the ledger entry created for an email and saves the
+// manifest, so a retry after a failed upload reuses the entry.
+func (m *UploadManifest) RecordImport(hash string, ledgerID uint) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.Imports[hash] = ledgerID
+
+	return m.save()
+}
+
+// RelinkFor returns the copy that replaced a file not yet deleted.
+func (m *UploadManifest) RelinkFor(fileID uint) (Relink, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	link, ok := m.Relinks[fileID]
+	return link, ok
+}
+
+// RecordRelink stores the copy that replaced a file and saves the manifest,
+// so a retry only deletes the original instead of uploading another copy.
+func (m *UploadManifest) RecordRelink(fileID uint, link Relink) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.Relinks[fileID] = link
+
+	return m.save()
+}
+
+// Forget removes any record pointing at a remote file ID (e.g. after it is deleted).
+func (m *UploadManifest) Forget(fileID uint) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, changed := m.Remote[fileID]
+	delete(m.Remote, fileID)
+	if _, ok := m.Relinks[fileID]; ok {
+		delete(m.Relinks, fileID)
+		changed = true
+	}
+	for hash, rec := range m.Files {
+		if rec.FileID == fileID {
+			delete(m.Files, hash)
+			changed = true
+		}
+	}
+
+	if !changed {
+		return nil
+	}
+
+	return m.save()
+}
+
+// save writes the manifest atomically. The caller holds m.mu.
+func (m *UploadManifest) save() error {
+	if err := os.MkdirAll(filepath.Dir(m.path), 0700); err != nil {
+		return fmt.Errorf("unable to create manifest directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to marshal upload manifest: %w", err)
+	}
+
+	tmp := m.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("unable to write upload manifest: %w", err)
+	}
+
+	if err := os.Rename(tmp, m.path); err != nil {
+		return fmt.Errorf("unable to write upload manifest: %w", err)
+	}
+
+	return nil
+}
+
+// DuplicateError is returned when a file's content has already been uploaded.
+type DuplicateError struct {
+	Path     string
+	Existing UploadRecord
+}
+
+// Error describes the earlier upload and how to override the check.
+func (e *DuplicateError) Error() string {
+	if e.Existing.FileID == 0 {
+		return fmt.Sprintf("same content as %s in this batch (use --force to upload anyway)", e.Existing.Name)
+	}
+
+	return fmt.Sprintf("duplicate of file %d (%s, uploaded %s); use --force to upload anyway",
+		e.Existing.FileID, e.Existing.Name, e.Existing.UploadedAt.Local().Format("2006-01-02"))
+}
+
+// IsDuplicate reports whether err is a DuplicateError.
+func IsDuplicate(err error) bool {
+	var dup *DuplicateError
+	return errors.As(err, &dup)
+}
+
+// DedupUploader wraps an Uploader, refusing content already in the manifest
+// (unless Force is set) and recording every successful upload.
+type DedupUploader struct {
+	Next     Uploader
+	Manifest *UploadManifest
+	Force    bool
+
+	mu       sync.Mutex
+	inflight map[string]string
+}
+
+// UploadFile hashes the file, checks the manifest and uploads it.
+func (d *DedupUploader) UploadFile(filePath string, ledgerID string, opts ...api.UploadOption) (*api.File, error) {
+	hash, err := HashFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if !d.Force {
+		if rec, ok := d.Manifest.Lookup(hash); ok {
+			return nil, &DuplicateError{Path: filePath, Existing: rec}
+		}
+	}
+
+	// Identical files in one concurrent batch must not both slip past the check.
+	if err := d.reserve(hash, filePath); err != nil {
+		return nil, err
+	}
+	defer d.release(hash)
+
+	// Record what was actually sent, which differs from the source when a
+	// wrapped uploader rewrites the file.
+	var sent string
+	opts = append(opts, api.WithContentHash(func(sha string) { sent = sha }))
+
+	file, err := d.Next.UploadFile(filePath, ledgerID, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// An absolute source lets later commands find sidecars next to the original.
+	source := filePath
+	if abs, err := filepath.Abs(filePath); err == nil {
+		source = abs
+	}
+
+	rec := UploadRecord{
+		SHA256:       hash,
+		RemoteSHA256: sent,
+		Size:         file.Size,
+		FileID:       file.ID,
+		Name:         file.Name,
+		Source:       source,
+		UploadedAt:   time.Now().UTC(),
+	}
+	if err := d.Manifest.Record(rec); err != nil {
+		return file, err
+	}
+
+	return file, nil
+}
+
+// reserve marks a hash as being uploaded, failing if another upload of the
+// same content is in progress.
+func (d *DedupUploader) reserve(hash string, filePath string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.inflight == nil {
+		d.inflight = map[string]string{}
+	}
+
+	if other, ok := d.inflight[hash]; ok && !d.Force {
+		return &DuplicateError{Path: filePath, Existing: UploadRecord{SHA256: hash, Name: other}}
+	}
+	d.inflight[hash] = filePath
+
+	return nil
+}
+
+// release clears an in-progress reservation.
+func (d *DedupUploader) release(hash string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.inflight, hash)
+}
+
+// HashBytes returns the hex SHA-256 of data.
+func HashBytes(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// HashFile returns the hex SHA-256 of a file's contents.
+func HashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("unable to open file: %w", err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("unable to read file: %w", err)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
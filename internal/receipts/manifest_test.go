@@ -0,0 +1,150 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package receipts
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+)
+
+// TestDedupUploaderRefusesDuplicates verifies content already uploaded is
+// refused, --force overrides, and the manifest survives a reload.
+func TestDedupUploaderRefusesDuplicates(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"a.jpg": "same", "b.jpg": "same", "c.jpg": "other"})
+
+	path := filepath.Join(dir, "uploads", "account.json")
+	manifest, err := LoadUploadManifest(path)
+	if err != nil {
+		t.Fatalf("LoadUploadManifest() error = %v", err)
+	}
+
+	u := &fakeUploader{uploaded: map[string]string{}}
+	d := &DedupUploader{Next: u, Manifest: manifest}
+
+	if _, err := d.UploadFile(filepath.Join(dir, "a.jpg"), ""); err != nil {
+		t.Fatalf("first upload error = %v", err)
+	}
+
+	_, err = d.UploadFile(filepath.Join(dir, "b.jpg"), "")
+	if !IsDuplicate(err) {
+		t.Fatalf("duplicate upload error = %v, want DuplicateError", err)
+	}
+
+	d.Force = true
+	if _, err := d.UploadFile(filepath.Join(dir, "b.jpg"), ""); err != nil {
+		t.Errorf("forced upload error = %v", err)
+	}
+
+	reloaded, err := LoadUploadManifest(path)
+	if err != nil {
+		t.Fatalf("reload error = %v", err)
+	}
+	hash, _ := HashFile(filepath.Join(dir, "a.jpg"))
+	if _, ok := reloaded.Lookup(hash); !ok {
+		t.Error("reloaded manifest is missing the uploaded hash")
+	}
+
+	if err := reloaded.Forget(1); err != nil {
+		t.Fatalf("Forget() error = %v", err)
+	}
+	if _, ok := reloaded.Lookup(hash); ok {
+		t.Error("hash still present after Forget")
+	}
+}
+
+// TestManifestRemoteHashes verifies every remote file keeps its content hash,
+// including optimized uploads whose bytes differ from their source, and that
+// imported emails keep their ledger entry.
+func TestManifestRemoteHashes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "manifest.json")
+	manifest, _ := LoadUploadManifest(path)
+
+	manifest.Record(UploadRecord{SHA256: "source", RemoteSHA256: "optimized", FileID: 1, Source: "/tmp/a.jpg"})
+	manifest.Record(UploadRecord{SHA256: "remote", FileID: 3, Source: "remote"})
+	manifest.Record(UploadRecord{SHA256: "remote", FileID: 2, Source: "remote"})
+
+	manifest, err := LoadUploadManifest(path)
+	if err != nil {
+		t.Fatalf("LoadUploadManifest() error = %v", err)
+	}
+	for id, want := range map[uint]string{1: "optimized", 2: "remote", 3: "remote"} {
+		if got, ok := manifest.HashFor(id); !ok || got != want {
+			t.Errorf("HashFor(%d) = %q, %v, want %q", id, got, ok, want)
+		}
+	}
+	if rec, ok := manifest.Lookup("source"); !ok || rec.FileID != 1 {
+		t.Errorf("Lookup(source) = %+v, %v", rec, ok)
+	}
+	if rec, ok := manifest.Lookup("remote"); !ok || rec.FileID != 2 {
+		t.Errorf("Lookup(remote) = %+v, want the oldest file", rec)
+	}
+	if rec, ok := manifest.RecordFor(1); !ok || rec.Source != "/tmp/a.jpg" {
+		t.Errorf("RecordFor(1) = %+v, %v", rec, ok)
+	}
+
+	manifest.Forget(3)
+	if _, ok := manifest.HashFor(3); ok {
+		t.Error("HashFor(3) still found after Forget")
+	}
+
+	manifest.RecordImport("email", 7)
+	manifest, _ = LoadUploadManifest(path)
+	if id, ok := manifest.ImportedLedger("email"); !ok || id != 7 {
+		t.Errorf("ImportedLedger(email) = %d, %v, want 7", id, ok)
+	}
+}
+
+// TestManifestRelinks verifies a pending relink survives a reload and is
+// dropped once the original file is forgotten.
+func TestManifestRelinks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "manifest.json")
+	manifest, _ := LoadUploadManifest(path)
+
+	link := Relink{LedgerID: 7, Record: UploadRecord{SHA256: "abc", FileID: 12}}
+	if err := manifest.RecordRelink(5, link); err != nil {
+		t.Fatalf("RecordRelink() error = %v", err)
+	}
+
+	manifest, _ = LoadUploadManifest(path)
+	if got, ok := manifest.RelinkFor(5); !ok || got != link {
+		t.Errorf("RelinkFor(5) = %+v, %v, want %+v", got, ok, link)
+	}
+
+	manifest.Forget(5)
+	if manifest, _ = LoadUploadManifest(path); len(manifest.Relinks) != 0 {
+		t.Errorf("Relinks = %+v after Forget", manifest.Relinks)
+	}
+}
+
+// TestFindDuplicates verifies files are grouped by size then hash, oldest first.
+func TestFindDuplicates(t *testing.T) {
+	files := []api.File{
+		{ID: 4, Size: 10},
+		{ID: 2, Size: 10},
+		{ID: 3, Size: 10},
+		{ID: 1, Size: 99},
+	}
+	hashes := map[uint]string{4: "aaa", 2: "aaa", 3: "bbb"}
+
+	hashed := 0
+	groups, errs := FindDuplicates(files, func(f api.File) (string, error) {
+		hashed++
+		return hashes[f.ID], nil
+	})
+
+	if len(errs) != 0 || hashed != 3 {
+		t.Fatalf("errs = %v, hashed = %d, want no errors and 3 hashes", errs, hashed)
+	}
+	if len(groups) != 1 || groups[0].SHA256 != "aaa" || groups[0].Files[0].ID != 2 || groups[0].Redundant()[0].ID != 4 {
+		t.Errorf("groups = %+v", groups)
+	}
+
+	bySize, _ := FindDuplicates(files, nil)
+	if len(bySize) != 1 || len(bySize[0].Files) != 3 {
+		t.Errorf("size-only groups = %+v", bySize)
+	}
+}
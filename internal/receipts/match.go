@@ -0,0 +1,342 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package receipts
+
+import (
+	"encoding/json"
+	"math"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+)
+
+// Match scoring weights. A perfect match (same amount, same day, same vendor) scores 100.
+const (
+	amountWeight = 50
+	dateWeight   = 30
+	vendorWeight = 20
+
+	// MatchWindowDays is how far apart a receipt and entry date may be and still score.
+	MatchWindowDays = 7
+)
+
+// Hints are what a receipt says about its transaction, as far as we can tell.
+type Hints struct {
+	Date   time.Time
+	Amount float64
+	Vendor string
+}
+
+// MarshalJSON writes the date as YYYY-MM-DD and leaves out anything not found.
+func (h Hints) MarshalJSON() ([]byte, error) {
+	out := struct {
+		Date   string  `json:"date,omitempty"`
+		Amount float64 `json:"amount,omitempty"`
+		Vendor string  `json:"vendor,omitempty"`
+	}{Amount: h.Amount, Vendor: h.Vendor}
+	if !h.Date.IsZero() {
+		out.Date = h.Date.Format("2006-01-02")
+	}
+
+	return json.Marshal(out)
+}
+
+// Empty reports whether nothing was found.
+func (h Hints) Empty() bool {
+	return h.Date.IsZero() && h.Amount == 0 && h.Vendor == ""
+}
+
+// Merge fills fields missing from h with those from other.
+func (h Hints) Merge(other Hints) Hints {
+	if h.Date.IsZero() {
+		h.Date = other.Date
+	}
+	if h.Amount == 0 {
+		h.Amount = other.Amount
+	}
+	if h.Vendor == "" {
+		h.Vendor = other.Vendor
+	}
+
+	return h
+}
+
+// Date formats recognised in file names and receipt text.
+var (
+	isoDate   = regexp.MustCompile(`\b(20\d{2})[-_.]?(\d{2})[-_.]?(\d{2})\b`)
+	usDate    = regexp.MustCompile(`\b(\d{1,2})[-/.](\d{1,2})[-/.](20\d{2})\b`)
+	monthDate = regexp.MustCompile(`(?i)\b(jan|feb|mar|apr|may|jun|jul|aug|sep|oct|nov|dec)[a-z]*\.?\s+(\d{1,2})(?:st|nd|rd|th)?,?\s+(20\d{2})\b`)
+	dayMonth  = regexp.MustCompile(`(?i)\b(\d{1,2})\s+(jan|feb|mar|apr|may|jun|jul|aug|sep|oct|nov|dec)[a-z]*\.?,?\s+(20\d{2})\b`)
+)
+
+// months maps three-letter month abbreviations to months.
+var months = map[string]time.Month{
+	"jan": time.January, "feb": time.February, "mar": time.March, "apr": time.April,
+	"may": time.May, "jun": time.June, "jul": time.July, "aug": time.August,
+	"sep": time.September, "oct": time.October, "nov": time.November, "dec": time.December,
+}
+
+// findDate returns the first date in s along with the matched text.
+func findDate(s string) (time.Time, string) {
+	type candidate struct {
+		at    int
+		text  string
+		year  string
+		month string
+		day   string
+	}
+
+	var found []candidate
+	if m := isoDate.FindStringSubmatchIndex(s); m != nil {
+		found = append(found, candidate{m[0], s[m[0]:m[1]], s[m[2]:m[3]], s[m[4]:m[5]], s[m[6]:m[7]]})
+	}
+	if m := usDate.FindStringSubmatchIndex(s); m != nil {
+		found = append(found, candidate{m[0], s[m[0]:m[1]], s[m[6]:m[7]], s[m[2]:m[3]], s[m[4]:m[5]]})
+	}
+	if m := monthDate.FindStringSubmatchIndex(s); m != nil {
+		found = append(found, candidate{m[0], s[m[0]:m[1]], s[m[6]:m[7]], s[m[2]:m[3]], s[m[4]:m[5]]})
+	}
+	if m := dayMonth.FindStringSubmatchIndex(s); m != nil {
+		found = append(found, candidate{m[0], s[m[0]:m[1]], s[m[6]:m[7]], s[m[4]:m[5]], s[m[2]:m[3]]})
+	}
+
+	sort.SliceStable(found, func(i, j int) bool { return found[i].at < found[j].at })
+
+	for _, c := range found {
+		year, _ := strconv.Atoi(c.year)
+		day, _ := strconv.Atoi(c.day)
+		month, ok := months[strings.ToLower(c.month)]
+		if !ok {
+			n, _ := strconv.Atoi(c.month)
+			month = time.Month(n)
+		}
+
+		if month < 1 || month > 12 || day < 1 || day > 31 {
+			continue
+		}
+		date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+		if date.Day() != day {
+			continue
+		}
+
+		return date, c.text
+	}
+
+	return time.Time{}, ""
+}
+
+// nameAmount finds amounts like 43.20 or 43,20 in file names.
+var nameAmount = regexp.MustCompile(`(?:^|[^0-9.,])\$?([0-9]{1,7})[.,]([0-9]{2})(?:[^0-9]|$)`)
+
+// nameWord splits file names into words.
+var nameWord = regexp.MustCompile(`[a-zA-Z][a-zA-Z&']*`)
+
+// nameStopWords are words in receipt file names that never name a vendor.
+var nameStopWords = map[string]bool{
+	"receipt": true, "receipts": true, "invoice": true, "inv": true, "scan": true,
+	"scanned": true, "img": true, "image": true, "photo": true, "pxl": true,
+	"dsc": true, "order": true, "payment": true, "paid": true, "bill": true,
+	"statement": true, "copy": true, "final": true, "document": true, "doc": true,
+	"pdf": true, "jpg": true, "jpeg": true, "png": true, "usd": true, "total": true,
+	"jan": true, "feb": true, "mar": true, "apr": true, "may": true, "jun": true,
+	"jul": true, "aug": true, "sep": true, "oct": true, "nov": true, "dec": true,
+}
+
+// ParseFileName reads a date, amount and vendor from a receipt file name such
+// as "2026-03-03_acme-office_43.20.pdf".
+func ParseFileName(name string) Hints {
+	base := strings.TrimSuffix(filepath.Base(name), filepath.Ext(name))
+	base = strings.ReplaceAll(base, "_", " ")
+
+	var h Hints
+	date, text := findDate(base)
+	h.Date = date
+	rest := strings.Replace(base, text, " ", 1)
+
+	if m := nameAmount.FindStringSubmatch(rest); m != nil {
+		h.Amount, _ = strconv.ParseFloat(m[1]+"."+m[2], 64)
+	}
+
+	var words []string
+	for _, w := range nameWord.FindAllString(rest, -1) {
+		lw := strings.ToLower(w)
+		if len(lw) < 2 || nameStopWords[lw] {
+			continue
+		}
+		words = append(words, lw)
+	}
+	h.Vendor = strings.Join(words, " ")
+
+	return h
+}
+
+// ParseText reads a date, total and vendor from receipt text such as OCR
+// output or an email body. The vendor is taken to be the first line with letters.
+func ParseText(text string) Hints {
+	var h Hints
+	h.Date, _ = findDate(text)
+
+	if totals := FindTotals(text); len(totals) > 0 {
+		h.Amount = totals[0].Amount
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || len(line) > 60 || !nameWord.MatchString(line) {
+			continue
+		}
+		h.Vendor = line
+		break
+	}
+
+	return h
+}
+
+// Candidate is a ledger entry scored against a receipt's hints.
+type Candidate struct {
+	Ledger  api.Ledger `json:"ledger"`
+	Score   int        `json:"score"`
+	Reasons []string   `json:"reasons"`
+}
+
+// Score rates how well a ledger entry fits the hints, from 0 to 100. Amounts
+// score on equality (sign ignored), dates on proximity within
+// MatchWindowDays, and vendors on similarity to the entry's contact name.
+func Score(h Hints, l api.Ledger) Candidate {
+	c := Candidate{Ledger: l}
+	var score float64
+
+	if h.Amount != 0 {
+		diff := math.Abs(math.Abs(l.Amount) - h.Amount)
+		switch {
+		case diff < 0.005:
+			score += amountWeight
+			c.Reasons = append(c.Reasons, "same amount")
+		case diff <= h.Amount*0.01:
+			score += amountWeight / 2
+			c.Reasons = append(c.Reasons, "amount within 1%")
+		}
+	}
+
+	if !h.Date.IsZero() {
+		if date, ok := ledgerDate(l); ok {
+			days := math.Abs(date.Sub(h.Date).Hours() / 24)
+			if days <= MatchWindowDays {
+				score += dateWeight * (1 - days/(MatchWindowDays+1))
+				switch days {
+				case 0:
+					c.Reasons = append(c.Reasons, "same date")
+				case 1:
+					c.Reasons = append(c.Reasons, "1 day apart")
+				default:
+					c.Reasons = append(c.Reasons, strconv.Itoa(int(days))+" days apart")
+				}
+			}
+		}
+	}
+
+	if h.Vendor != "" {
+		if sim := similarity(h.Vendor, l.Contact.Name); sim > 0 {
+			score += vendorWeight * sim
+			if sim == 1 {
+				c.Reasons = append(c.Reasons, "same vendor")
+			} else {
+				c.Reasons = append(c.Reasons, "similar vendor")
+			}
+		}
+	}
+
+	c.Score = int(math.Round(score))
+	return c
+}
+
+// ledgerDate parses the date of a ledger entry.
+func ledgerDate(l api.Ledger) (time.Time, bool) {
+	if len(l.Date) < 10 {
+		return time.Time{}, false
+	}
+
+	date, err := time.Parse("2006-01-02", l.Date[:10])
+	return date, err == nil
+}
+
+// wordPattern splits names into lowercase comparable words.
+var wordPattern = regexp.MustCompile(`[a-z0-9]+`)
+
+// similarity compares two names from 0 to 1: 1 when one contains the other
+// (ignoring case, spaces and punctuation), otherwise the share of the shorter
+// name's words found in the longer one.
+func similarity(a string, b string) float64 {
+	wa := wordPattern.FindAllString(strings.ToLower(a), -1)
+	wb := wordPattern.FindAllString(strings.ToLower(b), -1)
+	if len(wa) == 0 || len(wb) == 0 {
+		return 0
+	}
+
+	ja, jb := strings.Join(wa, ""), strings.Join(wb, "")
+	if strings.Contains(ja, jb) || strings.Contains(jb, ja) {
+		return 1
+	}
+
+	if len(wa) > len(wb) {
+		wa, wb = wb, wa
+	}
+	words := map[string]bool{}
+	for _, w := range wb {
+		words[w] = true
+	}
+
+	common := 0
+	for _, w := range wa {
+		if words[w] {
+			common++
+		}
+	}
+
+	return float64(common) / float64(len(wa))
+}
+
+// Rank scores every ledger entry against the hints and returns those scoring
+// above zero, best first (ties broken by entry ID).
+func Rank(h Hints, ledgers []api.Ledger) []Candidate {
+	var ranked []Candidate
+	for _, l := range ledgers {
+		if c := Score(h, l); c.Score > 0 {
+			ranked = append(ranked, c)
+		}
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		return ranked[i].Ledger.ID < ranked[j].Ledger.ID
+	})
+
+	return ranked
+}
+
+// Unattached returns the files not attached to any of the ledger entries.
+func Unattached(files []api.File, ledgers []api.Ledger) []api.File {
+	attached := map[uint]bool{}
+	for _, l := range ledgers {
+		for _, f := range l.Files {
+			attached[f.ID] = true
+		}
+	}
+
+	var list []api.File
+	for _, f := range files {
+		if !attached[f.ID] {
+			list = append(list, f)
+		}
+	}
+
+	return list
+}
@@ -0,0 +1,84 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package receipts
+
+import (
+	"testing"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+)
+
+// TestParseFileName verifies dates, amounts and vendors are read from common naming schemes.
+func TestParseFileName(t *testing.T) {
+	tests := []struct {
+		name   string
+		date   string
+		amount float64
+		vendor string
+	}{
+		{"2026-03-03_acme-office_43.20.pdf", "2026-03-03", 43.20, "acme office"},
+		{"Home Depot 03.14.2026 $1204,50.jpg", "2026-03-14", 1204.50, "home depot"},
+		{"receipt-20260201-github.png", "2026-02-01", 0, "github"},
+		{"IMG_4021.jpg", "", 0, ""},
+	}
+
+	for _, tt := range tests {
+		h := ParseFileName(tt.name)
+
+		date := ""
+		if !h.Date.IsZero() {
+			date = h.Date.Format("2006-01-02")
+		}
+		if date != tt.date || h.Amount != tt.amount || h.Vendor != tt.vendor {
+			t.Errorf("ParseFileName(%q) = %s %.2f %q, want %s %.2f %q", tt.name, date, h.Amount, h.Vendor, tt.date, tt.amount, tt.vendor)
+		}
+	}
+}
+
+// TestParseText verifies receipt text yields the vendor line, the date and the total.
+func TestParseText(t *testing.T) {
+	text := "\n  ACME Office Supply\n123 Main St\nMarch 3, 2026\nSubtotal 40.00\nTotal $43.20\n"
+
+	h := ParseText(text)
+	if h.Vendor != "ACME Office Supply" || h.Amount != 43.20 || h.Date.Format("2006-01-02") != "2026-03-03" {
+		t.Errorf("ParseText() = %+v", h)
+	}
+
+	merged := ParseFileName("scan.pdf").Merge(h)
+	if merged != h {
+		t.Errorf("Merge() = %+v, want the text hints", merged)
+	}
+}
+
+// TestRank verifies the entry agreeing on amount, date and vendor ranks first
+// and unrelated entries are dropped.
+func TestRank(t *testing.T) {
+	ledgers := []api.Ledger{
+		{ID: 1, Amount: -43.20, Date: "2026-03-20T00:00:00Z", Contact: api.Contact{Name: "Staples"}},
+		{ID: 2, Amount: -43.20, Date: "2026-03-04T00:00:00Z", Contact: api.Contact{Name: "Acme Office, Inc."}},
+		{ID: 3, Amount: 900, Date: "2025-01-01T00:00:00Z", Contact: api.Contact{Name: "Client"}},
+	}
+
+	ranked := Rank(ParseFileName("2026-03-03_acme-office_43.20.pdf"), ledgers)
+	if len(ranked) != 2 {
+		t.Fatalf("Rank() returned %d candidates, want 2: %+v", len(ranked), ranked)
+	}
+	if ranked[0].Ledger.ID != 2 || ranked[0].Score < 90 {
+		t.Errorf("best = entry %d score %d, want entry 2 scoring 90+", ranked[0].Ledger.ID, ranked[0].Score)
+	}
+	if ranked[1].Ledger.ID != 1 || ranked[1].Score != amountWeight {
+		t.Errorf("second = entry %d score %d, want entry 1 on amount alone", ranked[1].Ledger.ID, ranked[1].Score)
+	}
+}
+
+// TestUnattached verifies files linked to any entry are excluded.
+func TestUnattached(t *testing.T) {
+	files := []api.File{{ID: 1}, {ID: 2}, {ID: 3}}
+	ledgers := []api.Ledger{{ID: 10, Files: []api.File{{ID: 2}}}}
+
+	got := Unattached(files, ledgers)
+	if len(got) != 2 || got[0].ID != 1 || got[1].ID != 3 {
+		t.Errorf("Unattached() = %+v, want files 1 and 3", got)
+	}
+}
@@ -0,0 +1,252 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package receipts
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+)
+
+// Optimization defaults.
+const (
+	DefaultMaxDimension = 2000
+	DefaultQuality      = 80
+)
+
+// OptimizeOptions controls image preprocessing before upload.
+type OptimizeOptions struct {
+	MaxDimension int
+	Quality      int
+	Grayscale    bool
+}
+
+// OptimizeResult describes what preprocessing did to one file.
+type OptimizeResult struct {
+	Path          string `json:"path"`
+	Format        string `json:"format,omitempty"`
+	OriginalSize  int64  `json:"original_size"`
+	OptimizedSize int64  `json:"optimized_size"`
+	Width         int    `json:"width,omitempty"`
+	Height        int    `json:"height,omitempty"`
+	Rotated       bool   `json:"rotated,omitempty"`
+	StrippedGPS   bool   `json:"stripped_gps,omitempty"`
+	Skipped       string `json:"skipped,omitempty"`
+}
+
+// Optimize decodes a JPEG or PNG, applies its EXIF orientation, downscales it
+// to fit MaxDimension, optionally converts it to grayscale and re-encodes it.
+// Re-encoding drops all metadata, including GPS. The result is written under
+// the original file name inside a new temporary directory, which the caller
+// removes. Other formats (PDF, HEIC, ...) are left alone: out is "" and
+// Skipped says why.
+func Optimize(src string, opts OptimizeOptions) (string, OptimizeResult, error) {
+	res := OptimizeResult{Path: src}
+
+	if opts.MaxDimension <= 0 {
+		opts.MaxDimension = DefaultMaxDimension
+	}
+	if opts.Quality <= 0 || opts.Quality > 100 {
+		opts.Quality = DefaultQuality
+	}
+
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return "", res, fmt.Errorf("unable to read file: %w", err)
+	}
+	res.OriginalSize = int64(len(data))
+
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		res.Skipped = "not a JPEG or PNG image"
+		return "", res, nil
+	}
+	res.Format = format
+
+	exif := exifInfo{Orientation: 1}
+	if format == "jpeg" {
+		exif = readEXIF(data)
+	}
+
+	pixels := orient(toNRGBA(img), exif.Orientation)
+	res.Rotated = exif.Orientation > 1
+	res.StrippedGPS = exif.HasGPS
+
+	resized := downscale(pixels, opts.MaxDimension)
+
+	var final image.Image = resized
+	if opts.Grayscale {
+		gray := image.NewGray(resized.Bounds())
+		draw.Draw(gray, gray.Bounds(), resized, resized.Bounds().Min, draw.Src)
+		final = gray
+	}
+
+	var out bytes.Buffer
+	if format == "png" {
+		encoder := png.Encoder{CompressionLevel: png.BestCompression}
+		err = encoder.Encode(&out, final)
+	} else {
+		err = jpeg.Encode(&out, final, &jpeg.Options{Quality: opts.Quality})
+	}
+	if err != nil {
+		return "", res, fmt.Errorf("unable to encode image: %w", err)
+	}
+
+	// Nothing to fix and nothing saved: keep the original bytes.
+	changed := res.Rotated || exif.Present || opts.Grayscale || resized != pixels
+	if !changed && int64(out.Len()) >= res.OriginalSize {
+		res.Skipped = "already optimized"
+		return "", res, nil
+	}
+
+	dir, err := os.MkdirTemp("", "skyclerk-optimize-")
+	if err != nil {
+		return "", res, fmt.Errorf("unable to create temp directory: %w", err)
+	}
+
+	dest := filepath.Join(dir, filepath.Base(src))
+	if err := os.WriteFile(dest, out.Bytes(), 0600); err != nil {
+		os.RemoveAll(dir)
+		return "", res, fmt.Errorf("unable to write optimized image: %w", err)
+	}
+
+	b := final.Bounds()
+	res.Width, res.Height = b.Dx(), b.Dy()
+	res.OptimizedSize = int64(out.Len())
+
+	return dest, res, nil
+}
+
+// toNRGBA converts any image to a zero-origin NRGBA.
+func toNRGBA(img image.Image) *image.NRGBA {
+	b := img.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(dst, dst.Bounds(), img, b.Min, draw.Src)
+
+	return dst
+}
+
+// orient applies an EXIF orientation (1-8) so the image displays upright.
+func orient(src *image.NRGBA, orientation int) *image.NRGBA {
+	if orientation <= 1 || orientation > 8 {
+		return src
+	}
+
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2:
+				sx, sy = w-1-x, y
+			case 3:
+				sx, sy = w-1-x, h-1-y
+			case 4:
+				sx, sy = x, h-1-y
+			case 5:
+				sx, sy = y, x
+			case 6:
+				sx, sy = y, h-1-x
+			case 7:
+				sx, sy = w-1-y, h-1-x
+			case 8:
+				sx, sy = w-1-y, x
+			}
+
+			si := sy*src.Stride + sx*4
+			di := y*dst.Stride + x*4
+			copy(dst.Pix[di:di+4], src.Pix[si:si+4])
+		}
+	}
+
+	return dst
+}
+
+// downscale shrinks an image so neither side exceeds max, averaging each
+// block of source pixels (a box filter). Smaller images are returned as-is.
+func downscale(src *image.NRGBA, max int) *image.NRGBA {
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	if w <= max && h <= max {
+		return src
+	}
+
+	dw, dh := max, h*max/w
+	if h > w {
+		dw, dh = w*max/h, max
+	}
+	if dw < 1 {
+		dw = 1
+	}
+	if dh < 1 {
+		dh = 1
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		y0, y1 := y*h/dh, (y+1)*h/dh
+		for x := 0; x < dw; x++ {
+			x0, x1 := x*w/dw, (x+1)*w/dw
+
+			var r, g, b, a, n int
+			for sy := y0; sy < y1; sy++ {
+				row := sy * src.Stride
+				for sx := x0; sx < x1; sx++ {
+					i := row + sx*4
+					r += int(src.Pix[i])
+					g += int(src.Pix[i+1])
+					b += int(src.Pix[i+2])
+					a += int(src.Pix[i+3])
+					n++
+				}
+			}
+
+			di := y*dst.Stride + x*4
+			dst.Pix[di] = uint8(r / n)
+			dst.Pix[di+1] = uint8(g / n)
+			dst.Pix[di+2] = uint8(b / n)
+			dst.Pix[di+3] = uint8(a / n)
+		}
+	}
+
+	return dst
+}
+
+// OptimizingUploader optimizes images before handing them to the next uploader.
+type OptimizingUploader struct {
+	Next       Uploader
+	Options    OptimizeOptions
+	OnOptimize func(OptimizeResult)
+}
+
+// UploadFile optimizes the file (when it is a supported image) and uploads the result.
+func (o *OptimizingUploader) UploadFile(filePath string, ledgerID string, opts ...api.UploadOption) (*api.File, error) {
+	out, res, err := Optimize(filePath, o.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	if o.OnOptimize != nil {
+		o.OnOptimize(res)
+	}
+
+	if out == "" {
+		return o.Next.UploadFile(filePath, ledgerID, opts...)
+	}
+	defer os.RemoveAll(filepath.Dir(out))
+
+	return o.Next.UploadFile(out, ledgerID, opts...)
+}
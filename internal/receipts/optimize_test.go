@@ -0,0 +1,154 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package receipts
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// exifSegment builds an APP1 EXIF segment with an orientation and a GPS pointer.
+func exifSegment(orientation uint16) []byte {
+	var tiff bytes.Buffer
+	tiff.WriteString("MM")
+	binary.Write(&tiff, binary.BigEndian, uint16(42))
+	binary.Write(&tiff, binary.BigEndian, uint32(8))
+	binary.Write(&tiff, binary.BigEndian, uint16(2))
+	binary.Write(&tiff, binary.BigEndian, []uint16{exifTagOrientation, 3})
+	binary.Write(&tiff, binary.BigEndian, uint32(1))
+	binary.Write(&tiff, binary.BigEndian, []uint16{orientation, 0})
+	binary.Write(&tiff, binary.BigEndian, []uint16{exifTagGPSInfo, 4})
+	binary.Write(&tiff, binary.BigEndian, []uint32{1, 0})
+	binary.Write(&tiff, binary.BigEndian, uint32(0))
+
+	payload := append([]byte("Exif\x00\x00"), tiff.Bytes()...)
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+
+	return append(segment, payload...)
+}
+
+// writeJPEG writes a w x h JPEG, red on the left half, with an optional EXIF segment.
+func writeJPEG(t *testing.T, path string, w int, h int, exif []byte) {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c := color.RGBA{0, 0, 255, 255}
+			if x < w/2 {
+				c = color.RGBA{255, 0, 0, 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+
+	var buf bytes.Buffer
+	jpeg.Encode(&buf, img, &jpeg.Options{Quality: 95})
+	data := buf.Bytes()
+	if exif != nil {
+		data = append(append([]byte{0xFF, 0xD8}, exif...), data[2:]...)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+}
+
+// TestReadEXIF verifies orientation and GPS detection.
+func TestReadEXIF(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "r.jpg")
+	writeJPEG(t, path, 4, 2, exifSegment(6))
+	data, _ := os.ReadFile(path)
+
+	info := readEXIF(data)
+	if !info.Present || info.Orientation != 6 || !info.HasGPS {
+		t.Errorf("readEXIF() = %+v, want orientation 6 with GPS", info)
+	}
+
+	if plain := readEXIF([]byte("%PDF-1.4")); plain.Present || plain.Orientation != 1 {
+		t.Errorf("readEXIF(pdf) = %+v", plain)
+	}
+}
+
+// TestOptimizeRotatesScalesAndStrips verifies orientation is applied, the image
+// is downscaled and the EXIF block (with GPS) is gone from the output.
+func TestOptimizeRotatesScalesAndStrips(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "phone.jpg")
+	writeJPEG(t, path, 400, 200, exifSegment(6))
+
+	out, res, err := Optimize(path, OptimizeOptions{MaxDimension: 100, Quality: 70})
+	if err != nil {
+		t.Fatalf("Optimize() error = %v", err)
+	}
+	defer os.RemoveAll(filepath.Dir(out))
+
+	if filepath.Base(out) != "phone.jpg" {
+		t.Errorf("output name = %s, want phone.jpg", filepath.Base(out))
+	}
+	if !res.Rotated || !res.StrippedGPS || res.Width != 50 || res.Height != 100 {
+		t.Errorf("result = %+v, want rotated 50x100 with GPS stripped", res)
+	}
+
+	data, _ := os.ReadFile(out)
+	if readEXIF(data).Present {
+		t.Error("optimized image still has EXIF data")
+	}
+
+	img, err := jpeg.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("decode output error = %v", err)
+	}
+
+	// Rotating 90 degrees clockwise puts the red left half on top.
+	r, _, b, _ := img.At(25, 10).RGBA()
+	if r < b {
+		t.Errorf("top of rotated image is not red: r=%d b=%d", r>>8, b>>8)
+	}
+}
+
+// TestOptimizeGrayscalePNG verifies PNGs stay PNGs and can be converted to grayscale.
+func TestOptimizeGrayscalePNG(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scan.png")
+	img := image.NewNRGBA(image.Rect(0, 0, 20, 10))
+	for i := range img.Pix {
+		img.Pix[i] = 200
+	}
+	f, _ := os.Create(path)
+	png.Encode(f, img)
+	f.Close()
+
+	out, res, err := Optimize(path, OptimizeOptions{Grayscale: true})
+	if err != nil {
+		t.Fatalf("Optimize() error = %v", err)
+	}
+	defer os.RemoveAll(filepath.Dir(out))
+
+	data, _ := os.ReadFile(out)
+	decoded, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil || format != "png" || res.Format != "png" {
+		t.Fatalf("decode output = %s, %v", format, err)
+	}
+	if _, ok := decoded.(*image.Gray); !ok {
+		t.Errorf("decoded image is %T, want *image.Gray", decoded)
+	}
+}
+
+// TestOptimizeSkipsOtherFormats verifies non-images are passed through untouched.
+func TestOptimizeSkipsOtherFormats(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "invoice.pdf")
+	os.WriteFile(path, []byte("%PDF-1.4 fake"), 0644)
+
+	out, res, err := Optimize(path, OptimizeOptions{})
+	if err != nil || out != "" || res.Skipped == "" {
+		t.Errorf("Optimize(pdf) = %q, %+v, %v; want skipped", out, res, err)
+	}
+}
@@ -0,0 +1,353 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package receipts
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Watch folder layout and defaults.
+const (
+	UploadedDir       = "uploaded"
+	FailedDir         = "failed"
+	WatchManifestName = ".skyclerk-watch.json"
+	DefaultInterval   = 5 * time.Second
+	DefaultStableFor  = 2 * time.Second
+)
+
+// partialSuffixes mark files that are still being written by another program.
+var partialSuffixes = []string{".part", ".partial", ".tmp", ".crdownload", ".download"}
+
+// WatchEntry records one file uploaded from a watched directory.
+type WatchEntry struct {
+	Name       string    `json:"name"`
+	SHA256     string    `json:"sha256"`
+	FileID     uint      `json:"file_id"`
+	LedgerID   string    `json:"ledger_id,omitempty"`
+	UploadedAt time.Time `json:"uploaded_at"`
+}
+
+// WatchManifest is the on-disk record of uploads from a watched directory,
+// keyed by content hash so restarts never upload the same file twice.
+type WatchManifest struct {
+	path    string
+	Entries map[string]WatchEntry `json:"entries"`
+}
+
+// LoadWatchManifest reads the manifest in dir, returning an empty one if none exists.
+func LoadWatchManifest(dir string) (*WatchManifest, error) {
+	m := &WatchManifest{path: filepath.Join(dir, WatchManifestName), Entries: map[string]WatchEntry{}}
+
+	data, err := os.ReadFile(m.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return m, nil
+		}
+		return nil, fmt.Errorf("unable to read watch manifest: %w", err)
+	}
+
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("unable to parse watch manifest: %w", err)
+	}
+	if m.Entries == nil {
+		m.Entries = map[string]WatchEntry{}
+	}
+
+	return m, nil
+}
+
+// Save writes the manifest atomically.
+func (m *WatchManifest) Save() error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to marshal watch manifest: %w", err)
+	}
+
+	tmp := m.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("unable to write watch manifest: %w", err)
+	}
+
+	if err := os.Rename(tmp, m.path); err != nil {
+		return fmt.Errorf("unable to write watch manifest: %w", err)
+	}
+
+	return nil
+}
+
+// WatchEvent reports what happened to one file in a watched directory.
+type WatchEvent struct {
+	Path      string `json:"path"`
+	Status    string `json:"status"`
+	FileID    uint   `json:"file_id,omitempty"`
+	LedgerID  string `json:"ledger_id,omitempty"`
+	MovedTo   string `json:"moved_to"`
+	Error     string `json:"error,omitempty"`
+	Warning   string `json:"warning,omitempty"`
+	Duplicate bool   `json:"duplicate,omitempty"`
+}
+
+// fileState is the last observed size and modification time of a pending file.
+type fileState struct {
+	size    int64
+	modTime time.Time
+	since   time.Time
+}
+
+// Watcher polls a directory for new receipts and uploads each one once it
+// has stopped changing. Uploaded files move to uploaded/, failures to failed/
+// alongside a .error.txt note.
+type Watcher struct {
+	Dir       string
+	Uploader  Uploader
+	LedgerID  string
+	Interval  time.Duration
+	StableFor time.Duration
+	OnEvent   func(WatchEvent)
+
+	mu       sync.Mutex
+	manifest *WatchManifest
+	pending  map[string]fileState
+	now      func() time.Time
+}
+
+// NewWatcher creates a watcher for dir, creating the uploaded/ and failed/
+// folders and loading the manifest.
+func NewWatcher(dir string, u Uploader) (*Watcher, error) {
+	for _, sub := range []string{UploadedDir, FailedDir} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0755); err != nil {
+			return nil, fmt.Errorf("unable to create %s folder: %w", sub, err)
+		}
+	}
+
+	manifest, err := LoadWatchManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Watcher{
+		Dir:       dir,
+		Uploader:  u,
+		Interval:  DefaultInterval,
+		StableFor: DefaultStableFor,
+		manifest:  manifest,
+		pending:   map[string]fileState{},
+		now:       time.Now,
+	}, nil
+}
+
+// Run polls until ctx is cancelled.
+func (w *Watcher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.Poll(); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll scans the directory once and processes every file that has been
+// stable for at least StableFor.
+func (w *Watcher) Poll() error {
+	return w.scan(false)
+}
+
+// Scan processes every file last modified at least StableFor ago in a single
+// pass, for runs that can't wait to see whether a file changes. Newer files may
+// still be being written, so they are left for a later run.
+func (w *Watcher) Scan() error {
+	return w.scan(true)
+}
+
+// scan processes every file that has been stable for at least StableFor. A
+// file first seen in this pass counts as unchanged since now, or since its
+// modification time when byModTime is set.
+func (w *Watcher) scan(byModTime bool) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	entries, err := os.ReadDir(w.Dir)
+	if err != nil {
+		return fmt.Errorf("unable to read directory: %w", err)
+	}
+
+	now := w.now()
+	present := map[string]bool{}
+
+	for _, e := range entries {
+		path := filepath.Join(w.Dir, e.Name())
+		if !e.Type().IsRegular() || skipFile(path) || isPartial(path) {
+			continue
+		}
+
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		present[path] = true
+
+		state, ok := w.pending[path]
+		if !ok || state.size != info.Size() || !state.modTime.Equal(info.ModTime()) {
+			state = fileState{size: info.Size(), modTime: info.ModTime(), since: now}
+			if byModTime {
+				state.since = info.ModTime()
+			}
+			w.pending[path] = state
+		}
+
+		if now.Sub(state.since) < w.StableFor {
+			continue
+		}
+
+		delete(w.pending, path)
+		w.emit(w.process(path))
+	}
+
+	// Forget files that disappeared before they became stable.
+	for path := range w.pending {
+		if !present[path] {
+			delete(w.pending, path)
+		}
+	}
+
+	return nil
+}
+
+// process uploads one stable file, or skips the upload when the manifest shows
+// the same content was already sent, then files it away.
+func (w *Watcher) process(path string) WatchEvent {
+	event := WatchEvent{Path: path}
+
+	fail := func(err error) WatchEvent {
+		event.Status = "failed"
+		event.Error = strings.TrimSpace(err.Error())
+		return w.file(event, FailedDir, err)
+	}
+
+	ledgerID, err := ReadSidecar(path)
+	if err != nil {
+		return fail(err)
+	}
+	if ledgerID == "" {
+		ledgerID = w.LedgerID
+	}
+	event.LedgerID = ledgerID
+
+	hash, err := HashFile(path)
+	if err != nil {
+		return fail(err)
+	}
+
+	// A crash between upload and move leaves the file behind; don't send it again.
+	if entry, ok := w.manifest.Entries[hash]; ok {
+		event.Status = "skipped"
+		event.Duplicate = true
+		event.FileID = entry.FileID
+		return w.file(event, UploadedDir, nil)
+	}
+
+	file, err := w.Uploader.UploadFile(path, ledgerID)
+	var dup *DuplicateError
+	if errors.As(err, &dup) {
+		event.Status = "skipped"
+		event.Duplicate = true
+		event.FileID = dup.Existing.FileID
+		return w.file(event, UploadedDir, nil)
+	}
+	if err != nil {
+		return fail(err)
+	}
+	event.FileID = file.ID
+
+	w.manifest.Entries[hash] = WatchEntry{
+		Name:       filepath.Base(path),
+		SHA256:     hash,
+		FileID:     file.ID,
+		LedgerID:   ledgerID,
+		UploadedAt: w.now().UTC(),
+	}
+	// The file is uploaded either way, so a manifest that can't be saved only
+	// means a copy left behind after a crash could be sent again.
+	if err := w.manifest.Save(); err != nil {
+		event.Warning = strings.TrimSpace(err.Error())
+	}
+
+	event.Status = "uploaded"
+	return w.file(event, UploadedDir, nil)
+}
+
+// file moves an event's file (and its sidecar) into sub, writing an error
+// note next to it when cause is set.
+func (w *Watcher) file(event WatchEvent, sub string, cause error) WatchEvent {
+	dest := uniquePath(filepath.Join(w.Dir, sub, filepath.Base(event.Path)))
+
+	if err := os.Rename(event.Path, dest); err != nil {
+		event.Status = "failed"
+		event.Error = fmt.Sprintf("unable to move file: %v", err)
+		return event
+	}
+	event.MovedTo = dest
+
+	if _, err := os.Stat(event.Path + SidecarExt); err == nil {
+		os.Rename(event.Path+SidecarExt, dest+SidecarExt)
+	}
+
+	if cause != nil {
+		note := fmt.Sprintf("%s\n%s\n", w.now().UTC().Format(time.RFC3339), strings.TrimSpace(cause.Error()))
+		os.WriteFile(dest+".error.txt", []byte(note), 0644)
+	}
+
+	return event
+}
+
+// emit reports an event to OnEvent if set.
+func (w *Watcher) emit(event WatchEvent) {
+	if w.OnEvent != nil {
+		w.OnEvent(event)
+	}
+}
+
+// isPartial reports whether a file looks like an in-progress download or copy.
+func isPartial(path string) bool {
+	lower := strings.ToLower(path)
+	for _, suffix := range partialSuffixes {
+		if strings.HasSuffix(lower, suffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// uniquePath returns path, or path with a numeric suffix if it already exists.
+func uniquePath(path string) string {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return path
+	}
+
+	ext := filepath.Ext(path)
+	base := strings.TrimSuffix(path, ext)
+	for i := 1; ; i++ {
+		candidate := fmt.Sprintf("%s-%d%s", base, i, ext)
+		if _, err := os.Stat(candidate); os.IsNotExist(err) {
+			return candidate
+		}
+	}
+}
@@ -0,0 +1,173 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package receipts
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestWatcher creates a watcher over a temp directory with a controllable clock.
+func newTestWatcher(t *testing.T) (*Watcher, *fakeUploader, *time.Time, *[]WatchEvent) {
+	t.Helper()
+
+	u := &fakeUploader{uploaded: map[string]string{}}
+	w, err := NewWatcher(t.TempDir(), u)
+	if err != nil {
+		t.Fatalf("NewWatcher() error = %v", err)
+	}
+
+	clock := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	w.now = func() time.Time { return clock }
+
+	var events []WatchEvent
+	w.OnEvent = func(e WatchEvent) { events = append(events, e) }
+
+	return w, u, &clock, &events
+}
+
+// TestWatcherWaitsForStableFiles verifies files are only uploaded once unchanged
+// for StableFor, and partial downloads are ignored.
+func TestWatcherWaitsForStableFiles(t *testing.T) {
+	w, u, clock, events := newTestWatcher(t)
+	writeFiles(t, w.Dir, map[string]string{"a.jpg": "a", "b.jpg.part": "b"})
+
+	w.Poll()
+	if len(*events) != 0 {
+		t.Fatalf("events after first poll = %d, want 0", len(*events))
+	}
+
+	// The file grows, which restarts the stability window.
+	*clock = clock.Add(time.Second)
+	writeFiles(t, w.Dir, map[string]string{"a.jpg": "aa"})
+	w.Poll()
+	*clock = clock.Add(time.Second)
+	w.Poll()
+	if len(*events) != 0 {
+		t.Fatalf("events while file changing = %d, want 0", len(*events))
+	}
+
+	*clock = clock.Add(2 * time.Second)
+	w.Poll()
+	if len(*events) != 1 || (*events)[0].Status != "uploaded" {
+		t.Fatalf("events = %+v, want one upload", *events)
+	}
+
+	if len(u.uploaded) != 1 {
+		t.Errorf("uploaded = %v, want only a.jpg", u.uploaded)
+	}
+	if _, err := os.Stat(filepath.Join(w.Dir, UploadedDir, "a.jpg")); err != nil {
+		t.Errorf("a.jpg not moved to uploaded/: %v", err)
+	}
+}
+
+// TestWatcherScanSkipsRecentFiles verifies a single scan uploads files
+// modified at least StableFor ago and leaves newer ones in place.
+func TestWatcherScanSkipsRecentFiles(t *testing.T) {
+	w, u, clock, events := newTestWatcher(t)
+	writeFiles(t, w.Dir, map[string]string{"old.jpg": "old", "new.jpg": "new"})
+	os.Chtimes(filepath.Join(w.Dir, "old.jpg"), *clock, clock.Add(-time.Minute))
+	os.Chtimes(filepath.Join(w.Dir, "new.jpg"), *clock, clock.Add(-time.Second))
+
+	if err := w.Scan(); err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+
+	if len(*events) != 1 || filepath.Base((*events)[0].Path) != "old.jpg" || (*events)[0].Status != "uploaded" {
+		t.Fatalf("events = %+v, want only old.jpg uploaded", *events)
+	}
+	if len(u.uploaded) != 1 {
+		t.Errorf("uploaded = %v, want only old.jpg", u.uploaded)
+	}
+	if _, err := os.Stat(filepath.Join(w.Dir, "new.jpg")); err != nil {
+		t.Errorf("new.jpg should be left for the next run: %v", err)
+	}
+}
+
+// TestWatcherFailuresAndSidecars verifies failures land in failed/ with a note
+// and sidecar ledger IDs are used and moved with their file.
+func TestWatcherFailuresAndSidecars(t *testing.T) {
+	w, u, _, events := newTestWatcher(t)
+	w.StableFor = 0
+	w.LedgerID = "5"
+	writeFiles(t, w.Dir, map[string]string{
+		"bad.jpg":         "x",
+		"good.jpg":        "y",
+		"good.jpg.ledger": "12",
+		"other.pdf":       "z",
+	})
+
+	w.Poll()
+	if len(*events) != 3 {
+		t.Fatalf("events = %+v, want 3", *events)
+	}
+
+	if u.uploaded[filepath.Join(w.Dir, "good.jpg")] != "12" || u.uploaded[filepath.Join(w.Dir, "other.pdf")] != "5" {
+		t.Errorf("uploaded = %v", u.uploaded)
+	}
+
+	note, err := os.ReadFile(filepath.Join(w.Dir, FailedDir, "bad.jpg.error.txt"))
+	if err != nil || !strings.Contains(string(note), "upload rejected") {
+		t.Errorf("error note = %q, %v", note, err)
+	}
+
+	if _, err := os.Stat(filepath.Join(w.Dir, UploadedDir, "good.jpg.ledger")); err != nil {
+		t.Errorf("sidecar not moved: %v", err)
+	}
+}
+
+// TestWatcherManifestSaveFailure verifies an upload whose manifest entry
+// can't be saved is still filed under uploaded/, with a warning.
+func TestWatcherManifestSaveFailure(t *testing.T) {
+	w, _, _, events := newTestWatcher(t)
+	w.StableFor = 0
+
+	// A directory where the manifest's temp file goes makes Save fail.
+	if err := os.Mkdir(filepath.Join(w.Dir, WatchManifestName+".tmp"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFiles(t, w.Dir, map[string]string{"r.jpg": "receipt"})
+	w.Poll()
+
+	if len(*events) != 1 || (*events)[0].Status != "uploaded" || (*events)[0].Warning == "" {
+		t.Fatalf("events = %+v, want one upload with a warning", *events)
+	}
+	if _, err := os.Stat(filepath.Join(w.Dir, UploadedDir, "r.jpg")); err != nil {
+		t.Errorf("r.jpg not moved to uploaded/: %v", err)
+	}
+}
+
+// TestWatcherManifestPreventsReupload verifies a restarted watcher skips files
+// whose content was already uploaded.
+func TestWatcherManifestPreventsReupload(t *testing.T) {
+	w, u, _, _ := newTestWatcher(t)
+	w.StableFor = 0
+	writeFiles(t, w.Dir, map[string]string{"r.jpg": "receipt"})
+	w.Poll()
+
+	// Simulate a crash before the move: the same content reappears.
+	restarted, err := NewWatcher(w.Dir, u)
+	if err != nil {
+		t.Fatalf("NewWatcher() error = %v", err)
+	}
+	restarted.StableFor = 0
+	var events []WatchEvent
+	restarted.OnEvent = func(e WatchEvent) { events = append(events, e) }
+
+	writeFiles(t, w.Dir, map[string]string{"r.jpg": "receipt"})
+	restarted.Poll()
+
+	if len(events) != 1 || events[0].Status != "skipped" || !events[0].Duplicate {
+		t.Fatalf("events = %+v, want one skipped duplicate", events)
+	}
+	if len(u.uploaded) != 1 {
+		t.Errorf("uploaded = %d files, want 1", len(u.uploaded))
+	}
+	if _, err := os.Stat(filepath.Join(w.Dir, UploadedDir, "r-1.jpg")); err != nil {
+		t.Errorf("duplicate not moved with unique name: %v", err)
+	}
+}
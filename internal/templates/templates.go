@@ -0,0 +1,177 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package templates
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+)
+
+// namePattern is what a template name may look like, such as "rent" or "aws-monthly".
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Ref names a contact, category or label by ID, with the name it had when the
+// template was saved.
+type Ref struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+// Template is a saved ledger entry that new entries start from. Every entry
+// made from it gets its own date.
+type Template struct {
+	Name     string    `json:"name"`
+	Amount   *float64  `json:"amount,omitempty"` // nil when each entry gives its own
+	Contact  Ref       `json:"contact"`
+	Category Ref       `json:"category"`
+	Labels   []Ref     `json:"labels,omitempty"`
+	Note     string    `json:"note,omitempty"`
+	Saved    time.Time `json:"saved"`
+}
+
+// Store is the local per-account set of templates, keyed by lowercase name.
+type Store struct {
+	path      string
+	Templates map[string]Template `json:"templates"`
+}
+
+// ValidName reports a problem with a template name, or nil.
+func ValidName(name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("invalid template name %q: use letters, digits, '.', '-' and '_'", name)
+	}
+
+	return nil
+}
+
+// FromLedger returns a template with a ledger entry's amount, contact,
+// category, labels and note.
+func FromLedger(name string, l api.Ledger) Template {
+	t := Template{
+		Name:     name,
+		Amount:   api.Ptr(l.Amount),
+		Contact:  Ref{ID: l.Contact.ID, Name: l.Contact.Name},
+		Category: Ref{ID: l.Category.ID, Name: l.Category.Name},
+		Note:     l.Note,
+	}
+	for _, label := range l.Labels {
+		t.Labels = append(t.Labels, Ref{ID: label.ID, Name: label.Name})
+	}
+
+	return t
+}
+
+// LabelIDs returns the IDs of the template's labels.
+func (t Template) LabelIDs() []uint {
+	ids := make([]uint, len(t.Labels))
+	for i, l := range t.Labels {
+		ids[i] = l.ID
+	}
+
+	return ids
+}
+
+// LabelNames returns the names of the template's labels.
+func (t Template) LabelNames() []string {
+	names := make([]string, len(t.Labels))
+	for i, l := range t.Labels {
+		names[i] = l.Name
+	}
+
+	return names
+}
+
+// Load reads a template file, returning an empty store if it does not exist.
+func Load(path string) (*Store, error) {
+	s := &Store{path: path, Templates: map[string]Template{}}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, fmt.Errorf("unable to read templates: %w", err)
+	}
+
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("unable to parse templates: %w", err)
+	}
+	if s.Templates == nil {
+		s.Templates = map[string]Template{}
+	}
+
+	return s, nil
+}
+
+// Get returns a template by name, ignoring case.
+func (s *Store) Get(name string) (Template, bool) {
+	t, ok := s.Templates[strings.ToLower(name)]
+	return t, ok
+}
+
+// List returns every template sorted by name.
+func (s *Store) List() []Template {
+	list := make([]Template, 0, len(s.Templates))
+	for _, t := range s.Templates {
+		list = append(list, t)
+	}
+	sort.Slice(list, func(i, j int) bool { return strings.ToLower(list[i].Name) < strings.ToLower(list[j].Name) })
+
+	return list
+}
+
+// Put adds or replaces a template and saves the file.
+func (s *Store) Put(t Template) error {
+	if err := ValidName(t.Name); err != nil {
+		return err
+	}
+	if t.Saved.IsZero() {
+		t.Saved = time.Now().UTC()
+	}
+	s.Templates[strings.ToLower(t.Name)] = t
+
+	return s.save()
+}
+
+// Delete removes a template and saves the file. It returns false if there
+// was no such template.
+func (s *Store) Delete(name string) (bool, error) {
+	if _, ok := s.Get(name); !ok {
+		return false, nil
+	}
+	delete(s.Templates, strings.ToLower(name))
+
+	return true, s.save()
+}
+
+// save writes the templates atomically, readable only by the current user.
+func (s *Store) save() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return fmt.Errorf("unable to create template directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to marshal templates: %w", err)
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("unable to write templates: %w", err)
+	}
+
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("unable to write templates: %w", err)
+	}
+
+	return nil
+}
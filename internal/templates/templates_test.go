@@ -0,0 +1,75 @@
+// Date: 2026-10-18
+// Copyright (c) 2026. All rights reserved.
+
+package templates
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/cloudmanic/skyclerk-cli/internal/api"
+)
+
+// TestStore verifies templates survive a reload, are found by name in any
+// case and can be deleted.
+func TestStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "templates", "account.json")
+	s, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	rent := FromLedger("Rent", api.Ledger{
+		Amount:   -2000,
+		Contact:  api.Contact{ID: 4, Name: "Main Street Properties"},
+		Category: api.Category{ID: 6, Name: "Rent"},
+		Labels:   []api.Label{{ID: 3, Name: "tax-deductible"}},
+		Note:     "Office rent",
+	})
+	if err := s.Put(rent); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if err := s.Put(Template{Name: "aws", Contact: Ref{ID: 1}, Category: Ref{ID: 3}}); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	s, err = Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	got, ok := s.Get("rent")
+	if !ok {
+		t.Fatal("Get(rent) found nothing")
+	}
+	if *got.Amount != -2000 || got.Contact.ID != 4 || !reflect.DeepEqual(got.LabelIDs(), []uint{3}) || got.Saved.IsZero() {
+		t.Errorf("Get(rent) = %+v", got)
+	}
+	if list := s.List(); len(list) != 2 || list[0].Name != "aws" || list[1].Name != "Rent" {
+		t.Errorf("List() = %+v", list)
+	}
+
+	if ok, err := s.Delete("RENT"); !ok || err != nil {
+		t.Errorf("Delete(RENT) = %v, %v", ok, err)
+	}
+	if ok, _ := s.Delete("rent"); ok {
+		t.Error("Delete() removed a template twice")
+	}
+	if s, _ = Load(path); len(s.List()) != 1 {
+		t.Errorf("after delete List() = %+v", s.List())
+	}
+}
+
+// TestValidName verifies names that would be awkward to type are refused.
+func TestValidName(t *testing.T) {
+	for _, name := range []string{"rent", "aws-monthly", "payroll_2026", "v1.2"} {
+		if err := ValidName(name); err != nil {
+			t.Errorf("ValidName(%q) error = %v", name, err)
+		}
+	}
+	for _, name := range []string{"", "office rent", "-rent", "rent/2"} {
+		if ValidName(name) == nil {
+			t.Errorf("ValidName(%q) accepted an invalid name", name)
+		}
+	}
+}